@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/database/databaseOverlay"
+	"github.com/FactomProject/factomd/database/hybridDB"
+	"github.com/FactomProject/factomd/state"
+)
+
+const level string = "level"
+const bolt string = "bolt"
+
+func main() {
+	journalPtr := flag.String("journal", "", "Journal written by the node with -journaling")
+	configPtr := flag.String("config", "", "Config file of the node, for its identity and keys")
+	networkPtr := flag.String("network", "", "Network the node was on: MAIN, TEST or LOCAL")
+	dbPtr := flag.String("db", "Map", "Database to replay into, a COPY of the node's from when the journal started: Map, LDB or Bolt")
+	dbPathPtr := flag.String("dbpath", "", "Directory of the LDB or Bolt database, as LdbPath or BoltDBPath in the config file")
+	refPtr := flag.String("ref", "", "Reference database to compare the blocks with, usually the node's own")
+	refTypePtr := flag.String("reftype", level, "Type of the reference database: level or bolt")
+	flag.Parse()
+
+	if *journalPtr == "" || *refPtr == "" {
+		fmt.Println("Usage:")
+		fmt.Println("JournalReplay -journal journal.log -config factomd.conf -ref DBFileLocation [-reftype level/bolt] [-db LDB -dbpath copy]")
+		fmt.Println("Replays the journal deterministically and reports the first block that differs from the reference database")
+		os.Exit(1)
+	}
+
+	f, err := os.Open(*journalPtr)
+	if err != nil {
+		panic(err)
+	}
+	entries, err := state.ReadJournal(f)
+	f.Close()
+	if err != nil {
+		panic(err)
+	}
+
+	var ref interfaces.IDatabase
+	switch *refTypePtr {
+	case bolt:
+		ref = hybridDB.NewBoltMapHybridDB(nil, *refPtr)
+	case level:
+		ref, err = hybridDB.NewLevelMapHybridDB(*refPtr, false)
+		if err != nil {
+			panic(err)
+		}
+	default:
+		fmt.Println("-reftype should be `level` or `bolt`")
+		os.Exit(1)
+	}
+	defer ref.Close()
+
+	s := new(state.State)
+	s.LoadConfig(*configPtr, *networkPtr)
+	s.DBType = *dbPtr
+	if *dbPathPtr != "" {
+		s.LdbPath = *dbPathPtr
+		s.BoltDBPath = *dbPathPtr
+	}
+	s.Init()
+
+	report := s.ReplayJournal(entries, databaseOverlay.NewOverlay(ref))
+	fmt.Print(report.String())
+	if report.Divergence != nil {
+		os.Exit(2)
+	}
+}
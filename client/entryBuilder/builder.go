@@ -0,0 +1,180 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package entryBuilder makes the commit and reveal that put an entry on Factom.
+// Given the ExtIDs and content of an entry and an entry credit key, it derives the
+// chain ID of a new chain, works out the entry credits the entry costs, checks its
+// size, and signs the commit.  The commit is sent with commit-chain or commit-entry,
+// then the entry with reveal-chain or reveal-entry.
+package entryBuilder
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/FactomProject/factomd/common/entryBlock"
+	"github.com/FactomProject/factomd/common/entryCreditBlock"
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/common/primitives"
+	"github.com/FactomProject/factomd/util"
+)
+
+// A new chain costs this many entry credits more than its first entry
+const ChainCost = 10
+
+// Payloads are the commit and reveal of an entry
+type Payloads struct {
+	Commit interfaces.IECBlockEntry // A *CommitChain for a new chain, else a *CommitEntry
+	Entry  *entryBlock.Entry
+}
+
+// ChainID returns the ID of the chain whose first entry has these ExtIDs
+func ChainID(extIDs [][]byte) interfaces.IHash {
+	e := entryBlock.NewEntry()
+	for _, id := range extIDs {
+		e.ExtIDs = append(e.ExtIDs, primitives.ByteSlice{Bytes: id})
+	}
+	return entryBlock.NewChainID(e)
+}
+
+// NewEntry returns the entry, with an error if it is too large
+func NewEntry(chainID interfaces.IHash, extIDs [][]byte, content []byte) (*entryBlock.Entry, error) {
+	e := entryBlock.NewEntry()
+	e.ChainID = chainID
+	for _, id := range extIDs {
+		e.ExtIDs = append(e.ExtIDs, primitives.ByteSlice{Bytes: id})
+	}
+	e.Content = primitives.ByteSlice{Bytes: content}
+	if _, err := EntryCost(e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// EntryCost returns the entry credits it costs to commit the entry, one for each
+// KiB or part of one, not counting the header.  Entries over 10 KiB can't be committed.
+func EntryCost(e *entryBlock.Entry) (uint8, error) {
+	ext, err := e.MarshalExtIDsBinary()
+	if err != nil {
+		return 0, err
+	}
+	if len(ext) > 0x7FFF {
+		return 0, fmt.Errorf("The ExtIDs are %d bytes, which is too large", len(ext))
+	}
+	data, err := e.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	return util.EntryCost(data)
+}
+
+// ComposeChain returns the commit and reveal of a new chain with its first entry.
+// The chain ID is derived from the ExtIDs.  ts is the time of the commit, or now if nil.
+func ComposeChain(extIDs [][]byte, content []byte, key *primitives.PrivateKey, ts interfaces.Timestamp) (*Payloads, error) {
+	if len(extIDs) == 0 {
+		return nil, fmt.Errorf("The first entry of a chain must have ExtIDs")
+	}
+	chainID := ChainID(extIDs)
+	e, err := NewEntry(chainID, extIDs, content)
+	if err != nil {
+		return nil, err
+	}
+	cost, err := EntryCost(e)
+	if err != nil {
+		return nil, err
+	}
+
+	c := entryCreditBlock.NewCommitChain()
+	setMilliTime(c.MilliTime, ts)
+	c.ChainIDHash.SetBytes(primitives.DoubleSha(chainID.Bytes()))
+	c.Weld = e.GetWeldHash()
+	c.EntryHash = e.GetHash()
+	c.Credits = cost + ChainCost
+	if err := c.Sign(key.Key[:]); err != nil {
+		return nil, err
+	}
+	return &Payloads{Commit: c, Entry: e}, nil
+}
+
+// ComposeEntry returns the commit and reveal of an entry in an existing chain.
+// ts is the time of the commit, or now if nil.
+func ComposeEntry(chainID interfaces.IHash, extIDs [][]byte, content []byte, key *primitives.PrivateKey, ts interfaces.Timestamp) (*Payloads, error) {
+	e, err := NewEntry(chainID, extIDs, content)
+	if err != nil {
+		return nil, err
+	}
+	cost, err := EntryCost(e)
+	if err != nil {
+		return nil, err
+	}
+
+	c := entryCreditBlock.NewCommitEntry()
+	setMilliTime(c.MilliTime, ts)
+	c.EntryHash = e.GetHash()
+	c.Credits = cost
+	if err := c.Sign(key.Key[:]); err != nil {
+		return nil, err
+	}
+	return &Payloads{Commit: c, Entry: e}, nil
+}
+
+func setMilliTime(milliTime *primitives.ByteSlice6, ts interfaces.Timestamp) {
+	if ts == nil {
+		ts = primitives.NewTimestampNow()
+	}
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], ts.GetTimeMilliUInt64())
+	copy(milliTime[:], buf[2:])
+}
+
+// IsChain is true if the payloads make a new chain
+func (p *Payloads) IsChain() bool {
+	_, ok := p.Commit.(*entryCreditBlock.CommitChain)
+	return ok
+}
+
+// CommitHex returns the commit as commit-chain and commit-entry take it
+func (p *Payloads) CommitHex() (string, error) {
+	data, err := p.Commit.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(data), nil
+}
+
+// RevealHex returns the entry as reveal-chain and reveal-entry take it
+func (p *Payloads) RevealHex() (string, error) {
+	data, err := p.Entry.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(data), nil
+}
+
+// CommitRequest returns the commit-chain or commit-entry API call
+func (p *Payloads) CommitRequest(id interface{}) (*primitives.JSON2Request, error) {
+	str, err := p.CommitHex()
+	if err != nil {
+		return nil, err
+	}
+	method := "commit-entry"
+	if p.IsChain() {
+		method = "commit-chain"
+	}
+	return primitives.NewJSON2Request(method, id, map[string]string{"message": str}), nil
+}
+
+// RevealRequest returns the reveal-chain or reveal-entry API call
+func (p *Payloads) RevealRequest(id interface{}) (*primitives.JSON2Request, error) {
+	str, err := p.RevealHex()
+	if err != nil {
+		return nil, err
+	}
+	method := "reveal-entry"
+	if p.IsChain() {
+		method = "reveal-chain"
+	}
+	return primitives.NewJSON2Request(method, id, map[string]string{"entry": str}), nil
+}
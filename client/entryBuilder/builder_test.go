@@ -0,0 +1,123 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package entryBuilder_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	. "github.com/FactomProject/factomd/client/entryBuilder"
+	"github.com/FactomProject/factomd/common/entryBlock"
+	"github.com/FactomProject/factomd/common/entryCreditBlock"
+	"github.com/FactomProject/factomd/common/primitives"
+)
+
+func newKey(t *testing.T) *primitives.PrivateKey {
+	key := new(primitives.PrivateKey)
+	if err := key.GenerateKey(); err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestChainID(t *testing.T) {
+	a, b := sha256.Sum256([]byte("my")), sha256.Sum256([]byte("chain"))
+	want := sha256.Sum256(append(a[:], b[:]...))
+	if got := ChainID([][]byte{[]byte("my"), []byte("chain")}); !bytes.Equal(got.Bytes(), want[:]) {
+		t.Errorf("ChainID() got %s, wanted %x", got, want)
+	}
+}
+
+func TestEntryCost(t *testing.T) {
+	chainID := ChainID([][]byte{[]byte("cost")})
+	// The ExtIDs take 2 bytes for their length, then the ID
+	for _, c := range []struct {
+		size int
+		cost uint8
+	}{{0, 1}, {1024 - 3, 1}, {1024 - 2, 2}, {10240 - 3, 10}} {
+		e, err := NewEntry(chainID, [][]byte{{1}}, make([]byte, c.size))
+		if err != nil {
+			t.Errorf("%d bytes: %v", c.size, err)
+			continue
+		}
+		if cost, _ := EntryCost(e); cost != c.cost || int(cost) < e.KSize() {
+			t.Errorf("%d bytes cost %d, wanted %d", c.size, cost, c.cost)
+		}
+	}
+	if _, err := NewEntry(chainID, [][]byte{{1}}, make([]byte, 10240-2)); err == nil {
+		t.Error("Made an entry over 10 KiB")
+	}
+}
+
+func TestComposeChain(t *testing.T) {
+	key := newKey(t)
+	extIDs := [][]byte{[]byte("test"), []byte("chain")}
+	p, err := ComposeChain(extIDs, make([]byte, 2000), key, primitives.NewTimestampFromMilliseconds(1500000000000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := p.Commit.(*entryCreditBlock.CommitChain)
+	if !c.IsValid() || !p.Entry.IsValid() {
+		t.Error("Invalid chain commit or entry")
+	}
+	if c.Credits != 2+ChainCost || c.GetTimestamp().GetTimeMilliUInt64() != 1500000000000 {
+		t.Errorf("Commit has %d credits at %d", c.Credits, c.GetTimestamp().GetTimeMilliUInt64())
+	}
+	if !p.Entry.ChainID.IsSameAs(ChainID(extIDs)) || !c.EntryHash.IsSameAs(p.Entry.GetHash()) {
+		t.Error("Commit doesn't match the entry")
+	}
+	if !bytes.Equal(c.ChainIDHash.Bytes(), primitives.DoubleSha(p.Entry.ChainID.Bytes())) ||
+		!bytes.Equal(c.Weld.Bytes(), p.Entry.GetWeld()) {
+		t.Error("Wrong chain ID hash or weld")
+	}
+	if !bytes.Equal(c.ECPubKey[:], key.Public()) {
+		t.Error("Commit not paid by the key")
+	}
+
+	req, err := p.CommitRequest(1)
+	if err != nil || req.Method != "commit-chain" {
+		t.Fatalf("Commit request %v: %v", req, err)
+	}
+	data, _ := hex.DecodeString(req.Params.(map[string]string)["message"])
+	c2 := entryCreditBlock.NewCommitChain()
+	if err := c2.UnmarshalBinary(data); err != nil || !c2.IsValid() {
+		t.Errorf("Commit request has an invalid commit: %v", err)
+	}
+	req, err = p.RevealRequest(1)
+	if err != nil || req.Method != "reveal-chain" {
+		t.Fatalf("Reveal request %v: %v", req, err)
+	}
+	data, _ = hex.DecodeString(req.Params.(map[string]string)["entry"])
+	e := entryBlock.NewEntry()
+	if err := e.UnmarshalBinary(data); err != nil || !e.GetHash().IsSameAs(c.EntryHash) {
+		t.Errorf("Reveal request has a different entry: %v", err)
+	}
+
+	if _, err := ComposeChain(nil, []byte("content"), key, nil); err == nil {
+		t.Error("Made a chain without ExtIDs")
+	}
+}
+
+func TestComposeEntry(t *testing.T) {
+	chainID := ChainID([][]byte{[]byte("existing")})
+	p, err := ComposeEntry(chainID, nil, []byte("hello"), newKey(t), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := p.Commit.(*entryCreditBlock.CommitEntry)
+	if !c.IsValid() || c.Credits != 1 || !c.EntryHash.IsSameAs(p.Entry.GetHash()) || p.IsChain() {
+		t.Errorf("Invalid entry commit %v", c)
+	}
+	req, err := p.CommitRequest(1)
+	if err != nil || req.Method != "commit-entry" {
+		t.Errorf("Commit request %v: %v", req, err)
+	}
+	req, err = p.RevealRequest(1)
+	if err != nil || req.Method != "reveal-entry" {
+		t.Errorf("Reveal request %v: %v", req, err)
+	}
+}
@@ -0,0 +1,347 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package transactionBuilder assembles and signs factoid transactions for clients.
+// A Builder is given the inputs, outputs and entry credit outputs of a transaction
+// and the keys that may sign it.  Build then works out the exact fee at the given
+// entry credit rate, pays it from a change address or an input, and signs every
+// input, including multisig inputs.  The result can be passed to factoid-submit.
+package transactionBuilder
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/FactomProject/factomd/common/factoid"
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/common/primitives"
+)
+
+type input struct {
+	rcd    interfaces.IRCD
+	amount uint64
+}
+
+type output struct {
+	address interfaces.IAddress
+	amount  uint64
+}
+
+type Builder struct {
+	inputs    []input
+	outputs   []output
+	ecOutputs []output
+
+	change   interfaces.IAddress // Gets what the inputs pay past the outputs and fee
+	feeInput int                 // Input whose amount is raised to pay the fee, or -1
+
+	timestamp    interfaces.Timestamp
+	lockHeight   uint32
+	expiryHeight uint32
+
+	signers map[[32]byte]interfaces.Signer // By the address of their RCD_1
+	rcds    map[[32]byte]interfaces.IRCD   // The RCDs behind known addresses
+}
+
+func NewBuilder() *Builder {
+	b := new(Builder)
+	b.feeInput = -1
+	b.signers = make(map[[32]byte]interfaces.Signer)
+	b.rcds = make(map[[32]byte]interfaces.IRCD)
+	return b
+}
+
+// FactoidAddress parses a human readable factoid address (FA...)
+func FactoidAddress(userAddr string) (interfaces.IAddress, error) {
+	if !primitives.ValidateFUserStr(userAddr) {
+		return nil, fmt.Errorf("Invalid factoid address %q", userAddr)
+	}
+	return factoid.NewAddress(primitives.ConvertUserStrToAddress(userAddr)), nil
+}
+
+// ECAddress parses a human readable entry credit address (EC...)
+func ECAddress(userAddr string) (interfaces.IAddress, error) {
+	if !primitives.ValidateECUserStr(userAddr) {
+		return nil, fmt.Errorf("Invalid entry credit address %q", userAddr)
+	}
+	return factoid.NewAddress(primitives.ConvertUserStrToAddress(userAddr)), nil
+}
+
+// AddKey adds a private key that may sign the transaction
+func (b *Builder) AddKey(key *primitives.PrivateKey) error {
+	return b.AddSigner(key.Public(), key)
+}
+
+// AddSigner adds a signer for the public key, for keys that are held elsewhere.
+// Its address is that of the RCD_1 of the public key.
+func (b *Builder) AddSigner(publicKey []byte, signer interfaces.Signer) error {
+	if len(publicKey) != 32 {
+		return fmt.Errorf("Invalid public key length %d", len(publicKey))
+	}
+	rcd := factoid.NewRCD_1(publicKey)
+	address, err := b.AddRCD(rcd)
+	if err != nil {
+		return err
+	}
+	b.signers[address.Fixed()] = signer
+	return nil
+}
+
+// AddRCD adds the RCD behind an address, so the address can be signed for.  This is
+// how the builder learns the multisigs nested in a multisig.
+func (b *Builder) AddRCD(rcd interfaces.IRCD) (interfaces.IAddress, error) {
+	address, err := rcd.GetAddress()
+	if err != nil {
+		return nil, err
+	}
+	b.rcds[address.Fixed()] = rcd
+	return address, nil
+}
+
+// AddInput adds an input from the address of the RCD
+func (b *Builder) AddInput(rcd interfaces.IRCD, amount uint64) error {
+	if _, err := b.AddRCD(rcd); err != nil {
+		return err
+	}
+	b.inputs = append(b.inputs, input{rcd: rcd, amount: amount})
+	return nil
+}
+
+// AddKeyInput adds an input from the address of the key, which signs for it
+func (b *Builder) AddKeyInput(key *primitives.PrivateKey, amount uint64) error {
+	if err := b.AddKey(key); err != nil {
+		return err
+	}
+	return b.AddInput(factoid.NewRCD_1(key.Public()), amount)
+}
+
+func (b *Builder) AddOutput(address interfaces.IAddress, amount uint64) {
+	b.outputs = append(b.outputs, output{address: address, amount: amount})
+}
+
+// AddECOutput adds an entry credit output.  The amount is in factoshis, as for any
+// output; the address gets amount / the entry credit rate entry credits.
+func (b *Builder) AddECOutput(address interfaces.IAddress, amount uint64) {
+	b.ecOutputs = append(b.ecOutputs, output{address: address, amount: amount})
+}
+
+// SetChange sends whatever the inputs pay past the outputs and the fee to the address.
+// Change too small to pay for its own output is left to the fee.
+func (b *Builder) SetChange(address interfaces.IAddress) {
+	b.change = address
+}
+
+// PayFeeFrom raises the amount of the index'th input to pay the fee, as wallets do
+func (b *Builder) PayFeeFrom(index int) error {
+	if index < 0 || index >= len(b.inputs) {
+		return fmt.Errorf("No input %d", index)
+	}
+	b.feeInput = index
+	return nil
+}
+
+// SetTimestamp sets the timestamp of the transaction.  It is the time of Build otherwise.
+func (b *Builder) SetTimestamp(ts interfaces.Timestamp) {
+	b.timestamp = ts
+}
+
+// SetHeights sets the lock and expiry heights of the transaction.  Zero means no limit.
+func (b *Builder) SetHeights(lockHeight, expiryHeight uint32) {
+	b.lockHeight = lockHeight
+	b.expiryHeight = expiryHeight
+}
+
+// Build assembles and signs the transaction, paying exactly the fee due at the
+// entry credit rate (factoshis per entry credit).  Without a change address or an
+// input to pay the fee, the inputs must cover the outputs and the fee exactly.
+func (b *Builder) Build(factoshisPerEC uint64) (*factoid.Transaction, error) {
+	if len(b.inputs) == 0 {
+		return nil, fmt.Errorf("The transaction has no inputs")
+	}
+	if factoshisPerEC == 0 {
+		return nil, fmt.Errorf("The entry credit rate is zero")
+	}
+	if b.timestamp == nil {
+		b.timestamp = primitives.NewTimestampNow()
+	}
+
+	// The fee depends on the size of the signed transaction, which depends on the
+	// amounts that pay the fee, so repeat until the fee settles.
+	tried := make(map[uint64]bool)
+	var fee uint64
+	for {
+		tx, err := b.assemble(fee)
+		if err != nil {
+			return nil, err
+		}
+		if err = b.sign(tx); err != nil {
+			return nil, err
+		}
+		due, err := tx.CalculateFee(factoshisPerEC)
+		if err != nil {
+			return nil, err
+		}
+		// If a smaller fee would make the transaction larger again, pay the larger one
+		if due == fee || due < fee && tried[due] {
+			if err := b.checkBalance(tx, due); err != nil {
+				return nil, err
+			}
+			return tx, nil
+		}
+		tried[fee] = true
+		fee = due
+	}
+}
+
+// assemble makes the unsigned transaction that pays the fee
+func (b *Builder) assemble(fee uint64) (*factoid.Transaction, error) {
+	tx := new(factoid.Transaction)
+	tx.SetTimestamp(b.timestamp)
+	tx.LockHeight = b.lockHeight
+	tx.ExpiryHeight = b.expiryHeight
+
+	var in, out uint64
+	var err error
+	for i, input := range b.inputs {
+		amount := input.amount
+		if i == b.feeInput {
+			if amount, err = factoid.ValidateAmounts(amount, fee); err != nil {
+				return nil, err
+			}
+		}
+		address, err := input.rcd.GetAddress()
+		if err != nil {
+			return nil, err
+		}
+		tx.AddInput(address, amount)
+		tx.AddAuthorization(input.rcd)
+		if in, err = factoid.ValidateAmounts(in, amount); err != nil {
+			return nil, err
+		}
+	}
+	for _, output := range b.outputs {
+		tx.AddOutput(output.address, output.amount)
+		if out, err = factoid.ValidateAmounts(out, output.amount); err != nil {
+			return nil, err
+		}
+	}
+	for _, output := range b.ecOutputs {
+		tx.AddECOutput(output.address, output.amount)
+		if out, err = factoid.ValidateAmounts(out, output.amount); err != nil {
+			return nil, err
+		}
+	}
+
+	if b.change != nil && in > out+fee {
+		tx.AddOutput(b.change, in-out-fee)
+	}
+	return tx, nil
+}
+
+// checkBalance returns an error if the transaction doesn't pay the fee, or pays more
+// than it and has nowhere to send the rest
+func (b *Builder) checkBalance(tx *factoid.Transaction, fee uint64) error {
+	in, err := tx.TotalInputs()
+	if err != nil {
+		return err
+	}
+	out, err := tx.TotalOutputs()
+	if err != nil {
+		return err
+	}
+	ecs, err := tx.TotalECs()
+	if err != nil {
+		return err
+	}
+	if in < out+ecs+fee {
+		return fmt.Errorf("The inputs of %s don't cover the outputs of %s and the fee of %s",
+			primitives.ConvertDecimalToString(in),
+			primitives.ConvertDecimalToString(out+ecs),
+			primitives.ConvertDecimalToString(fee))
+	}
+	if in > out+ecs+fee && b.change == nil {
+		return fmt.Errorf("The inputs pay %s more than the outputs and the fee; set a change address",
+			primitives.ConvertDecimalToString(in-out-ecs-fee))
+	}
+	return tx.Validate(1)
+}
+
+// sign signs every input of the transaction
+func (b *Builder) sign(tx *factoid.Transaction) error {
+	data, err := tx.MarshalBinarySig()
+	if err != nil {
+		return err
+	}
+	for i, rcd := range tx.RCDs {
+		sigblk, err := b.signRCD(rcd, data)
+		if err != nil {
+			return fmt.Errorf("Input %d: %v", i, err)
+		}
+		tx.SetSignatureBlock(i, sigblk)
+	}
+	return nil
+}
+
+// signRCD returns the signature block of data that satisfies the RCD
+func (b *Builder) signRCD(rcd interfaces.IRCD, data []byte) (interfaces.ISignatureBlock, error) {
+	address, err := rcd.GetAddress()
+	if err != nil {
+		return nil, err
+	}
+	switch r := rcd.(type) {
+	case *factoid.RCD_1:
+		signer := b.signers[address.Fixed()]
+		if signer == nil {
+			return nil, fmt.Errorf("No key for address %s", primitives.ConvertFctAddressToUserStr(address))
+		}
+		sig := new(factoid.FactoidSignature)
+		if err := sig.SetSignature(signer.Sign(data).GetSignature()[:]); err != nil {
+			return nil, err
+		}
+		sigblk := new(factoid.SignatureBlock)
+		sigblk.AddSignature(sig)
+		return sigblk, nil
+
+	case *factoid.RCD_2:
+		sigblk := new(factoid.RCD2SignatureBlock)
+		for i, a := range r.N_Addresses {
+			if len(sigblk.Signers) == r.N {
+				break
+			}
+			inner := b.rcds[a.Fixed()]
+			if inner == nil {
+				continue
+			}
+			innerblk, err := b.signRCD(inner, data)
+			if err != nil {
+				continue
+			}
+			sigblk.AddSigner(i, inner, innerblk)
+		}
+		if len(sigblk.Signers) < r.N {
+			return nil, fmt.Errorf("Can only sign %d of the %d signatures multisig %s needs",
+				len(sigblk.Signers), r.N, primitives.ConvertFctAddressToUserStr(address))
+		}
+		return sigblk, nil
+	}
+	return nil, fmt.Errorf("Can't sign for an RCD of type %T", rcd)
+}
+
+// Hex returns the transaction as factoid-submit takes it
+func Hex(tx interfaces.ITransaction) (string, error) {
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(data), nil
+}
+
+// SubmitRequest returns the factoid-submit API call that submits the transaction
+func SubmitRequest(tx interfaces.ITransaction, id interface{}) (*primitives.JSON2Request, error) {
+	str, err := Hex(tx)
+	if err != nil {
+		return nil, err
+	}
+	return primitives.NewJSON2Request("factoid-submit", id, map[string]string{"transaction": str}), nil
+}
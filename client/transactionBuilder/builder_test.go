@@ -0,0 +1,210 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package transactionBuilder_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	. "github.com/FactomProject/factomd/client/transactionBuilder"
+	"github.com/FactomProject/factomd/common/factoid"
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/common/primitives"
+)
+
+// Mainnet transactions, with the rate of their block and the input that paid the fee
+var goldenTransactions = []struct {
+	raw      string
+	rate     uint64
+	feeInput int
+}{
+	// Block 1, buying entry credits
+	{"02014f8a851657010001e397a1607d4f56c528ab09da5bbf7b37b0b453f43db303730e28e9ebe02657dff431d4f7dfaf840017ef7a21d1a616d65e6b73f3c6a7ad5c49340a6c2592872020ec60767ff00d7d01a5be79b6ada79c0af4d6b7f91234ff321f3b647ed01e02ccbbc0fe9dcc63293482f22455b9756ee4b4db411a5d00e31b689c1bd1abe1d1e887cf4c52e67fc51fe4d9594c24643a91009c6ea91701b5b6df240248c2f39453162b61d71b982701", 666600, 0},
+	// Block 90050, one input
+	{"02015c3b5738a7010100ab99e440733a747dfa9f3325e541b24f500831d332c551c2d10a1b65064824854343d741aaf59500733a747dfa9f3325e541b24f500831d332c551c2d10a1b65064824854343d7410120f372dc9d5e2a0d9683ad83874508ae193f2b8d1c9735ce0ee49e29b6260b02fb98ddaecc0af37a69744a9d2919b66ac376d52210f705579669e11d7bd8b84a1998bd9c82ca16bb5ebaffb872112128e1749edb33b912bd144d6bdbdc175d08", 50000, 0},
+	// Block 90050, two inputs with the fee paid by the second
+	{"02015c3b579215020100818afffca610e7511e875844ad95f767384fd79396439c636e2e76c57424b05867456b7ab62fa7d610330fd717584445ac866dc2facd8b856e63bdb8b15b5ed46c0b053b2c6c5c5c3f818afffca610330fd717584445ac866dc2facd8b856e63bdb8b15b5ed46c0b053b2c6c5c5c3f0108f5380fafc0df6dec81132f24d8bbdc20bd321a677e84b1473f91e01bd4386799e9db891e03fea16d02ec3d2e649bfc3624409948973a938e0c4f2c8d57ca13674c6c45b99ab9ebc5d2bab7ba667f92bb1624f077525d6dfaaafd23edb7850f012c94f2bbe49899679c54482eba49bf1d024476845e478f9cce3238f612edd7616f95ca30231c35c6c5c96ed2603383099f648c16b504445e77ec94bc838e2a3e99787424970e6e9434eadd7c47c53103684702dec2e25aba7f2872df73b6ad07", 50000, 1},
+	{"02015c3b5a24e102010081a5dadff81c9e2661b5bb8ade3deb2bb7e6b078e5c5e0398ea2b62c2c4cc3c4b3f1b3b5d7a2a7d610330fd717584445ac866dc2facd8b856e63bdb8b15b5ed46c0b053b2c6c5c5c3f81a5dadff81c330fd717584445ac866dc2facd8b856e63bdb8b15b5ed46c0b053b2c6c5c5c3f0141fb6c31249ece9e18b1f7d99285f7002e5cabeb7580fa200ed3630b3d5049feab6768b9e794516ab1cc49b816a680396b7f8b19e213d729c725f298a81e0b670baa8c31493f8ed691da450abb5acddf9a9e32286c4f1e20c07ce2840235880b012c94f2bbe49899679c54482eba49bf1d024476845e478f9cce3238f612edd761bcf6f84ceb25d91848adcf99115a51419dec155b7f09ae4b09aff19a864c2ab45e089ac6794231c2c37b1cef98875906174e8fee9a07d4362ce30fe2bc907307", 50000, 1},
+}
+
+// replaySigner gives the signature a mainnet key made, as we don't have the key
+type replaySigner struct {
+	pub, sig []byte
+}
+
+func (s replaySigner) Sign(msg []byte) interfaces.IFullSignature {
+	sig := new(primitives.Signature)
+	sig.SetPub(s.pub)
+	sig.SetSignature(s.sig)
+	return sig
+}
+
+func TestGoldenTransactions(t *testing.T) {
+	for i, golden := range goldenTransactions {
+		data, _ := hex.DecodeString(golden.raw)
+		tx := new(factoid.Transaction)
+		if err := tx.UnmarshalBinary(data); err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		in, _ := tx.TotalInputs()
+		out, _ := tx.TotalOutputs()
+		ecs, _ := tx.TotalECs()
+		fee := in - out - ecs
+
+		b := NewBuilder()
+		b.SetTimestamp(tx.GetTimestamp())
+		for j, input := range tx.Inputs {
+			rcd := tx.RCDs[j].(*factoid.RCD_1)
+			sig := tx.SigBlocks[j].GetSignature(0).GetSignature()
+			b.AddSigner(rcd.PublicKey[:], replaySigner{pub: rcd.PublicKey[:], sig: sig[:]})
+			amount := input.GetAmount()
+			if j == golden.feeInput {
+				amount -= fee
+			}
+			b.AddInput(rcd, amount)
+		}
+		for _, output := range tx.Outputs {
+			b.AddOutput(output.GetAddress(), output.GetAmount())
+		}
+		for _, output := range tx.OutECs {
+			b.AddECOutput(output.GetAddress(), output.GetAmount())
+		}
+		b.PayFeeFrom(golden.feeInput)
+
+		built, err := b.Build(golden.rate)
+		if err != nil {
+			t.Errorf("%d: %v", i, err)
+			continue
+		}
+		str, _ := Hex(built)
+		if str != golden.raw {
+			t.Errorf("%d: built\n%s\nwanted\n%s", i, str, golden.raw)
+		}
+		if err := built.ValidateSignatures(); err != nil {
+			t.Errorf("%d: %v", i, err)
+		}
+	}
+}
+
+func newKey(t *testing.T) *primitives.PrivateKey {
+	key := new(primitives.PrivateKey)
+	if err := key.GenerateKey(); err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func address(key *primitives.PrivateKey) interfaces.IAddress {
+	address, _ := factoid.NewRCD_1(key.Public()).GetAddress()
+	return address
+}
+
+// checkFee checks the transaction pays exactly the fee due
+func checkFee(t *testing.T, tx *factoid.Transaction, rate uint64) {
+	fee, err := tx.CalculateFee(rate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	in, _ := tx.TotalInputs()
+	out, _ := tx.TotalOutputs()
+	ecs, _ := tx.TotalECs()
+	if in != out+ecs+fee {
+		t.Errorf("Inputs of %d pay outputs of %d and a fee of %d", in, out+ecs, fee)
+	}
+	if err := tx.Validate(1); err != nil {
+		t.Error(err)
+	}
+	if err := tx.ValidateSignatures(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBuildChange(t *testing.T) {
+	from, change := newKey(t), newKey(t)
+	to := address(newKey(t))
+
+	b := NewBuilder()
+	b.AddKeyInput(from, 1000000000)
+	b.AddOutput(to, 300000000)
+	b.AddECOutput(address(newKey(t)), 100000000)
+	b.SetChange(address(change))
+	tx, err := b.Build(10000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFee(t, tx, 10000)
+	if len(tx.Outputs) != 2 || !tx.Outputs[1].GetAddress().IsSameAs(address(change)) {
+		t.Errorf("No change output in %v", tx)
+	}
+
+	// Without a change address, the inputs must pay the outputs and fee exactly
+	b.SetChange(nil)
+	if _, err := b.Build(10000); err == nil {
+		t.Error("Built a transaction that overpays the fee")
+	}
+	b = NewBuilder()
+	b.AddKeyInput(from, 1000)
+	b.AddOutput(to, 1000)
+	if _, err := b.Build(10000); err == nil {
+		t.Error("Built a transaction that doesn't pay the fee")
+	}
+	b.PayFeeFrom(0)
+	tx, err = b.Build(10000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFee(t, tx, 10000)
+}
+
+func TestBuildMultisig(t *testing.T) {
+	keys := []*primitives.PrivateKey{newKey(t), newKey(t), newKey(t)}
+	var addresses []interfaces.IAddress
+	for _, key := range keys {
+		addresses = append(addresses, address(key))
+	}
+	multisig, err := factoid.NewRCD_2(2, 3, addresses)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := NewBuilder()
+	b.AddInput(multisig, 5000000)
+	b.AddOutput(address(keys[0]), 5000000)
+	b.PayFeeFrom(0)
+	b.AddKey(keys[2])
+	if _, err := b.Build(1000); err == nil {
+		t.Error("Built a 2 of 3 multisig with one key")
+	}
+	b.AddKey(keys[0])
+	tx, err := b.Build(1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFee(t, tx, 1000)
+}
+
+func TestSubmitRequest(t *testing.T) {
+	b := NewBuilder()
+	b.AddKeyInput(newKey(t), 100000)
+	b.AddOutput(address(newKey(t)), 1)
+	b.SetChange(address(newKey(t)))
+	b.SetHeights(10, 20)
+	tx, err := b.Build(1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := SubmitRequest(tx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Method != "factoid-submit" {
+		t.Errorf("Method %s", req.Method)
+	}
+	data, _ := hex.DecodeString(req.Params.(map[string]string)["transaction"])
+	tx2 := new(factoid.Transaction)
+	if err := tx2.UnmarshalBinary(data); err != nil || !tx2.IsSameAs(tx) || tx2.LockHeight != 10 {
+		t.Errorf("Submitted a different transaction: %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package adminBlock
+
+import (
+	"fmt"
+
+	"github.com/FactomProject/factomd/common/constants"
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/common/primitives"
+)
+
+// AddFactoidAddress sets the factoid address (the RCD hash) the coinbase payouts of a
+// server go to, from the height of the admin block it is in
+type AddFactoidAddress struct {
+	IdentityChainID interfaces.IHash
+	FactoidAddress  interfaces.IHash
+}
+
+var _ interfaces.Printable = (*AddFactoidAddress)(nil)
+var _ interfaces.BinaryMarshallable = (*AddFactoidAddress)(nil)
+var _ interfaces.IABEntry = (*AddFactoidAddress)(nil)
+
+func (e *AddFactoidAddress) Init() {
+	if e.IdentityChainID == nil {
+		e.IdentityChainID = primitives.NewZeroHash()
+	}
+	if e.FactoidAddress == nil {
+		e.FactoidAddress = primitives.NewZeroHash()
+	}
+}
+
+func (e *AddFactoidAddress) String() string {
+	e.Init()
+	var out primitives.Buffer
+	out.WriteString(fmt.Sprintf("    E: %35s -- %17s %8x %12s %8s",
+		"AddFactoidAddress",
+		"IdentityChainID", e.IdentityChainID.Bytes()[3:5],
+		"FactoidAddress", e.FactoidAddress.String()[:8]))
+	return (string)(out.DeepCopyBytes())
+}
+
+func (e *AddFactoidAddress) Type() byte {
+	return constants.TYPE_ADD_FACTOID_ADDRESS
+}
+
+// UpdateState is done by AdminBlock.UpdateState, which knows the height of the block
+func (c *AddFactoidAddress) UpdateState(state interfaces.IState) error {
+	return fmt.Errorf("Should not be called alone!")
+}
+
+func NewAddFactoidAddress(identityChainID interfaces.IHash, factoidAddress interfaces.IHash) *AddFactoidAddress {
+	e := new(AddFactoidAddress)
+	e.IdentityChainID = identityChainID
+	e.FactoidAddress = factoidAddress
+	return e
+}
+
+func (e *AddFactoidAddress) MarshalBinary() ([]byte, error) {
+	e.Init()
+	var buf primitives.Buffer
+
+	err := buf.PushByte(e.Type())
+	if err != nil {
+		return nil, err
+	}
+	err = buf.PushBinaryMarshallable(e.IdentityChainID)
+	if err != nil {
+		return nil, err
+	}
+	err = buf.PushBinaryMarshallable(e.FactoidAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.DeepCopyBytes(), nil
+}
+
+func (e *AddFactoidAddress) UnmarshalBinaryData(data []byte) (newData []byte, err error) {
+	buf := primitives.NewBuffer(data)
+	b, err := buf.PopByte()
+	if err != nil {
+		return nil, err
+	}
+	if b != e.Type() {
+		return nil, fmt.Errorf("Invalid Entry type")
+	}
+
+	e.IdentityChainID = new(primitives.Hash)
+	err = buf.PopBinaryMarshallable(e.IdentityChainID)
+	if err != nil {
+		return nil, err
+	}
+	e.FactoidAddress = new(primitives.Hash)
+	err = buf.PopBinaryMarshallable(e.FactoidAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.DeepCopyBytes(), nil
+}
+
+func (e *AddFactoidAddress) UnmarshalBinary(data []byte) (err error) {
+	_, err = e.UnmarshalBinaryData(data)
+	return
+}
+
+func (e *AddFactoidAddress) JSONByte() ([]byte, error) {
+	return primitives.EncodeJSON(e)
+}
+
+func (e *AddFactoidAddress) JSONString() (string, error) {
+	return primitives.EncodeJSONString(e)
+}
+
+func (e *AddFactoidAddress) IsInterpretable() bool {
+	return false
+}
+
+func (e *AddFactoidAddress) Interpret() string {
+	return ""
+}
+
+func (e *AddFactoidAddress) Hash() interfaces.IHash {
+	bin, err := e.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	return primitives.Sha(bin)
+}
@@ -0,0 +1,106 @@
+package adminBlock_test
+
+import (
+	"testing"
+
+	. "github.com/FactomProject/factomd/common/adminBlock"
+	"github.com/FactomProject/factomd/common/constants"
+	"github.com/FactomProject/factomd/testHelper"
+)
+
+func TestAddFactoidAddressTypeIDCheck(t *testing.T) {
+	a := new(AddFactoidAddress)
+	b, err := a.MarshalBinary()
+	if err != nil {
+		t.Errorf("%v", err)
+	}
+	if b[0] != a.Type() {
+		t.Errorf("Invalid byte marshalled")
+	}
+	a2 := new(AddFactoidAddress)
+	err = a2.UnmarshalBinary(b)
+	if err != nil {
+		t.Errorf("%v", err)
+	}
+
+	b[0] = (b[0] + 1) % 255
+	err = a2.UnmarshalBinary(b)
+	if err == nil {
+		t.Errorf("No error caught")
+	}
+}
+
+func TestUnmarshalNilAddFactoidAddress(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("Panic caught during the test - %v", r)
+		}
+	}()
+
+	a := new(AddFactoidAddress)
+	err := a.UnmarshalBinary(nil)
+	if err == nil {
+		t.Errorf("Error is nil when it shouldn't be")
+	}
+
+	err = a.UnmarshalBinary([]byte{})
+	if err == nil {
+		t.Errorf("Error is nil when it shouldn't be")
+	}
+}
+
+func TestAddFactoidAddressMarshalUnmarshal(t *testing.T) {
+	identity := testHelper.NewRepeatingHash(0xAB)
+	address := testHelper.NewRepeatingHash(0xCD)
+
+	fa := NewAddFactoidAddress(identity, address)
+	tmp, err := fa.MarshalBinary()
+	if err != nil {
+		t.Error(err)
+	}
+
+	fa = new(AddFactoidAddress)
+	rest, err := fa.UnmarshalBinaryData(tmp)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(rest) != 0 {
+		t.Errorf("%d bytes left over", len(rest))
+	}
+	if fa.Type() != constants.TYPE_ADD_FACTOID_ADDRESS {
+		t.Errorf("Invalid type")
+	}
+	if fa.IdentityChainID.IsSameAs(identity) == false {
+		t.Errorf("Invalid IdentityChainID")
+	}
+	if fa.FactoidAddress.IsSameAs(address) == false {
+		t.Errorf("Invalid FactoidAddress")
+	}
+}
+
+func TestAddFactoidAddressInBlock(t *testing.T) {
+	block := new(AdminBlock)
+	block.Init()
+	block.GetHeader().SetDBHeight(77)
+	identity := testHelper.NewRepeatingHash(0xAB)
+	address := testHelper.NewRepeatingHash(0xCD)
+	if err := block.AddFactoidAddress(identity, address); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := block.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	block2 := new(AdminBlock)
+	if err := block2.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	fa, ok := block2.GetABEntries()[0].(*AddFactoidAddress)
+	if !ok {
+		t.Fatalf("Got %T, expected an AddFactoidAddress", block2.GetABEntries()[0])
+	}
+	if !fa.IdentityChainID.IsSameAs(identity) || !fa.FactoidAddress.IsSameAs(address) {
+		t.Errorf("Got %v", fa)
+	}
+}
@@ -79,6 +79,11 @@ func (c *AdminBlock) UpdateState(state interfaces.IState) error {
 	for _, entry := range c.ABEntries {
 		if entry.Type() == constants.TYPE_DB_SIGNATURE {
 			dbSigs = append(dbSigs, entry.(*DBSignatureEntry))
+		} else if entry.Type() == constants.TYPE_ADD_FACTOID_ADDRESS {
+			// The address is set from the height of this block, not one the entry claims
+			fa := entry.(*AddFactoidAddress)
+			fa.Init()
+			state.AddCoinbaseAddress(c.GetDBHeight(), fa.IdentityChainID, fa.FactoidAddress)
 		} else {
 			err := entry.UpdateState(state)
 			if err != nil {
@@ -154,6 +159,20 @@ func (c *AdminBlock) AddMatryoshkaHash(identityChainID interfaces.IHash, mHash i
 	return c.AddEntry(entry)
 }
 
+// AddFactoidAddress sets the address the coinbase payouts of the server go to, from this block
+func (c *AdminBlock) AddFactoidAddress(identityChainID interfaces.IHash, factoidAddress interfaces.IHash) error {
+	c.Init()
+	if identityChainID == nil {
+		return fmt.Errorf("No identityChainID provided")
+	}
+	if factoidAddress == nil {
+		return fmt.Errorf("No factoidAddress provided")
+	}
+
+	entry := NewAddFactoidAddress(identityChainID, factoidAddress)
+	return c.AddEntry(entry)
+}
+
 func (c *AdminBlock) AddFederatedServerSigningKey(identityChainID interfaces.IHash, publicKey [32]byte) error {
 	c.Init()
 	if identityChainID == nil {
@@ -389,6 +408,8 @@ func (b *AdminBlock) UnmarshalBinaryData(data []byte) ([]byte, error) {
 			b.ABEntries[i] = new(AddFederatedServerBitcoinAnchorKey)
 		case constants.TYPE_SERVER_FAULT:
 			b.ABEntries[i] = new(ServerFault)
+		case constants.TYPE_ADD_FACTOID_ADDRESS:
+			b.ABEntries[i] = new(AddFactoidAddress)
 		default:
 			fmt.Printf("AB UNDEFINED ENTRY %x for block %v\n", t, b.GetHeader().GetDBHeight())
 			panic("Undefined Admin Block Entry Type")
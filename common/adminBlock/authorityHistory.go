@@ -0,0 +1,145 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package adminBlock
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/FactomProject/factomd/common/constants"
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/common/primitives"
+)
+
+// AuthorityHistory follows the federated and audit servers, and their block signing
+// keys, through the admin blocks of a chain.  Admin blocks are added in order from
+// genesis; the authority set at any height seen so far can then be asked for.
+//
+// Server list and key entries carry the height they take effect at (usually the
+// block after the admin block holding them), and that is the height used here.  An
+// entry can't change the past, so one claiming the height of its own admin block or
+// earlier takes effect at the block after.
+type AuthorityHistory struct {
+	changes []authorityChange
+	next    uint32 // height of the next admin block we expect
+}
+
+type authorityChange struct {
+	DBHeight uint32 // effective height
+	ChainID  [32]byte
+	Status   uint8                 // IDENTITY_FEDERATED_SERVER, IDENTITY_AUDIT_SERVER, or 0 to remove the server
+	Key      *primitives.PublicKey // set for key changes, which leave the status alone
+}
+
+// AuthorityKey is a server in an AuthoritySet
+type AuthorityKey struct {
+	ChainID          interfaces.IHash      `json:"chainid"`
+	SigningKey       *primitives.PublicKey `json:"signingkey"`       // nil if the server never registered one
+	SigningKeyHeight uint32                `json:"signingkeyheight"` // height the key took effect
+}
+
+// AuthoritySet is who the servers were at a directory block height
+type AuthoritySet struct {
+	DBHeight         uint32         `json:"dbheight"`
+	FederatedServers []AuthorityKey `json:"federatedservers"`
+	AuditServers     []AuthorityKey `json:"auditservers"`
+}
+
+func NewAuthorityHistory() *AuthorityHistory {
+	return new(AuthorityHistory)
+}
+
+// AddBootstrapServer makes a server federated from genesis, with its key.  This is the network's
+// bootstrap identity, which leads before any admin block says otherwise.
+func (h *AuthorityHistory) AddBootstrapServer(chainID interfaces.IHash, key *primitives.PublicKey) {
+	h.changes = append(h.changes, authorityChange{DBHeight: 0, ChainID: chainID.Fixed(), Status: constants.IDENTITY_FEDERATED_SERVER})
+	h.changes = append(h.changes, authorityChange{DBHeight: 0, ChainID: chainID.Fixed(), Key: key})
+}
+
+// Height returns the number of admin blocks added, which is the height of the next one expected
+func (h *AuthorityHistory) Height() uint32 {
+	return h.next
+}
+
+// AddAdminBlock adds the authority changes in the admin block, which must be the next one in the chain
+func (h *AuthorityHistory) AddAdminBlock(ablock interfaces.IAdminBlock) error {
+	if ablock.GetDBHeight() != h.next {
+		return fmt.Errorf("Expected the admin block at height %d, got height %d", h.next, ablock.GetDBHeight())
+	}
+	effective := func(dbheight uint32) uint32 {
+		if dbheight <= ablock.GetDBHeight() {
+			return ablock.GetDBHeight() + 1
+		}
+		return dbheight
+	}
+	for _, entry := range ablock.GetABEntries() {
+		switch e := entry.(type) {
+		case *AddFederatedServer:
+			h.changes = append(h.changes, authorityChange{DBHeight: effective(e.DBHeight), ChainID: e.IdentityChainID.Fixed(), Status: constants.IDENTITY_FEDERATED_SERVER})
+		case *AddAuditServer:
+			h.changes = append(h.changes, authorityChange{DBHeight: effective(e.DBHeight), ChainID: e.IdentityChainID.Fixed(), Status: constants.IDENTITY_AUDIT_SERVER})
+		case *RemoveFederatedServer:
+			h.changes = append(h.changes, authorityChange{DBHeight: effective(e.DBHeight), ChainID: e.IdentityChainID.Fixed(), Status: 0})
+		case *AddFederatedServerSigningKey:
+			key := e.PublicKey
+			h.changes = append(h.changes, authorityChange{DBHeight: effective(e.DBHeight), ChainID: e.IdentityChainID.Fixed(), Key: &key})
+		}
+	}
+	h.next++
+	return nil
+}
+
+// AuthoritySetAt returns the servers and their keys at dbheight, sorted by chain ID.  Only
+// the admin blocks added so far are considered.
+func (h *AuthorityHistory) AuthoritySetAt(dbheight uint32) *AuthoritySet {
+	status := map[[32]byte]uint8{}
+	keys := map[[32]byte]AuthorityKey{}
+	for _, change := range h.changes {
+		if change.DBHeight > dbheight {
+			continue
+		}
+		if change.Key != nil {
+			keys[change.ChainID] = AuthorityKey{SigningKey: change.Key, SigningKeyHeight: change.DBHeight}
+		} else if change.Status == 0 {
+			delete(status, change.ChainID)
+		} else {
+			status[change.ChainID] = change.Status
+		}
+	}
+
+	set := new(AuthoritySet)
+	set.DBHeight = dbheight
+	set.FederatedServers = []AuthorityKey{}
+	set.AuditServers = []AuthorityKey{}
+	for chainID, s := range status {
+		server := keys[chainID]
+		server.ChainID = primitives.NewHash(chainID[:])
+		if s == constants.IDENTITY_FEDERATED_SERVER {
+			set.FederatedServers = append(set.FederatedServers, server)
+		} else {
+			set.AuditServers = append(set.AuditServers, server)
+		}
+	}
+	sortAuthorityKeys(set.FederatedServers)
+	sortAuthorityKeys(set.AuditServers)
+	return set
+}
+
+func sortAuthorityKeys(servers []AuthorityKey) {
+	sort.Slice(servers, func(i, j int) bool {
+		return bytes.Compare(servers[i].ChainID.Bytes(), servers[j].ChainID.Bytes()) < 0
+	})
+}
+
+// IsFederatedKey is true if key belonged to one of the federated servers in the set
+func (set *AuthoritySet) IsFederatedKey(key []byte) bool {
+	for _, server := range set.FederatedServers {
+		if server.SigningKey != nil && bytes.Equal(server.SigningKey[:], key) {
+			return true
+		}
+	}
+	return false
+}
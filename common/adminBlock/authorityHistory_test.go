@@ -0,0 +1,97 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package adminBlock_test
+
+import (
+	"testing"
+
+	. "github.com/FactomProject/factomd/common/adminBlock"
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/common/primitives"
+)
+
+func aBlockAt(dbheight uint32) interfaces.IAdminBlock {
+	a := NewAdminBlock(nil)
+	a.GetHeader().SetDBHeight(dbheight)
+	return a
+}
+
+func TestAuthorityHistory(t *testing.T) {
+	boot := primitives.Sha([]byte("bootstrap server"))
+	fed := primitives.Sha([]byte("federated server"))
+	audit := primitives.Sha([]byte("audit server"))
+	bootKey := primitives.RandomPrivateKey().Pub
+	fedKey := primitives.RandomPrivateKey().Pub
+
+	h := NewAuthorityHistory()
+	h.AddBootstrapServer(boot, bootKey)
+
+	// Block 0 is genesis, block 1 adds the servers, block 2 gives the federated server a key,
+	// block 3 demotes the bootstrap server
+	if err := h.AddAdminBlock(aBlockAt(0)); err != nil {
+		t.Fatal(err)
+	}
+	a := aBlockAt(1)
+	a.AddFedServer(fed)
+	a.AddAuditServer(audit)
+	if err := h.AddAdminBlock(a); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.AddAdminBlock(aBlockAt(1)); err == nil {
+		t.Errorf("AddAdminBlock() should refuse a block out of order")
+	}
+	a = aBlockAt(2)
+	a.AddFederatedServerSigningKey(fed, *fedKey)
+	h.AddAdminBlock(a)
+	a = aBlockAt(3)
+	a.RemoveFederatedServer(boot)
+	h.AddAdminBlock(a)
+	if h.Height() != 4 {
+		t.Errorf("Height() got %d, expected 4", h.Height())
+	}
+
+	set := h.AuthoritySetAt(1)
+	if len(set.FederatedServers) != 1 || len(set.AuditServers) != 0 || !set.IsFederatedKey(bootKey[:]) {
+		t.Errorf("AuthoritySetAt(1) got %+v", set)
+	}
+
+	set = h.AuthoritySetAt(2)
+	if len(set.FederatedServers) != 2 || len(set.AuditServers) != 1 || !set.AuditServers[0].ChainID.IsSameAs(audit) {
+		t.Errorf("AuthoritySetAt(2) got %+v", set)
+	}
+	if set.IsFederatedKey(fedKey[:]) {
+		t.Errorf("the federated server's key is not in effect until height 3")
+	}
+
+	set = h.AuthoritySetAt(3)
+	if !set.IsFederatedKey(fedKey[:]) || !set.IsFederatedKey(bootKey[:]) {
+		t.Errorf("AuthoritySetAt(3) got %+v", set)
+	}
+
+	set = h.AuthoritySetAt(4)
+	if len(set.FederatedServers) != 1 || !set.FederatedServers[0].ChainID.IsSameAs(fed) || set.FederatedServers[0].SigningKeyHeight != 3 {
+		t.Errorf("AuthoritySetAt(4) got %+v", set)
+	}
+	if set.IsFederatedKey(bootKey[:]) {
+		t.Errorf("the bootstrap server was removed at height 4")
+	}
+
+	// An entry claiming the height of its own block, or earlier, only takes effect at the next block
+	a = aBlockAt(4)
+	a.AddABEntry(NewRemoveFederatedServer(fed, 2))
+	a.AddABEntry(NewAddFederatedServer(audit, 4))
+	h.AddAdminBlock(a)
+	set = h.AuthoritySetAt(4)
+	if len(set.FederatedServers) != 1 || !set.FederatedServers[0].ChainID.IsSameAs(fed) {
+		t.Errorf("AuthoritySetAt(4) changed by a later block: %+v", set)
+	}
+	if set = h.AuthoritySetAt(2); len(set.FederatedServers) != 2 {
+		t.Errorf("AuthoritySetAt(2) changed by a later block: %+v", set)
+	}
+	set = h.AuthoritySetAt(5)
+	if len(set.FederatedServers) != 1 || !set.FederatedServers[0].ChainID.IsSameAs(audit) {
+		t.Errorf("AuthoritySetAt(5) got %+v", set)
+	}
+}
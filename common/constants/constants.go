@@ -156,7 +156,8 @@ const (
 	TYPE_REMOVE_FED_SERVER               // 7
 	TYPE_ADD_FED_SERVER_KEY              // 8
 	TYPE_ADD_BTC_ANCHOR_KEY              // 9
-	TYPE_SERVER_FAULT
+	TYPE_SERVER_FAULT                    // 10
+	TYPE_ADD_FACTOID_ADDRESS             // 11
 )
 
 //---------------------------------------------------------------------
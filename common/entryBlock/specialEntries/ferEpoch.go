@@ -0,0 +1,116 @@
+package specialEntries
+
+import (
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/common/primitives"
+)
+
+// FEREpoch is a Factoshis per EC rate, in effect from ActivationHeight until the next epoch.
+// EntryHash and Priority are those of the FER entry that scheduled the change, if it is known.
+type FEREpoch struct {
+	ActivationHeight uint32           `json:"activation_height"`
+	FactoshisPerEC   uint64           `json:"factoshis_per_ec"`
+	EntryHash        interfaces.IHash `json:"entry_hash,omitempty"`
+	Priority         uint32           `json:"priority,omitempty"`
+}
+
+var _ interfaces.Printable = (*FEREpoch)(nil)
+var _ interfaces.BinaryMarshallableAndCopyable = (*FEREpoch)(nil)
+var _ interfaces.IFEREpoch = (*FEREpoch)(nil)
+
+func NewFEREpoch(activationHeight uint32, factoshisPerEC uint64) *FEREpoch {
+	e := new(FEREpoch)
+	e.ActivationHeight = activationHeight
+	e.FactoshisPerEC = factoshisPerEC
+	return e
+}
+
+func (e *FEREpoch) New() interfaces.BinaryMarshallableAndCopyable {
+	return new(FEREpoch)
+}
+
+func (e *FEREpoch) GetActivationHeight() uint32 {
+	return e.ActivationHeight
+}
+
+func (e *FEREpoch) GetFactoshisPerEC() uint64 {
+	return e.FactoshisPerEC
+}
+
+func (e *FEREpoch) GetEntryHash() interfaces.IHash {
+	return e.EntryHash
+}
+
+func (e *FEREpoch) GetPriority() uint32 {
+	return e.Priority
+}
+
+func (e *FEREpoch) JSONByte() ([]byte, error) {
+	return primitives.EncodeJSON(e)
+}
+
+func (e *FEREpoch) JSONString() (string, error) {
+	return primitives.EncodeJSONString(e)
+}
+
+func (e *FEREpoch) String() string {
+	str, _ := e.JSONString()
+	return str
+}
+
+func (e *FEREpoch) MarshalBinary() ([]byte, error) {
+	buf := primitives.NewBuffer(nil)
+	err := buf.PushUInt32(e.ActivationHeight)
+	if err != nil {
+		return nil, err
+	}
+	err = buf.PushUInt64(e.FactoshisPerEC)
+	if err != nil {
+		return nil, err
+	}
+	err = buf.PushUInt32(e.Priority)
+	if err != nil {
+		return nil, err
+	}
+	entryHash := e.EntryHash
+	if entryHash == nil {
+		entryHash = primitives.NewZeroHash()
+	}
+	err = buf.PushBinaryMarshallable(entryHash)
+	if err != nil {
+		return nil, err
+	}
+	return buf.DeepCopyBytes(), nil
+}
+
+func (e *FEREpoch) UnmarshalBinaryData(data []byte) (newData []byte, err error) {
+	buf := primitives.NewBuffer(data)
+	e.ActivationHeight, err = buf.PopUInt32()
+	if err != nil {
+		return
+	}
+	e.FactoshisPerEC, err = buf.PopUInt64()
+	if err != nil {
+		return
+	}
+	e.Priority, err = buf.PopUInt32()
+	if err != nil {
+		return
+	}
+	entryHash := new(primitives.Hash)
+	err = buf.PopBinaryMarshallable(entryHash)
+	if err != nil {
+		return
+	}
+	e.EntryHash = nil
+	if !entryHash.IsZero() {
+		e.EntryHash = entryHash
+	}
+	newData = buf.DeepCopyBytes()
+	return
+}
+
+func (e *FEREpoch) UnmarshalBinary(data []byte) (err error) {
+	_, err = e.UnmarshalBinaryData(data)
+	return
+}
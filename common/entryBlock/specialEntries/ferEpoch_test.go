@@ -0,0 +1,48 @@
+package specialEntries_test
+
+import (
+	"testing"
+
+	. "github.com/FactomProject/factomd/common/entryBlock/specialEntries"
+	"github.com/FactomProject/factomd/common/primitives"
+)
+
+func TestMarshalUnmarshalFEREpoch(t *testing.T) {
+	e := NewFEREpoch(10, 12345)
+	e.EntryHash = primitives.Sha([]byte("FER entry"))
+	e.Priority = 3
+
+	b, err := e.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	e2 := new(FEREpoch)
+	rest, err := e2.UnmarshalBinaryData(append(b, 0xFF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rest) != 1 {
+		t.Errorf("%d bytes left over", len(rest))
+	}
+	if e2.ActivationHeight != 10 || e2.FactoshisPerEC != 12345 || e2.Priority != 3 || !e2.EntryHash.IsSameAs(e.EntryHash) {
+		t.Errorf("got %v, expected %v", e2, e)
+	}
+
+	// Without a FER entry
+	e = NewFEREpoch(0, 1000)
+	b, err = e.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	e2 = new(FEREpoch)
+	if err = e2.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if e2.EntryHash != nil || e2.FactoshisPerEC != 1000 {
+		t.Errorf("got %v", e2)
+	}
+
+	if err = e2.UnmarshalBinary(b[:10]); err == nil {
+		t.Errorf("Error is nil when it shouldn't be")
+	}
+}
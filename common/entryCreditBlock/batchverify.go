@@ -0,0 +1,53 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package entryCreditBlock
+
+import (
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/common/primitives"
+)
+
+// ValidateCommits returns what IsValid() would for each of many chain and entry commits,
+// with their signatures verified together on the verifier's workers.  Other entries are
+// never valid.
+func ValidateCommits(commits []interfaces.IECBlockEntry) []bool {
+	valid := make([]bool, len(commits))
+	index := make([]int, len(commits))
+	batch := primitives.NewBatchVerifier()
+
+	for i, commit := range commits {
+		index[i] = -1
+		var pub *primitives.ByteSlice32
+		var sig *primitives.ByteSlice64
+		var data []byte
+		var err error
+		switch c := commit.(type) {
+		case *CommitChain:
+			if !c.isWellFormed() {
+				continue
+			}
+			pub, sig = c.ECPubKey, c.Sig
+			data, err = c.MarshalBinarySig()
+		case *CommitEntry:
+			if !c.isWellFormed() {
+				continue
+			}
+			pub, sig = c.ECPubKey, c.Sig
+			data, err = c.MarshalBinarySig()
+		default:
+			continue
+		}
+		if pub == nil || sig == nil || err != nil {
+			continue
+		}
+		index[i] = batch.Add(pub[:], data, sig[:])
+	}
+
+	verified := batch.Verify()
+	for i := range commits {
+		valid[i] = index[i] >= 0 && verified[index[i]]
+	}
+	return valid
+}
@@ -0,0 +1,63 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package entryCreditBlock_test
+
+import (
+	"testing"
+
+	. "github.com/FactomProject/factomd/common/entryCreditBlock"
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/common/primitives"
+)
+
+func TestValidateCommits(t *testing.T) {
+	var commits []interfaces.IECBlockEntry
+	for i := 0; i < 10; i++ {
+		p := new(primitives.PrivateKey)
+		if err := p.GenerateKey(); err != nil {
+			t.Fatal(err)
+		}
+		if i%2 == 0 {
+			c := NewCommitChain()
+			c.Credits = 11
+			c.Sign(p.Key[:])
+			commits = append(commits, c)
+		} else {
+			c := NewCommitEntry()
+			c.Credits = 1
+			c.Sign(p.Key[:])
+			commits = append(commits, c)
+		}
+	}
+	commits[3].(*CommitEntry).Credits = 2 // No longer what was signed
+	commits = append(commits, NewMinuteNumber(1))
+
+	p := new(primitives.PrivateKey)
+	if err := p.GenerateKey(); err != nil {
+		t.Fatal(err)
+	}
+	c := NewCommitEntry()
+	c.Credits = 11 // Signed, but too many credits for an entry
+	c.Sign(p.Key[:])
+	commits = append(commits, c)
+
+	valid := ValidateCommits(commits)
+	for i, commit := range commits {
+		if valid[i] != (i != 3 && i != 10 && i != 11) {
+			t.Errorf("Commit %d: got %v", i, valid[i])
+		}
+		if v, ok := commit.(interface {
+			IsValid() bool
+		}); ok && v.IsValid() != valid[i] {
+			t.Errorf("Commit %d: IsValid() disagrees with the batch", i)
+		}
+	}
+
+	// The results aren't kept on the commits, so changing one after is caught
+	commits[0].(*CommitChain).Credits = 12
+	if commits[0].(*CommitChain).IsValid() {
+		t.Errorf("IsValid() after the commit changed")
+	}
+}
@@ -172,9 +172,7 @@ func (c *CommitChain) GetTimestamp() interfaces.Timestamp {
 }
 
 func (c *CommitChain) IsValid() bool {
-	c.Init()
-	//double check the credits in the commit
-	if c.Credits < 11 || c.Version != 0 || c.Credits > 20 {
+	if !c.isWellFormed() {
 		return false
 	}
 
@@ -186,6 +184,13 @@ func (c *CommitChain) IsValid() bool {
 	}
 }
 
+// isWellFormed checks everything IsValid() does but the signature
+func (c *CommitChain) isWellFormed() bool {
+	c.Init()
+	//double check the credits in the commit
+	return 11 <= c.Credits && c.Credits <= 20 && c.Version == 0
+}
+
 func (c *CommitChain) GetHash() interfaces.IHash {
 	data, _ := c.MarshalBinary()
 	return primitives.Sha(data)
@@ -157,8 +157,7 @@ func (c *CommitEntry) GetTimestamp() interfaces.Timestamp {
 }
 
 func (c *CommitEntry) IsValid() bool {
-	//double check the credits in the commit
-	if c.Credits < 1 || c.Version != 0 || c.Credits > 10 {
+	if !c.isWellFormed() {
 		return false
 	}
 
@@ -170,6 +169,12 @@ func (c *CommitEntry) IsValid() bool {
 	}
 }
 
+// isWellFormed checks everything IsValid() does but the signature
+func (c *CommitEntry) isWellFormed() bool {
+	//double check the credits in the commit
+	return 1 <= c.Credits && c.Credits <= 10 && c.Version == 0
+}
+
 func (c *CommitEntry) GetHash() interfaces.IHash {
 	h, _ := c.MarshalBinary()
 	return primitives.Sha(h)
@@ -0,0 +1,34 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package factoid
+
+import (
+	"math"
+
+	"github.com/FactomProject/factomd/common/constants"
+)
+
+// ActivationHeights are the first directory block heights at which a network accepts
+// transactions using newer features.  Before them, such transactions are rejected.
+type ActivationHeights struct {
+	RCD2          uint32 // Inputs redeemed by an RCD type 2 (m of n multisig)
+	TransactionV3 uint32 // Transactions with a lock or expiry height (TransactionVersionHeights)
+}
+
+// The activation heights of each network, by network ID.  Networks not listed never activate.
+// The shared networks only get a height once the server operators have agreed on one.
+var ActivationNetworks = map[uint32]ActivationHeights{
+	constants.MAIN_NETWORK_ID:  {RCD2: math.MaxUint32, TransactionV3: math.MaxUint32},
+	constants.TEST_NETWORK_ID:  {RCD2: math.MaxUint32, TransactionV3: math.MaxUint32},
+	constants.LOCAL_NETWORK_ID: {RCD2: 0, TransactionV3: 0},
+}
+
+// GetActivationHeights returns the activation heights of the network
+func GetActivationHeights(networkID uint32) ActivationHeights {
+	if heights, ok := ActivationNetworks[networkID]; ok {
+		return heights
+	}
+	return ActivationHeights{RCD2: math.MaxUint32, TransactionV3: math.MaxUint32}
+}
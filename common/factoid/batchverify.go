@@ -0,0 +1,85 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package factoid
+
+import (
+	"fmt"
+
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/common/primitives"
+)
+
+// batchRCD is an RCD whose signatures can be verified in a batch
+type batchRCD interface {
+	// addSignatures adds the signatures of data the RCD needs to the batch.  It is
+	// false if the signature block can't satisfy the RCD, however they verify.
+	addSignatures(batch *primitives.BatchVerifier, data []byte, sigblk interfaces.ISignatureBlock) bool
+}
+
+var _ batchRCD = (*RCD_1)(nil)
+var _ batchRCD = (*RCD_2)(nil)
+
+// ValidateSignaturesBatch checks the signatures of all the transactions in one
+// batch, and returns for each transaction what its ValidateSignatures would.
+func ValidateSignaturesBatch(transactions []interfaces.ITransaction) []error {
+	type pendingRCD struct {
+		trans       int
+		first, last int // The RCD's signatures in the batch
+	}
+
+	errs := make([]error, len(transactions))
+	missing := make([]int, len(transactions))
+	var pending []pendingRCD
+	batch := primitives.NewBatchVerifier()
+
+	for i, trans := range transactions {
+		rcds := trans.GetRCDs()
+		if len(rcds) == 0 {
+			continue
+		}
+		data, err := trans.MarshalBinarySig()
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		sigBlks := trans.GetSignatureBlocks()
+		for j, rcd := range rcds {
+			var sigblk interfaces.ISignatureBlock
+			if j < len(sigBlks) {
+				sigblk = sigBlks[j]
+			}
+			b, ok := rcd.(batchRCD)
+			if !ok {
+				if !rcd.CheckSig(trans, sigblk) {
+					missing[i]++
+				}
+				continue
+			}
+			first := batch.Len()
+			if !b.addSignatures(batch, data, sigblk) {
+				missing[i]++
+				continue
+			}
+			pending = append(pending, pendingRCD{trans: i, first: first, last: batch.Len()})
+		}
+	}
+
+	valid := batch.Verify()
+	for _, p := range pending {
+		for k := p.first; k < p.last; k++ {
+			if !valid[k] {
+				missing[p.trans]++
+				break
+			}
+		}
+	}
+
+	for i, trans := range transactions {
+		if errs[i] == nil && missing[i] != 0 {
+			errs[i] = fmt.Errorf("Missing %d of %d signatures", missing[i], len(trans.GetRCDs()))
+		}
+	}
+	return errs
+}
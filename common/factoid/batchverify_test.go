@@ -0,0 +1,41 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package factoid_test
+
+import (
+	"testing"
+
+	. "github.com/FactomProject/factomd/common/factoid"
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/testHelper"
+)
+
+func TestValidateSignaturesBatch(t *testing.T) {
+	var transactions []interfaces.ITransaction
+	for i := 0; i < 20; i++ {
+		tx := newMultisigTransaction(testHelper.NewFactoidRCDAddress(uint64(i + 1)))
+		data, _ := tx.MarshalBinarySig()
+		key := uint64(i + 1)
+		if i%4 == 3 {
+			key++ // Signed with the wrong key
+		}
+		tx.SetSignatureBlock(0, NewSingleSignatureBlock(testHelper.NewPrivKey(key), data))
+		transactions = append(transactions, tx)
+	}
+	multisig := newMultisigTransaction(newMultisig(2, 3))
+	multisig.SetSignatureBlock(0, multisigSignatures(multisig, 0, 2))
+	transactions = append(transactions, multisig)
+	transactions = append(transactions, new(Transaction)) // Like a coinbase
+
+	errs := ValidateSignaturesBatch(transactions)
+	for i, tx := range transactions {
+		if (errs[i] == nil) != (tx.ValidateSignatures() == nil) {
+			t.Errorf("Transaction %d: batch got %v, alone got %v", i, errs[i], tx.ValidateSignatures())
+		}
+		if (errs[i] == nil) == (i < 20 && i%4 == 3) {
+			t.Errorf("Transaction %d: got %v", i, errs[i])
+		}
+	}
+}
@@ -5,31 +5,92 @@
 package factoid
 
 import (
+	"fmt"
+	"math"
+
+	"github.com/FactomProject/factomd/common/constants"
 	"github.com/FactomProject/factomd/common/interfaces"
 )
 
-var adrs []interfaces.IAddress
-var amount uint64 = 5000000000 // One Factoid (remember, fixed point math!
-var addressCnt int = 0         // No coinbase payments until Milestone 3
+// CoinbaseParams are how a network pays its authority servers.  Every Frequency blocks
+// from ActivationHeight, the coinbase transaction pays each federated and audit server
+// to the coinbase address its identity declared at least Declaration blocks before.
+type CoinbaseParams struct {
+	ActivationHeight uint32
+	Frequency        uint32
+	Declaration      uint32
+	FederatedAmount  uint64 // Factoshis to each federated server
+	AuditAmount      uint64 // Factoshis to each audit server
+}
+
+// The coinbase payouts of each network, by network ID.  Networks not listed never pay.
+var CoinbaseNetworks = map[uint32]CoinbaseParams{
+	constants.MAIN_NETWORK_ID: {ActivationHeight: math.MaxUint32},
+	constants.TEST_NETWORK_ID: {ActivationHeight: math.MaxUint32},
+	constants.LOCAL_NETWORK_ID: {
+		ActivationHeight: 0,
+		Frequency:        10,
+		Declaration:      5,
+		FederatedAmount:  640000000,
+		AuditAmount:      64000000,
+	},
+}
+
+// GetCoinbaseParams returns the coinbase payouts of the network
+func GetCoinbaseParams(networkID uint32) CoinbaseParams {
+	if params, ok := CoinbaseNetworks[networkID]; ok {
+		return params
+	}
+	return CoinbaseParams{ActivationHeight: math.MaxUint32}
+}
+
+// IsActive is true if the coinbase of the block at dbheight must be checked
+func (p CoinbaseParams) IsActive(dbheight uint32) bool {
+	return p.ActivationHeight != math.MaxUint32 && dbheight >= p.ActivationHeight
+}
 
-// Allows the amount paid in the coinbase to be modified.   This is
-// NOT allowed in production!  That's why it is here in Test!
-func UpdateAmount(amt uint64) {
-	amount = amt
+// PaysAt is true if the coinbase of the block at dbheight pays the authority servers
+func (p CoinbaseParams) PaysAt(dbheight uint32) bool {
+	return p.IsActive(dbheight) && dbheight > 0 && p.Frequency > 0 && dbheight%p.Frequency == 0
 }
 
-// This routine generates the Coinbase.  This is a fixed amount to be
-// paid to the federated servers.
-//
-// Currently we are paying just a few fixed addresses.
-//
-func GetCoinbase(ftime interfaces.Timestamp) interfaces.ITransaction {
+// CoinbasePayout is an output of the coinbase transaction
+type CoinbasePayout struct {
+	Address interfaces.IAddress
+	Amount  uint64
+}
+
+// This routine generates the Coinbase.  It pays each payout in order, and must be
+// deterministic so that all servers will know and expect its outputs.
+func GetCoinbase(ftime interfaces.Timestamp, payouts []CoinbasePayout) interfaces.ITransaction {
 	coinbase := new(Transaction)
 	coinbase.SetTimestamp(ftime)
 
-	for _, adr := range adrs {
-		coinbase.AddOutput(adr, amount) // add specified amount
+	for _, p := range payouts {
+		coinbase.AddOutput(p.Address, p.Amount)
 	}
 
 	return coinbase
 }
+
+// CheckCoinbase returns an error if the coinbase transaction does not pay exactly the
+// payouts, in order
+func CheckCoinbase(coinbase interfaces.ITransaction, payouts []CoinbasePayout) error {
+	if coinbase == nil {
+		return fmt.Errorf("Block has no coinbase transaction")
+	}
+	if len(coinbase.GetInputs()) != 0 || len(coinbase.GetECOutputs()) != 0 {
+		return fmt.Errorf("The coinbase transaction can only have factoid outputs")
+	}
+	outputs := coinbase.GetOutputs()
+	if len(outputs) != len(payouts) {
+		return fmt.Errorf("The coinbase transaction has %d outputs, expected %d", len(outputs), len(payouts))
+	}
+	for i, p := range payouts {
+		if !outputs[i].GetAddress().IsSameAs(p.Address) || outputs[i].GetAmount() != p.Amount {
+			return fmt.Errorf("The coinbase transaction output %d pays %d to %x, expected %d to %x",
+				i, outputs[i].GetAmount(), outputs[i].GetAddress().Bytes(), p.Amount, p.Address.Bytes())
+		}
+	}
+	return nil
+}
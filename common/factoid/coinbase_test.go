@@ -0,0 +1,66 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package factoid_test
+
+import (
+	"testing"
+
+	"github.com/FactomProject/factomd/common/constants"
+	. "github.com/FactomProject/factomd/common/factoid"
+	"github.com/FactomProject/factomd/common/primitives"
+)
+
+func TestCoinbaseParams(t *testing.T) {
+	for _, id := range []uint32{constants.MAIN_NETWORK_ID, constants.TEST_NETWORK_ID, 0x12345678} {
+		params := GetCoinbaseParams(id)
+		if params.IsActive(0) || params.IsActive(4294967294) || params.PaysAt(1000) {
+			t.Errorf("Network %x pays a coinbase", id)
+		}
+	}
+
+	local := GetCoinbaseParams(constants.LOCAL_NETWORK_ID)
+	if !local.IsActive(1) || local.FederatedAmount == 0 || local.Frequency == 0 {
+		t.Fatalf("LOCAL network does not pay a coinbase: %+v", local)
+	}
+	if local.PaysAt(0) || local.PaysAt(local.Frequency-1) || !local.PaysAt(local.Frequency) || !local.PaysAt(3*local.Frequency) {
+		t.Errorf("LOCAL network pays at the wrong heights")
+	}
+}
+
+func TestCheckCoinbase(t *testing.T) {
+	a := NewAddress(primitives.Sha([]byte("a")).Bytes())
+	b := NewAddress(primitives.Sha([]byte("b")).Bytes())
+	payouts := []CoinbasePayout{{Address: a, Amount: 5}, {Address: b, Amount: 7}}
+	ts := primitives.NewTimestampFromSeconds(1000)
+
+	coinbase := GetCoinbase(ts, payouts)
+	if err := CheckCoinbase(coinbase, payouts); err != nil {
+		t.Error(err)
+	}
+	if err := CheckCoinbase(GetCoinbase(ts, nil), nil); err != nil {
+		t.Error(err)
+	}
+	fblock := NewFBlock(nil)
+	if err := fblock.AddCoinbase(coinbase); err != nil {
+		t.Errorf("AddCoinbase() %v", err)
+	}
+
+	bad := map[string][]CoinbasePayout{
+		"missing":    payouts[:1],
+		"extra":      append(payouts, CoinbasePayout{Address: a, Amount: 1}),
+		"amount":     {{Address: a, Amount: 5}, {Address: b, Amount: 8}},
+		"address":    {{Address: a, Amount: 5}, {Address: a, Amount: 7}},
+		"order":      {payouts[1], payouts[0]},
+		"no payouts": nil,
+	}
+	for name, p := range bad {
+		if err := CheckCoinbase(coinbase, p); err == nil {
+			t.Errorf("CheckCoinbase() passed a coinbase with the wrong payouts: %s", name)
+		}
+	}
+	if err := CheckCoinbase(nil, nil); err == nil {
+		t.Error("CheckCoinbase() passed a block without a coinbase")
+	}
+}
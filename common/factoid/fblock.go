@@ -484,7 +484,13 @@ func (b *FBlock) GetExchRate() uint64 {
 	return b.ExchRate
 }
 
-func (b FBlock) ValidateTransaction(index int, trans interfaces.ITransaction) error {
+func (b FBlock) ValidateTransaction(networkID uint32, index int, trans interfaces.ITransaction) error {
+	return b.validateTransaction(networkID, index, trans, trans.ValidateSignatures)
+}
+
+// validateTransaction validates the transaction on the network, using validateSignatures
+// to check its signatures
+func (b FBlock) validateTransaction(networkID uint32, index int, trans interfaces.ITransaction, validateSignatures func() error) error {
 	// Calculate the fee due.
 	{
 		err := trans.Validate(index)
@@ -493,9 +499,26 @@ func (b FBlock) ValidateTransaction(index int, trans interfaces.ITransaction) er
 		}
 	}
 
+	if err := CheckRCDActivation(trans, networkID, b.DBHeight); err != nil {
+		return err
+	}
+
+	if err := CheckVersionActivation(trans, networkID, b.DBHeight); err != nil {
+		return err
+	}
+
+	if err := CheckLockHeights(trans, b.DBHeight); err != nil {
+		return err
+	}
+
+	// The coinbase has no inputs to sign or to pay a fee; its payouts are checked by the state
+	if index == 0 {
+		return nil
+	}
+
 	//Ignore coinbase transaction's signatures
 	if len(b.Transactions) > 0 {
-		err := trans.ValidateSignatures()
+		err := validateSignatures()
 		if err != nil {
 			return err
 		}
@@ -533,10 +556,14 @@ func (b FBlock) ValidateTransaction(index int, trans interfaces.ITransaction) er
 	return nil
 }
 
-func (b FBlock) Validate() error {
+func (b FBlock) Validate(networkID uint32) error {
+	// Verify the signatures of the whole block at once
+	sigErrs := ValidateSignaturesBatch(b.Transactions)
 	for i, trans := range b.Transactions {
-		if err := b.ValidateTransaction(i, trans); err != nil {
-			return nil
+		sigErr := sigErrs[i]
+		validateSignatures := func() error { return sigErr }
+		if err := b.validateTransaction(networkID, i, trans, validateSignatures); err != nil {
+			return err
 		}
 		if i == 0 {
 			if len(trans.GetInputs()) != 0 {
@@ -558,7 +585,7 @@ func (b FBlock) Validate() error {
 	b.CalculateHashes()
 
 	// Make sure nothing changes.  If something did, this block is bad.
-	if mr != b.BodyMR {
+	if mr != nil && !mr.IsSameAs(b.BodyMR) {
 		return fmt.Errorf("This blocks Merkle Root of the transactions does not match the transactions")
 	}
 
@@ -594,11 +621,11 @@ func (b *FBlock) AddCoinbase(trans interfaces.ITransaction) error {
 
 // Add the given transaction to this block.  Reports an error if this
 // cannot be done, or if the transaction is invalid.
-func (b *FBlock) AddTransaction(trans interfaces.ITransaction) error {
+func (b *FBlock) AddTransaction(networkID uint32, trans interfaces.ITransaction) error {
 	// These tests check that the Transaction itself is valid.  If it
 	// is not internally valid, it never will be valid.
 	b.BodyMR = nil
-	err := b.ValidateTransaction(len(b.Transactions), trans)
+	err := b.ValidateTransaction(networkID, len(b.Transactions), trans)
 	if err != nil {
 		return err
 	}
@@ -6,8 +6,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/FactomProject/factomd/common/constants"
 	. "github.com/FactomProject/factomd/common/factoid"
 	"github.com/FactomProject/factomd/common/primitives"
+	"github.com/FactomProject/factomd/testHelper"
 )
 
 func TestUnmarshalNilFBlock(t *testing.T) {
@@ -84,7 +86,7 @@ func TestMarshalUnmarshal(t *testing.T) {
 			t.Errorf("Wrong SecondaryIndex - %v vs %v", f.DatabaseSecondaryIndex().String(), tBlock.Hash)
 		}
 
-		err = f.Validate()
+		err = f.Validate(constants.MAIN_NETWORK_ID)
 		if err != nil {
 			t.Errorf("%v", err)
 		}
@@ -188,6 +190,45 @@ func TestMerkleTrees(t *testing.T) {
 	*/
 }
 
+func TestValidateBadSignature(t *testing.T) {
+	f := testHelper.CreateTestFactoidBlock(nil)
+	if err := f.Validate(constants.LOCAL_NETWORK_ID); err != nil {
+		t.Fatalf("Validate() failed on a good block: %v", err)
+	}
+
+	tx := f.GetTransactions()[1]
+	data, _ := tx.MarshalBinarySig()
+	tx.SetSignatureBlock(0, NewSingleSignatureBlock(testHelper.NewPrivKey(1), data)) // Signed with the wrong key
+	if err := f.Validate(constants.LOCAL_NETWORK_ID); err == nil {
+		t.Errorf("Validate() accepted a block with a bad signature")
+	}
+}
+
+func TestValidateLockedTransaction(t *testing.T) {
+	f := testHelper.CreateTestFactoidBlock(nil).(*FBlock)
+
+	tx := new(Transaction)
+	tx.LockHeight = f.DBHeight + 1
+	tx.AddInput(testHelper.NewFactoidAddress(0), 1000)
+	tx.AddOutput(testHelper.NewFactoidAddress(1), 1000)
+	fee, err := tx.CalculateFee(f.ExchRate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	in, _ := tx.GetInput(0)
+	in.SetAmount(in.GetAmount() + fee*2) // The signature adds to the fee
+	testHelper.SignFactoidTransaction(0, tx)
+	f.Transactions = append(f.Transactions, tx)
+
+	if err := f.Validate(constants.LOCAL_NETWORK_ID); err == nil {
+		t.Errorf("Validate() accepted a transaction locked until %d at height %d", tx.LockHeight, f.DBHeight)
+	}
+	f.DBHeight = tx.LockHeight
+	if err := f.Validate(constants.LOCAL_NETWORK_ID); err != nil {
+		t.Errorf("Validate() failed at the lock height: %v", err)
+	}
+}
+
 func TestFBlockDump(t *testing.T) {
 	var i uint32
 	i = 1
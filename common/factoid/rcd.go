@@ -52,16 +52,30 @@ func NewRCD_2(n int, m int, addresses []interfaces.IAddress) (interfaces.IRCD, e
 	if len(addresses) != m {
 		return nil, fmt.Errorf("Improper number of addresses.  m = %d n = %d #addresses = %d", m, n, len(addresses))
 	}
+	if n < 1 || n > m {
+		return nil, fmt.Errorf("Improper number of signatures.  m = %d n = %d", m, n)
+	}
 
 	au := new(RCD_2)
 	au.N = n
 	au.M = m
 	au.N_Addresses = make([]interfaces.IAddress, len(addresses), len(addresses))
 	copy(au.N_Addresses, addresses)
+	if err := au.validate(); err != nil {
+		return nil, err
+	}
 
 	return au, nil
 }
 
+// NewSignatureBlock returns an empty signature block of the kind the RCD checks
+func NewSignatureBlock(rcd interfaces.IRCD) interfaces.ISignatureBlock {
+	if _, ok := rcd.(*RCD_2); ok {
+		return new(RCD2SignatureBlock)
+	}
+	return new(SignatureBlock)
+}
+
 func CreateRCD(data []byte) interfaces.IRCD {
 	switch data[0] {
 	case 1:
@@ -91,6 +91,22 @@ func (w RCD_1) CheckSig(trans interfaces.ITransaction, sigblk interfaces.ISignat
 	return w.validSig
 }
 
+func (w RCD_1) addSignatures(batch *primitives.BatchVerifier, data []byte, sigblk interfaces.ISignatureBlock) bool {
+	if sigblk == nil {
+		return false
+	}
+	signature := sigblk.GetSignature(0)
+	if signature == nil {
+		return false
+	}
+	cryptosig := signature.GetSignature()
+	if cryptosig == nil {
+		return false
+	}
+	batch.Add(w.PublicKey[:], data, cryptosig[:])
+	return true
+}
+
 func (w RCD_1) Clone() interfaces.IRCD {
 	c := new(RCD_1)
 	copy(c.PublicKey[:], w.PublicKey[:])
@@ -7,8 +7,10 @@ package factoid
 import (
 	"encoding/binary"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 
+	"github.com/FactomProject/factomd/common/constants"
 	"github.com/FactomProject/factomd/common/interfaces"
 	"github.com/FactomProject/factomd/common/primitives"
 )
@@ -18,36 +20,56 @@ import (
  ************************/
 
 // Type 2 RCD implement multisig
-// m of n
+// n of m
 // Must have m addresses from which to choose, no fewer, no more
-// Must have n RCD, no fewer no more.
+// Must have n signers, each revealing the RCD behind one of the
+// addresses and signing with it.
 // NOTE: This does mean you can have a multisig nested in a
 // multisig.  It just works.
 
 type RCD_2 struct {
-	M           int                   // Number signatures required
-	N           int                   // Total sigatures possible
-	N_Addresses []interfaces.IAddress // n addresses
+	M           int                   // Number of addresses
+	N           int                   // Number of signatures required
+	N_Addresses []interfaces.IAddress // m addresses
 }
 
 var _ interfaces.IRCD = (*RCD_2)(nil)
 
-/*************************************
- *       Stubs
- *************************************/
+// CheckRCDActivation returns an error if the transaction is redeemed by an RCD
+// type that isn't active yet on the network at this directory block height.
+func CheckRCDActivation(trans interfaces.ITransaction, networkID uint32, dbheight uint32) error {
+	activation := GetActivationHeights(networkID).RCD2
+	if dbheight >= activation {
+		return nil
+	}
+	for i, rcd := range trans.GetRCDs() {
+		if _, ok := rcd.(*RCD_2); ok {
+			return fmt.Errorf("RCD %d is a multisig RCD, which is not active until height %d", i, activation)
+		}
+	}
+	return nil
+}
+
+/***************************************
+ *       Methods
+ ***************************************/
 
+// GetAddress returns the hash of the RCD, as for an RCD_1
 func (b RCD_2) GetAddress() (interfaces.IAddress, error) {
-	return nil, nil
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+	data, err := b.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return CreateAddress(primitives.Shad(data)), nil
 }
 
 func (b RCD_2) NumberOfSignatures() int {
-	return 1
+	return b.N
 }
 
-/***************************************
- *       Methods
- ***************************************/
-
 func (b RCD_2) IsSameAs(rcd interfaces.IRCD) bool {
 	return b.String() == rcd.String()
 }
@@ -57,20 +79,83 @@ func (b RCD_2) UnmarshalBinary(data []byte) error {
 	return err
 }
 
+// CheckSig is true if at least n of the addresses signed.  The signature block
+// must be an RCD2SignatureBlock, where every signer reveals an RCD that hashes to
+// its address and whose own signature block it checks.
 func (b RCD_2) CheckSig(trans interfaces.ITransaction, sigblk interfaces.ISignatureBlock) bool {
-	return false
+	data, err := trans.MarshalBinarySig()
+	if err != nil {
+		return false
+	}
+	batch := primitives.NewBatchVerifier()
+	return b.addSignatures(batch, data, sigblk) && batch.VerifyAll()
+}
+
+func (b RCD_2) addSignatures(batch *primitives.BatchVerifier, data []byte, sigblk interfaces.ISignatureBlock) bool {
+	if b.validate() != nil {
+		return false
+	}
+	block, ok := sigblk.(*RCD2SignatureBlock)
+	if !ok || block == nil {
+		return false
+	}
+
+	signed := make(map[int]bool)
+	for _, signer := range block.Signers {
+		if signer.Index < 0 || signer.Index >= b.M || signed[signer.Index] || signer.RCD == nil {
+			return false
+		}
+		address, err := signer.RCD.GetAddress()
+		if err != nil || !address.IsSameAs(b.N_Addresses[signer.Index]) {
+			return false
+		}
+		nested, ok := signer.RCD.(batchRCD)
+		if !ok || !nested.addSignatures(batch, data, signer.SigBlock) {
+			return false
+		}
+		signed[signer.Index] = true
+	}
+	return len(signed) >= b.N
+}
+
+func (b RCD_2) validate() error {
+	if b.N < 1 || b.N > b.M {
+		return fmt.Errorf("RCD_2 requires %d of %d signatures", b.N, b.M)
+	}
+	if len(b.N_Addresses) != b.M {
+		return fmt.Errorf("RCD_2 has %d addresses, expected %d", len(b.N_Addresses), b.M)
+	}
+	// One key listed twice would count twice towards the n signatures
+	listed := make(map[[32]byte]bool)
+	for _, address := range b.N_Addresses {
+		if address == nil {
+			return fmt.Errorf("RCD_2 has a nil address")
+		}
+		if listed[address.Fixed()] {
+			return fmt.Errorf("RCD_2 lists the address %x more than once", address.Bytes())
+		}
+		listed[address.Fixed()] = true
+	}
+	return nil
 }
 
 func (e *RCD_2) JSONByte() ([]byte, error) {
 	return primitives.EncodeJSON(e)
 }
 
-// TODO: Fix Json marshaling of RCD_2. Right now the RCD type
-// is not included in the json marshal.
 func (e *RCD_2) JSONString() (string, error) {
 	return primitives.EncodeJSONString(e)
 }
 
+// MarshalJSON writes the RCD as hex, type first, like an RCD_1
+func (e *RCD_2) MarshalJSON() ([]byte, error) {
+	data, err := e.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(fmt.Sprintf("%x", data))
+}
+
 func (b RCD_2) String() string {
 	txt, err := b.CustomMarshalText()
 	if err != nil {
@@ -102,6 +187,12 @@ func (t *RCD_2) UnmarshalBinaryData(data []byte) (newData []byte, err error) {
 
 	t.N, data = int(binary.BigEndian.Uint16(data[0:2])), data[2:]
 	t.M, data = int(binary.BigEndian.Uint16(data[0:2])), data[2:]
+	if t.N < 1 || t.N > t.M {
+		return nil, fmt.Errorf("RCD_2 requires %d of %d signatures", t.N, t.M)
+	}
+	if len(data) < t.M*constants.ADDRESS_LENGTH {
+		return nil, fmt.Errorf("Not enough data to unmarshal %d addresses", t.M)
+	}
 
 	t.N_Addresses = make([]interfaces.IAddress, t.M, t.M)
 
@@ -112,6 +203,9 @@ func (t *RCD_2) UnmarshalBinaryData(data []byte) (newData []byte, err error) {
 			return nil, err
 		}
 	}
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
 
 	return data, nil
 }
@@ -6,10 +6,13 @@ package factoid_test
 
 import (
 	"math/rand"
+	"strings"
 	"testing"
 
+	"github.com/FactomProject/factomd/common/constants"
 	. "github.com/FactomProject/factomd/common/factoid"
 	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/testHelper"
 )
 
 func TestUnmarshalNilRCD_2(t *testing.T) {
@@ -108,3 +111,177 @@ func nextAuth2_rcd2() *RCD_2 {
 	rcd, _ := NewRCD_2(n, m, addresses)
 	return rcd.(*RCD_2)
 }
+
+// newMultisig returns an n of m RCD_2 over the RCD_1s of keys 1 to m
+func newMultisig(n, m int) *RCD_2 {
+	addresses := make([]interfaces.IAddress, m)
+	for i := range addresses {
+		addresses[i], _ = testHelper.NewFactoidRCDAddress(uint64(i + 1)).GetAddress()
+	}
+	rcd, err := NewRCD_2(n, m, addresses)
+	if err != nil {
+		panic(err)
+	}
+	return rcd.(*RCD_2)
+}
+
+// multisigSignatures signs the transaction with the keys of the given addresses of newMultisig
+func multisigSignatures(tx interfaces.ITransaction, indexes ...int) *RCD2SignatureBlock {
+	data, err := tx.MarshalBinarySig()
+	if err != nil {
+		panic(err)
+	}
+	sigblk := new(RCD2SignatureBlock)
+	for _, i := range indexes {
+		sigblk.AddSigner(i, testHelper.NewFactoidRCDAddress(uint64(i+1)), NewSingleSignatureBlock(testHelper.NewPrivKey(uint64(i+1)), data))
+	}
+	return sigblk
+}
+
+func newMultisigTransaction(rcd interfaces.IRCD) *Transaction {
+	address, err := rcd.GetAddress()
+	if err != nil {
+		panic(err)
+	}
+	tx := new(Transaction)
+	tx.AddInput(address, 1000)
+	tx.AddOutput(testHelper.NewFactoidAddress(10), 1000)
+	tx.AddAuthorization(rcd)
+	return tx
+}
+
+func TestRCD2Address(t *testing.T) {
+	rcd := newMultisig(2, 3)
+	address, err := rcd.GetAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, _ := newMultisig(1, 3).GetAddress()
+	if address.IsSameAs(other) {
+		t.Errorf("2 of 3 and 1 of 3 have the same address")
+	}
+
+	if _, err := NewRCD_2(0, 1, rcd.N_Addresses[:1]); err == nil {
+		t.Errorf("NewRCD_2() accepted 0 of 1")
+	}
+	if _, err := NewRCD_2(4, 3, rcd.N_Addresses); err == nil {
+		t.Errorf("NewRCD_2() accepted 4 of 3")
+	}
+	data, _ := rcd.MarshalBinary()
+	data[2] = 9 // n
+	if _, err := new(RCD_2).UnmarshalBinaryData(data); err == nil {
+		t.Errorf("UnmarshalBinaryData() accepted 9 of 3")
+	}
+
+	// One key listed twice can't make up two of the signatures
+	duplicated := []interfaces.IAddress{rcd.N_Addresses[0], rcd.N_Addresses[1], rcd.N_Addresses[0]}
+	if _, err := NewRCD_2(2, 3, duplicated); err == nil {
+		t.Errorf("NewRCD_2() accepted a duplicate address")
+	}
+	data, _ = rcd.MarshalBinary()
+	copy(data[5+2*constants.ADDRESS_LENGTH:], data[5:5+constants.ADDRESS_LENGTH])
+	if _, err := new(RCD_2).UnmarshalBinaryData(data); err == nil {
+		t.Errorf("UnmarshalBinaryData() accepted a duplicate address")
+	}
+
+	js, err := rcd.JSONString()
+	if err != nil || !strings.HasPrefix(js, "\"0200020003") {
+		t.Errorf("JSONString() got %s, %v", js, err)
+	}
+}
+
+func TestRCD2CheckSig(t *testing.T) {
+	rcd := newMultisig(2, 3)
+	tx := newMultisigTransaction(rcd)
+	if err := tx.Validate(1); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		indexes []int
+		valid   bool
+	}{
+		{[]int{0, 1}, true},
+		{[]int{2, 0}, true},
+		{[]int{0, 1, 2}, true},
+		{[]int{1}, false},
+		{[]int{1, 1}, false},
+		{[]int{0, 3}, false},
+	} {
+		if got := rcd.CheckSig(tx, multisigSignatures(tx, test.indexes...)); got != test.valid {
+			t.Errorf("CheckSig() signed by %v got %v", test.indexes, got)
+		}
+	}
+
+	// The revealed RCD must be the one behind the address
+	sigblk := multisigSignatures(tx, 0, 1)
+	sigblk.Signers[1].RCD = testHelper.NewFactoidRCDAddress(3)
+	if rcd.CheckSig(tx, sigblk) {
+		t.Errorf("CheckSig() accepted a signer revealing another RCD")
+	}
+	if rcd.CheckSig(tx, NewSingleSignatureBlock(testHelper.NewPrivKey(1), []byte{})) {
+		t.Errorf("CheckSig() accepted a single signature block")
+	}
+}
+
+func TestRCD2Nested(t *testing.T) {
+	inner := newMultisig(2, 2)
+	innerAddress, _ := inner.GetAddress()
+	key3, _ := testHelper.NewFactoidRCDAddress(3).GetAddress()
+	outer, _ := NewRCD_2(2, 2, []interfaces.IAddress{innerAddress, key3})
+	tx := newMultisigTransaction(outer)
+
+	sigblk := new(RCD2SignatureBlock)
+	sigblk.AddSigner(0, inner, multisigSignatures(tx, 0, 1))
+	data, _ := tx.MarshalBinarySig()
+	sigblk.AddSigner(1, testHelper.NewFactoidRCDAddress(3), NewSingleSignatureBlock(testHelper.NewPrivKey(3), data))
+	tx.SetSignatureBlock(0, sigblk)
+	if err := tx.ValidateSignatures(); err != nil {
+		t.Fatal(err)
+	}
+	if len(sigblk.GetSignatures()) != 3 {
+		t.Errorf("GetSignatures() got %d signatures", len(sigblk.GetSignatures()))
+	}
+
+	// Marshalled, the nested signatures still check
+	bin, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx2 := new(Transaction)
+	if err := tx2.UnmarshalBinary(bin); err != nil {
+		t.Fatal(err)
+	}
+	if !tx2.IsSameAs(tx) || tx2.ValidateSignatures() != nil {
+		t.Errorf("Unmarshalled transaction differs or fails its signatures")
+	}
+
+	// Without the inner multisig's second signature, the outer fails
+	sigblk.Signers[0].SigBlock = multisigSignatures(tx, 0)
+	if err := tx.ValidateSignatures(); err == nil {
+		t.Errorf("ValidateSignatures() accepted a nested multisig missing a signature")
+	}
+}
+
+func TestCheckRCDActivation(t *testing.T) {
+	tx := newMultisigTransaction(newMultisig(1, 1))
+	if CheckRCDActivation(tx, constants.MAIN_NETWORK_ID, 1<<31) == nil {
+		t.Errorf("RCD_2 accepted on the main network")
+	}
+	if err := CheckRCDActivation(tx, constants.LOCAL_NETWORK_ID, 0); err != nil {
+		t.Error(err)
+	}
+	if CheckRCDActivation(tx, 0x12345678, 1<<31) == nil {
+		t.Errorf("RCD_2 accepted on a network it never activates on")
+	}
+
+	// Each network has its own height
+	ActivationNetworks[0x12345678] = ActivationHeights{RCD2: 100, TransactionV3: 100}
+	defer delete(ActivationNetworks, 0x12345678)
+	if CheckRCDActivation(tx, 0x12345678, 99) == nil {
+		t.Errorf("RCD_2 accepted before its activation")
+	}
+	if err := CheckRCDActivation(tx, 0x12345678, 100); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package factoid
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/common/primitives"
+)
+
+/**************************************
+ * RCD2SignatureBlock
+ *
+ * The signature block of an input redeemed by an RCD_2.  Each signer names
+ * the address it signs for by its index in the RCD_2, reveals the RCD behind
+ * that address, and gives the signature block that RCD checks.  Since that
+ * RCD may itself be an RCD_2, multisigs nest.
+ *
+ * Binary:  uint8 number of signers, then for each signer
+ *          uint16 index, the RCD, the RCD's signature block
+ **************************************/
+
+type RCD2Signer struct {
+	Index    int                        `json:"index"`
+	RCD      interfaces.IRCD            `json:"rcd"`
+	SigBlock interfaces.ISignatureBlock `json:"sigblock"`
+}
+
+type RCD2SignatureBlock struct {
+	Signers []*RCD2Signer `json:"signers"`
+}
+
+var _ interfaces.ISignatureBlock = (*RCD2SignatureBlock)(nil)
+
+// AddSigner adds the signature block of the RCD behind the address at index.
+func (b *RCD2SignatureBlock) AddSigner(index int, rcd interfaces.IRCD, sigblk interfaces.ISignatureBlock) {
+	b.Signers = append(b.Signers, &RCD2Signer{Index: index, RCD: rcd, SigBlock: sigblk})
+}
+
+// AddSignature adds the signature to the last signer added, so a signer can
+// be added with an empty signature block and signed after.
+func (b *RCD2SignatureBlock) AddSignature(sig interfaces.ISignature) {
+	if len(b.Signers) == 0 {
+		return
+	}
+	signer := b.Signers[len(b.Signers)-1]
+	if signer.SigBlock == nil {
+		signer.SigBlock = NewSignatureBlock(signer.RCD)
+	}
+	signer.SigBlock.AddSignature(sig)
+}
+
+// GetSignature returns the index'th of the signatures of all the signers
+func (b *RCD2SignatureBlock) GetSignature(index int) interfaces.ISignature {
+	sigs := b.GetSignatures()
+	if index < 0 || len(sigs) <= index {
+		return nil
+	}
+	return sigs[index]
+}
+
+// GetSignatures returns the signatures of all the signers, in order
+func (b *RCD2SignatureBlock) GetSignatures() []interfaces.ISignature {
+	sigs := make([]interfaces.ISignature, 0, len(b.Signers))
+	for _, signer := range b.Signers {
+		if signer.SigBlock != nil {
+			sigs = append(sigs, signer.SigBlock.GetSignatures()...)
+		}
+	}
+	return sigs
+}
+
+func (b *RCD2SignatureBlock) IsSameAs(s interfaces.ISignatureBlock) bool {
+	if s == nil {
+		return b == nil
+	}
+	other, ok := s.(*RCD2SignatureBlock)
+	if !ok {
+		return false
+	}
+	data, err := b.MarshalBinary()
+	if err != nil {
+		return false
+	}
+	data2, err := other.MarshalBinary()
+	if err != nil {
+		return false
+	}
+	return bytes.Compare(data, data2) == 0
+}
+
+func (b *RCD2SignatureBlock) MarshalBinary() ([]byte, error) {
+	if len(b.Signers) > 255 {
+		return nil, fmt.Errorf("Too many signers: %d", len(b.Signers))
+	}
+	buf := primitives.NewBuffer(nil)
+	err := buf.PushUInt8(uint8(len(b.Signers)))
+	if err != nil {
+		return nil, err
+	}
+	for _, signer := range b.Signers {
+		if signer.RCD == nil {
+			return nil, fmt.Errorf("Signer %d has no RCD", signer.Index)
+		}
+		err = buf.PushUInt16(uint16(signer.Index))
+		if err != nil {
+			return nil, err
+		}
+		err = buf.PushBinaryMarshallable(signer.RCD)
+		if err != nil {
+			return nil, err
+		}
+		sigblk := signer.SigBlock
+		if sigblk == nil {
+			sigblk = NewSignatureBlock(signer.RCD)
+		}
+		err = buf.PushBinaryMarshallable(sigblk)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buf.DeepCopyBytes(), nil
+}
+
+func (b *RCD2SignatureBlock) UnmarshalBinaryData(data []byte) ([]byte, error) {
+	buf := primitives.NewBuffer(data)
+	count, err := buf.PopUInt8()
+	if err != nil {
+		return nil, err
+	}
+	b.Signers = make([]*RCD2Signer, int(count))
+	for i := range b.Signers {
+		signer := new(RCD2Signer)
+		index, err := buf.PopUInt16()
+		if err != nil {
+			return nil, err
+		}
+		signer.Index = int(index)
+
+		typ, err := buf.PeekByte()
+		if err != nil {
+			return nil, err
+		}
+		if typ != 1 && typ != 2 {
+			return nil, fmt.Errorf("Invalid type byte for signer %d: %x", i, typ)
+		}
+		signer.RCD = CreateRCD([]byte{typ})
+		err = buf.PopBinaryMarshallable(signer.RCD)
+		if err != nil {
+			return nil, err
+		}
+
+		signer.SigBlock = NewSignatureBlock(signer.RCD)
+		err = buf.PopBinaryMarshallable(signer.SigBlock)
+		if err != nil {
+			return nil, err
+		}
+		b.Signers[i] = signer
+	}
+	return buf.DeepCopyBytes(), nil
+}
+
+func (b *RCD2SignatureBlock) UnmarshalBinary(data []byte) error {
+	_, err := b.UnmarshalBinaryData(data)
+	return err
+}
+
+func (b *RCD2SignatureBlock) JSONByte() ([]byte, error) {
+	return primitives.EncodeJSON(b)
+}
+
+func (b *RCD2SignatureBlock) JSONString() (string, error) {
+	return primitives.EncodeJSONString(b)
+}
+
+func (b *RCD2SignatureBlock) String() string {
+	txt, err := b.CustomMarshalText()
+	if err != nil {
+		return "<error>"
+	}
+	return string(txt)
+}
+
+func (b *RCD2SignatureBlock) CustomMarshalText() ([]byte, error) {
+	var out primitives.Buffer
+
+	out.WriteString("Multisig Signature Block: \n")
+	for _, signer := range b.Signers {
+		out.WriteString(fmt.Sprintf(" signer %d: ", signer.Index))
+		if signer.RCD != nil {
+			out.WriteString(signer.RCD.String())
+		}
+		if signer.SigBlock != nil {
+			txt, err := signer.SigBlock.CustomMarshalText()
+			if err != nil {
+				return nil, err
+			}
+			out.Write(txt)
+		}
+		out.WriteString("\n ")
+	}
+
+	return out.DeepCopyBytes(), nil
+}
@@ -23,8 +23,11 @@ type Transaction struct {
 	sigValid    bool
 
 	// Marshalled in MarshalBinary()
-	// version     uint64         Version of transaction. Hardcoded, naturally.
+	// version     uint64         Version of transaction. See GetVersion()
 	MilliTimestamp uint64 `json:"millitimestamp"`
+	// Only in version 3 transactions.  Zero means no limit.
+	LockHeight   uint32 `json:"lockheight,omitempty"`   // Not valid in blocks below this height
+	ExpiryHeight uint32 `json:"expiryheight,omitempty"` // Not valid in blocks above this height
 	// #inputs     uint8          number of inputs
 	// #outputs    uint8          number of outputs
 	// #ecoutputs  uint8          number of outECs (Number of EntryCredits)
@@ -49,6 +52,9 @@ func (t *Transaction) IsSameAs(trans interfaces.ITransaction) bool {
 	if t.GetTimestamp().GetTimeMilliUInt64() != trans.GetTimestamp().GetTimeMilliUInt64() {
 		return false
 	}
+	if t.LockHeight != trans.GetLockHeight() || t.ExpiryHeight != trans.GetExpiryHeight() {
+		return false
+	}
 	ins := trans.GetInputs()
 	if len(t.Inputs) != len(ins) {
 		return false
@@ -115,8 +121,76 @@ func (t *Transaction) clearCaches() {
 	return
 }
 
-func (*Transaction) GetVersion() uint64 {
-	return 2
+const (
+	// The original transaction format
+	TransactionVersion uint64 = 2
+	// Adds a lock height and an expiry height after the timestamp
+	TransactionVersionHeights uint64 = 3
+)
+
+// GetVersion returns the version of the transaction's format.  Transactions
+// without a lock or expiry height keep the original format, so their hashes
+// are the same as ever.
+func (t *Transaction) GetVersion() uint64 {
+	if t.LockHeight != 0 || t.ExpiryHeight != 0 {
+		return TransactionVersionHeights
+	}
+	return TransactionVersion
+}
+
+func (t *Transaction) GetLockHeight() uint32 {
+	return t.LockHeight
+}
+
+func (t *Transaction) GetExpiryHeight() uint32 {
+	return t.ExpiryHeight
+}
+
+// CheckVersionActivation returns an error if the transaction's format isn't active
+// yet on the network at this directory block height.
+func CheckVersionActivation(trans interfaces.ITransaction, networkID uint32, dbheight uint32) error {
+	if trans.GetVersion() != TransactionVersionHeights {
+		return nil
+	}
+	if activation := GetActivationHeights(networkID).TransactionV3; dbheight < activation {
+		return fmt.Errorf("Transactions with lock or expiry heights are not active until height %d", activation)
+	}
+	return nil
+}
+
+// MaxLockMinutes is how far ahead of the block being built a transaction may be locked.
+// A transaction is only taken within an hour of its timestamp (see state.Replay), so one
+// locked until a block more than an hour away waits in holding and is dropped unmined.
+const MaxLockMinutes = 60
+
+// CheckLockReachable returns an error if the transaction is locked until a block more than
+// MaxLockMinutes after the block at dbheight, given blocks of blockSeconds.
+func CheckLockReachable(trans interfaces.ITransaction, dbheight uint32, blockSeconds int) error {
+	lock := trans.GetLockHeight()
+	if lock <= dbheight {
+		return nil
+	}
+	if int64(lock-dbheight)*int64(blockSeconds) > MaxLockMinutes*60 {
+		return fmt.Errorf("Transaction is locked until height %d, more than %d minutes after height %d", lock, MaxLockMinutes, dbheight)
+	}
+	return nil
+}
+
+// CheckLockHeights returns an error if the transaction can't go in a block at
+// this directory block height, as it is locked until a later one or has expired.
+func CheckLockHeights(trans interfaces.ITransaction, dbheight uint32) error {
+	if dbheight < trans.GetLockHeight() {
+		return fmt.Errorf("Transaction is locked until height %d", trans.GetLockHeight())
+	}
+	if IsExpired(trans, dbheight) {
+		return fmt.Errorf("Transaction expired at height %d", trans.GetExpiryHeight())
+	}
+	return nil
+}
+
+// IsExpired is true if the transaction can never go in a block at this height or later
+func IsExpired(trans interfaces.ITransaction, dbheight uint32) bool {
+	return trans.GetExpiryHeight() != 0 && trans.GetExpiryHeight() < dbheight
 }
 
 func (t *Transaction) GetTxID() interfaces.IHash {
@@ -332,6 +406,10 @@ func (t Transaction) Validate(index int) error {
 			return fmt.Errorf("Coinbase transactions cannot have inputs.")
 		}
 	}
+	// A transaction that expires before it unlocks can never be valid
+	if t.ExpiryHeight != 0 && t.ExpiryHeight < t.LockHeight {
+		return fmt.Errorf("The transaction expires at height %d before it unlocks at %d", t.ExpiryHeight, t.LockHeight)
+	}
 	// Every input must have an RCD block
 	if len(t.Inputs) != len(t.RCDs) {
 		return fmt.Errorf("All inputs must have a cooresponding RCD")
@@ -362,15 +440,9 @@ func (t Transaction) Validate(index int) error {
 //
 func (t Transaction) ValidateSignatures() error {
 	if !t.sigValid {
-		missingCnt := 0
-		sigBlks := t.GetSignatureBlocks()
-		for i, rcd := range t.RCDs {
-			if !rcd.CheckSig(&t, sigBlks[i]) {
-				missingCnt++
-			}
-		}
-		if missingCnt != 0 {
-			return fmt.Errorf("Missing %d of %d signatures", missingCnt, len(t.RCDs))
+		// The signatures of all the inputs are verified together
+		if err := ValidateSignaturesBatch([]interfaces.ITransaction{&t})[0]; err != nil {
+			return err
 		}
 		t.sigValid = true
 	}
@@ -391,8 +463,12 @@ func (t *Transaction) GetSignatureBlocks() []interfaces.ISignatureBlock {
 		return t.SigBlocks
 	}
 	for i := len(t.SigBlocks); i < len(t.Inputs); i++ { // If too short, then
-		t.SigBlocks = append(t.SigBlocks, new(SignatureBlock)) // pad it with
-	} // signature blocks.
+		var rcd interfaces.IRCD // pad it with the signature blocks the RCDs check.
+		if i < len(t.RCDs) {
+			rcd = t.RCDs[i]
+		}
+		t.SigBlocks = append(t.SigBlocks, NewSignatureBlock(rcd))
+	}
 	return t.SigBlocks
 }
 
@@ -433,8 +509,8 @@ func (t *Transaction) UnmarshalBinaryData(data []byte) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	if v != t.GetVersion() {
-		return nil, fmt.Errorf("Wrong Transaction Version encountered. Expected %v and found %v", t.GetVersion(), v)
+	if v != TransactionVersion && v != TransactionVersionHeights {
+		return nil, fmt.Errorf("Wrong Transaction Version encountered. Expected %v or %v and found %v", TransactionVersion, TransactionVersionHeights, v)
 	}
 
 	hd, err := buf.PopUInt32()
@@ -447,6 +523,22 @@ func (t *Transaction) UnmarshalBinaryData(data []byte) ([]byte, error) {
 	}
 	t.MilliTimestamp = (uint64(hd) << 16) + uint64(ld)
 
+	t.LockHeight, t.ExpiryHeight = 0, 0
+	if v == TransactionVersionHeights {
+		t.LockHeight, err = buf.PopUInt32()
+		if err != nil {
+			return nil, err
+		}
+		t.ExpiryHeight, err = buf.PopUInt32()
+		if err != nil {
+			return nil, err
+		}
+		// Otherwise the transaction would marshal differently than it was signed
+		if t.GetVersion() != v {
+			return nil, fmt.Errorf("Version %v transaction has no lock or expiry height", v)
+		}
+	}
+
 	numInputs, err := buf.PopUInt8()
 	if err != nil {
 		return nil, err
@@ -502,7 +594,7 @@ func (t *Transaction) UnmarshalBinaryData(data []byte) ([]byte, error) {
 		if err != nil {
 			return nil, err
 		}
-		t.SigBlocks[i] = new(SignatureBlock)
+		t.SigBlocks[i] = NewSignatureBlock(t.RCDs[i])
 		err = buf.PopBinaryMarshallable(t.SigBlocks[i])
 		if err != nil {
 			return nil, err
@@ -540,6 +632,17 @@ func (t *Transaction) MarshalBinarySig() ([]byte, error) {
 		return nil, err
 	}
 
+	if t.GetVersion() == TransactionVersionHeights {
+		err = buf.PushUInt32(t.LockHeight)
+		if err != nil {
+			return nil, err
+		}
+		err = buf.PushUInt32(t.ExpiryHeight)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = buf.PushByte(byte(len(t.Inputs)))
 	if err != nil {
 		return nil, err
@@ -597,7 +700,7 @@ func (t Transaction) MarshalBinary() ([]byte, error) {
 		// we don't want to restrict what might be required to
 		// sign an input.
 		if len(t.SigBlocks) <= i {
-			t.SigBlocks = append(t.SigBlocks, new(SignatureBlock))
+			t.SigBlocks = append(t.SigBlocks, NewSignatureBlock(rcd))
 		}
 		err = buf.PushBinaryMarshallable(t.SigBlocks[i])
 		if err != nil {
@@ -660,6 +763,12 @@ func (t *Transaction) CustomMarshalText() (text []byte, err error) {
 	primitives.WriteNumber64(&out, uint64(t.MilliTimestamp))
 	ts := time.Unix(0, int64(t.MilliTimestamp*1000000))
 	out.WriteString(ts.UTC().Format(" Jan 2, 2006 at 15:04:05 (MST)"))
+	if t.GetVersion() == TransactionVersionHeights {
+		out.WriteString("\n             Lock Height: ")
+		primitives.WriteNumber32(&out, t.LockHeight)
+		out.WriteString("\n           Expiry Height: ")
+		primitives.WriteNumber32(&out, t.ExpiryHeight)
+	}
 	out.WriteString("\n                # Inputs: ")
 	primitives.WriteNumber16(&out, uint16(len(t.Inputs)))
 	out.WriteString("\n               # Outputs: ")
@@ -212,6 +212,78 @@ func TestTransaction_MarshalUnMarshal(t *testing.T) {
 	}
 }
 
+func TestTransaction_LockHeights(t *testing.T) {
+	tx := getDeterministicTransaction().(*Transaction)
+	data, err := tx.MarshalBinary()
+	if err != nil || tx.GetVersion() != TransactionVersion || data[0] != byte(TransactionVersion) {
+		t.Fatalf("Plain transaction has version %d: %v", tx.GetVersion(), err)
+	}
+
+	locked := new(Transaction)
+	if err := locked.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	locked.LockHeight = 10
+	locked.ExpiryHeight = 20
+	data, err = locked.MarshalBinary()
+	if err != nil || data[0] != byte(TransactionVersionHeights) {
+		t.Fatalf("Locked transaction has version %d: %v", data[0], err)
+	}
+	if locked.GetSigHash().IsSameAs(tx.GetSigHash()) {
+		t.Error("The heights are not signed")
+	}
+
+	xb := new(Transaction)
+	if err := xb.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !xb.IsSameAs(locked) || xb.IsSameAs(tx) || xb.LockHeight != 10 || xb.ExpiryHeight != 20 {
+		t.Errorf("Unmarshalled heights %d and %d", xb.LockHeight, xb.ExpiryHeight)
+	}
+
+	// A version 3 transaction must have a height
+	copy(data[7:15], make([]byte, 8))
+	if err := xb.UnmarshalBinary(data); err == nil {
+		t.Error("Unmarshalled a version 3 transaction without heights")
+	}
+
+	for _, c := range []struct {
+		height uint32
+		valid  bool
+	}{{9, false}, {10, true}, {20, true}, {21, false}} {
+		if err := CheckLockHeights(locked, c.height); (err == nil) != c.valid {
+			t.Errorf("CheckLockHeights() at %d got %v", c.height, err)
+		}
+	}
+	if IsExpired(locked, 20) || !IsExpired(locked, 21) || IsExpired(tx, 1<<31) {
+		t.Error("IsExpired() is wrong")
+	}
+
+	// Each network takes them from its own height
+	if err := CheckVersionActivation(locked, constants.LOCAL_NETWORK_ID, 0); err != nil {
+		t.Error(err)
+	}
+	if CheckVersionActivation(locked, constants.MAIN_NETWORK_ID, 1<<31) == nil {
+		t.Error("Version 3 accepted on the main network")
+	}
+	if err := CheckVersionActivation(tx, constants.MAIN_NETWORK_ID, 0); err != nil {
+		t.Error(err)
+	}
+
+	// With 10 minute blocks, a lock an hour away can still be mined, but not one further
+	if err := CheckLockReachable(locked, 4, 600); err != nil {
+		t.Error(err)
+	}
+	if CheckLockReachable(locked, 3, 600) == nil {
+		t.Error("Accepted a lock more than an hour away")
+	}
+
+	locked.ExpiryHeight = 5
+	if err := locked.Validate(1); err == nil {
+		t.Error("Validated a transaction that expires before it unlocks")
+	}
+}
+
 func TestValidateAmounts(t *testing.T) {
 	var zero uint64
 	_, err := ValidateAmounts(zero - 1)
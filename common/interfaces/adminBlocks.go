@@ -30,6 +30,7 @@ type IAdminBlock interface {
 	AddABEntry(e IABEntry) error
 	AddAuditServer(IHash) error
 	AddDBSig(serverIdentity IHash, sig IFullSignature) error
+	AddFactoidAddress(IHash, IHash) error
 	AddFedServer(IHash) error
 	AddFederatedServerBitcoinAnchorKey(IHash, byte, byte, [20]byte) error
 	AddFederatedServerSigningKey(IHash, [32]byte) error
@@ -22,9 +22,9 @@ type IFBlock interface {
 
 	// Get the ChainID. This is a constant for all Factoids.
 	GetChainID() IHash
-	// Validation functions
-	Validate() error
-	ValidateTransaction(int, ITransaction) error
+	// Validation functions, for the network with this ID
+	Validate(networkID uint32) error
+	ValidateTransaction(networkID uint32, index int, trans ITransaction) error
 	// Marshal just the header for the block. This is to include the header
 	// in the FullHash
 	MarshalHeader() ([]byte, error)
@@ -32,9 +32,9 @@ type IFBlock interface {
 	MarshalTrans() ([]byte, error)
 	// Add a coinbase transaction.  This transaction has no inputs
 	AddCoinbase(ITransaction) error
-	// Add a proper transaction.  Transactions are validated before
-	// being added to the block.
-	AddTransaction(ITransaction) error
+	// Add a proper transaction.  Transactions are validated for the network
+	// with this ID before being added to the block.
+	AddTransaction(networkID uint32, trans ITransaction) error
 	// Calculate all the MR and serial hashes for this block.  Done just
 	// prior to being persisted.
 	CalculateHashes()
@@ -0,0 +1,16 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package interfaces
+
+import (
+	"time"
+)
+
+// The state and its timers read the time from a clock, so tests and replays can
+// run on simulated time instead of the wall clock.
+type IClock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
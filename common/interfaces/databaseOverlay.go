@@ -47,6 +47,12 @@ type DBOverlaySimple interface {
 	StartMultiBatch()
 	Trim()
 	FetchAllEntriesByChainID(chainID IHash) ([]IEBEntry, error)
+	SaveFEREpoch(epoch IFEREpoch, height uint32) error
+	FetchAllFEREpochs() ([]IFEREpoch, uint32, error)
+	SaveBalanceHistory(height uint32, changes []byte, checkpoint []byte) error
+	FetchBalanceHistoryHeight() ([]uint32, uint32, error)
+	FetchBalanceChanges(height uint32) ([]byte, error)
+	FetchBalanceCheckpoint(height uint32) ([]byte, error)
 }
 
 // Db defines a generic interface that is used to request and insert data into db
@@ -252,6 +258,13 @@ type DBOverlay interface {
 
 	FetchPaidFor(hash IHash) (IHash, error)
 
+	SaveFEREpoch(epoch IFEREpoch, height uint32) error
+	FetchAllFEREpochs() ([]IFEREpoch, uint32, error)
+	SaveBalanceHistory(height uint32, changes []byte, checkpoint []byte) error
+	FetchBalanceHistoryHeight() ([]uint32, uint32, error)
+	FetchBalanceChanges(height uint32) ([]byte, error)
+	FetchBalanceCheckpoint(height uint32) ([]byte, error)
+
 	FetchFactoidTransaction(hash IHash) (ITransaction, error)
 	FetchECTransaction(hash IHash) (IECBlockEntry, error)
 }
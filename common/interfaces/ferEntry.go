@@ -14,3 +14,11 @@ type IFEREntry interface {
 	GetTargetPrice() uint64
 	SetTargetPrice(passedTargetPrice uint64) IFEREntry
 }
+
+type IFEREpoch interface {
+	BinaryMarshallableAndCopyable
+	GetActivationHeight() uint32
+	GetFactoshisPerEC() uint64
+	GetEntryHash() IHash
+	GetPriority() uint32
+}
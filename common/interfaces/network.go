@@ -14,3 +14,13 @@ type INetwork interface {
 	GetMLog() IMLog
 	SetMLog(IMLog)
 }
+
+// INetworkController lets the debug API inspect and steer the p2p layer at runtime.
+// Peers are given by hash or address, see p2p/status.go.
+type INetworkController interface {
+	GetNetworkStatus() (interface{}, error)
+	DialPeerAddress(address string, persistent bool) error
+	DisconnectPeer(peer string) error
+	BanPeer(peer string) error
+	UnbanPeer(address string) error
+}
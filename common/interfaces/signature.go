@@ -23,6 +23,13 @@ type Signer interface {
 	Sign(msg []byte) IFullSignature
 }
 
+// KeySigner is a Signer that knows its public key.  A node's identity key is
+// one, whether it is held by factomd or by a signer outside of it.
+type KeySigner interface {
+	Signer
+	Public() []byte
+}
+
 type ISignature interface {
 	BinaryMarshallable
 
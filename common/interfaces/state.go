@@ -59,6 +59,7 @@ type IState interface {
 	AddStatus(status string)
 
 	AddDBSig(dbheight uint32, chainID IHash, sig IFullSignature)
+	AddCoinbaseAddress(dbheight uint32, chainID IHash, address IHash)
 	AddPrefix(string)
 	AddFedServer(uint32, IHash) int
 	GetFedServers(uint32) []IServer
@@ -141,6 +142,7 @@ type IState interface {
 	GetNetworkNumber() int  // Encoded into Directory Blocks
 	GetNetworkName() string // Some networks have defined names
 	GetNetworkID() uint32
+	GetNetworkController() INetworkController // nil when there is no p2p network (eg: simulations)
 
 	// Bootstrap Identity Information is dependent on Network
 	GetNetworkBootStrapKey() IHash
@@ -236,6 +238,7 @@ type IState interface {
 
 	GetTimestamp() Timestamp
 	GetTimeOffset() Timestamp
+	GetClock() IClock // Where the state and its timers get the time
 
 	GetTrueLeaderHeight() uint32
 	Print(a ...interface{}) (n int, err error)
@@ -280,18 +283,37 @@ type IState interface {
 	ExchangeRateAuthorityIsValid(IEBEntry) bool
 	FerEntryIsValid(passedFEREntry IFEREntry) bool
 	GetPredictiveFER() uint64
+	// The Factoshis per EC epochs in effect for some height from from to to
+	GetFERHistory(from, to uint32) (interface{}, error)
+	GetFERHistoryHeight() (uint32, error)
+	// The balances of addresses after the block at height
+	GetFactoidBalanceAt(adr [32]byte, height uint32) (int64, error)
+	GetECBalanceAt(adr [32]byte, height uint32) (int64, error)
+	// Totals of the balances, and the largest factoid balances
+	GetSupply() interface{}
+	GetRichList(count int) interface{}
+
+	// Checkpoints
+	GetCheckPoint(dbheight uint32) string // Trusted directory block KeyMR at this height, if any
+	IsCheckPointed(dbheight uint32) bool  // True if fast syncing and a checkpoint vouches for this height
 
 	// Identity Section
 	VerifyIsAuthority(cid IHash) bool // True if is authority
 	UpdateAuthorityFromABEntry(entry IABEntry) error
 	VerifyAuthoritySignature(Message []byte, signature *[64]byte, dbheight uint32) (int, error)
 	FastVerifyAuthoritySignature(Message []byte, signature IFullSignature, dbheight uint32) (int, error)
+	FastVerifyAuthoritySignatures(Message []byte, signatures []IFullSignature, dbheight uint32) []int
 	UpdateAuthSigningKeys(height uint32)
 
 	AddAuthorityDelta(changeString string)
+	GetFaultAuditTrail(serverID string, dbheight uint32) interface{} // Fault negotiations about serverID ("" for all) from dbheight on
 
 	GetAuthorities() []IAuthority
+	GetAuthoritiesAtHeight(dbheight uint32) (interface{}, error) // Federated and audit servers with their signing keys
 	GetLeaderPL() IProcessList
+	GetProcessListStatus() (interface{}, error) // Structured view of the current and next process lists
+	// Messages waiting to get into a block, about chainID, address and of msgType ("" for any)
+	GetMempool(chainID string, address string, msgType string, limit int) interface{}
 	GetLLeaderHeight() uint32
 	GetEntryDBHeightComplete() uint32
 	GetMissingEntryCount() uint32
@@ -55,6 +55,10 @@ type ITransaction interface {
 	// the network.
 	GetTimestamp() Timestamp
 	SetTimestamp(Timestamp)
+	// The transaction is only valid in blocks from the lock height to the
+	// expiry height.  Zero means no limit.
+	GetLockHeight() uint32
+	GetExpiryHeight() uint32
 	// Get a signature
 	GetSignatureBlock(i int) ISignatureBlock
 	SetSignatureBlock(i int, signatureblk ISignatureBlock)
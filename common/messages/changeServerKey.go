@@ -256,6 +256,8 @@ func (m *ChangeServerKeyMsg) String() string {
 		mtype = "Signing Key"
 	} else if m.AdminBlockChange == constants.TYPE_ADD_BTC_ANCHOR_KEY {
 		mtype = "BTC Key"
+	} else if m.AdminBlockChange == constants.TYPE_ADD_FACTOID_ADDRESS {
+		mtype = "Factoid Address"
 	} else {
 		mtype = "other"
 	}
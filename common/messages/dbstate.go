@@ -175,15 +175,13 @@ func (m *DBStateMsg) Validate(state interfaces.IState) int {
 		return -1
 	}
 
-	if m.DirectoryBlock.GetHeader().GetNetworkID() == constants.MAIN_NETWORK_ID {
-		key := constants.CheckPoints[dbheight]
-		if key != "" {
-			if key != m.DirectoryBlock.DatabasePrimaryIndex().String() {
-				state.AddStatus(fmt.Sprintf("DBStateMsg.Validate() Fail  ht: %d checkpoint failure. Had %s Expected %s",
-					dbheight, m.DirectoryBlock.DatabasePrimaryIndex().String(), key))
-				//Key does not match checkpoint
-				return -1
-			}
+	key := state.GetCheckPoint(dbheight)
+	if key != "" {
+		if key != m.DirectoryBlock.DatabasePrimaryIndex().String() {
+			state.AddStatus(fmt.Sprintf("DBStateMsg.Validate() Fail  ht: %d checkpoint failure. Had %s Expected %s",
+				dbheight, m.DirectoryBlock.DatabasePrimaryIndex().String(), key))
+			//Key does not match checkpoint
+			return -1
 		}
 	}
 
@@ -339,11 +337,23 @@ func (m *DBStateMsg) SigTally(state interfaces.IState) int {
 
 	// If there is a repeat signature, we do not count it twice
 	sigmap := make(map[string]bool)
+	var sigs []interfaces.IFullSignature
 	for _, sig := range m.SignatureList.List {
 		if sigmap[fmt.Sprintf("%x", sig.GetSignature()[:])] {
 			continue // Toss duplicate signatures
 		}
 		sigmap[fmt.Sprintf("%x", sig.GetSignature()[:])] = true
+		sigs = append(sigs, sig)
+	}
+
+	// Verify the signatures of the current authorities all at once
+	checks := state.FastVerifyAuthoritySignatures(data, sigs, dbheight)
+	for i, sig := range sigs {
+		if checks[i] >= 0 {
+			validSigCount++
+			continue
+		}
+
 		//Check signature against the Skeleton key
 		authoritativeKey := state.GetNetworkBootStrapKey()
 		if authoritativeKey != nil {
@@ -355,12 +365,6 @@ func (m *DBStateMsg) SigTally(state interfaces.IState) int {
 			}
 		}
 
-		check, err := state.VerifyAuthoritySignature(data, sig.GetSignature(), dbheight)
-		if err == nil && check >= 0 {
-			validSigCount++
-			continue
-		}
-
 		if sig.Verify(data) {
 			remainingSig = append(remainingSig, sig)
 		}
@@ -459,8 +463,13 @@ func (m *DBStateMsg) SigTally(state interfaces.IState) int {
 }
 
 func (m *DBStateMsg) checkpointFix() int {
+	return CheckpointSignatures(m.DirectoryBlock.GetDatabaseHeight(), m.SignatureList)
+}
+
+// CheckpointSignatures counts the signatures in siglist that are known to be good for the directory
+// block at dbheight, even though they do not validate against the authorities of the time.
+func CheckpointSignatures(dbheight uint32, siglist SigList) int {
 	returnAmt := 0
-	dbheight := m.DirectoryBlock.GetDatabaseHeight()
 
 	allow := func(str string, siglist SigList) int {
 		amt := 0
@@ -476,13 +485,13 @@ func (m *DBStateMsg) checkpointFix() int {
 
 	switch dbheight {
 	case 75893:
-		returnAmt += allow("8066fc4222eff67470ffaca15bdb5d6d15b65daf3cc86c121b872d7485b388b3cb4b7bbbd0248076065262d54699bab68e7d5be96e137aa3428b903916e4180a", m.SignatureList)
+		returnAmt += allow("8066fc4222eff67470ffaca15bdb5d6d15b65daf3cc86c121b872d7485b388b3cb4b7bbbd0248076065262d54699bab68e7d5be96e137aa3428b903916e4180a", siglist)
 	case 76720:
-		returnAmt += allow("ab429576ee93485cfffe0c778d429073f24ce76d3014f2ddecd6e90e87a5e912b849842597cae23a66beee203ee455bd44fe4073747ce6c099a21f4525c3d901", m.SignatureList)
+		returnAmt += allow("ab429576ee93485cfffe0c778d429073f24ce76d3014f2ddecd6e90e87a5e912b849842597cae23a66beee203ee455bd44fe4073747ce6c099a21f4525c3d901", siglist)
 	case 76792:
-		returnAmt += allow("9f86122d624400b3036e60105f3db4e99199ae9217cbeb1462811426319983dc0e4f5e5cd16996cc3cf2940ead765ce00fc699e23b459395569c10e1df4c650b", m.SignatureList)
+		returnAmt += allow("9f86122d624400b3036e60105f3db4e99199ae9217cbeb1462811426319983dc0e4f5e5cd16996cc3cf2940ead765ce00fc699e23b459395569c10e1df4c650b", siglist)
 	case 87624:
-		returnAmt += allow("cb67b7f8ed2b2845b9941264f3631a639685e2b7a47b8c353461ee9b197c2307aaab27419b72f5478f0e2a0d610ef4f16cbfaa5e9889c114415a63b8cb54f000", m.SignatureList)
+		returnAmt += allow("cb67b7f8ed2b2845b9941264f3631a639685e2b7a47b8c353461ee9b197c2307aaab27419b72f5478f0e2a0d610ef4f16cbfaa5e9889c114415a63b8cb54f000", siglist)
 	default:
 		return 0
 	}
@@ -98,6 +98,27 @@ func (m *FactoidTransaction) Validate(state interfaces.IState) int {
 		return -1 // No, object!
 	}
 
+	// Can its RCDs be used yet?
+	err = factoid.CheckRCDActivation(m.Transaction, state.GetNetworkID(), state.GetLLeaderHeight())
+	if err != nil {
+		return -1
+	}
+
+	// Can its format be used yet?
+	err = factoid.CheckVersionActivation(m.Transaction, state.GetNetworkID(), state.GetLLeaderHeight())
+	if err != nil {
+		return -1
+	}
+
+	// Has it expired?  If it is locked, it waits in holding until it unlocks, if that is soon enough.
+	if factoid.IsExpired(m.Transaction, state.GetLLeaderHeight()) {
+		return -1
+	}
+	err = factoid.CheckLockReachable(m.Transaction, state.GetLLeaderHeight(), state.GetDirectoryBlockInSeconds())
+	if err != nil {
+		return -1
+	}
+
 	// Is the transaction properly signed?
 	err = m.Transaction.ValidateSignatures()
 	if err != nil {
@@ -0,0 +1,33 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package messages
+
+import (
+	"github.com/FactomProject/factomd/common/entryCreditBlock"
+	"github.com/FactomProject/factomd/common/interfaces"
+)
+
+// ValidateCommits checks the chain and entry commits among msgs together (see
+// entryCreditBlock.ValidateCommits).  The messages whose commits are valid don't check them
+// again in Validate(); the others check them as usual.
+func ValidateCommits(msgs []interfaces.IMsg) {
+	var commits []interfaces.IECBlockEntry
+	var valid []func()
+	for _, msg := range msgs {
+		switch m := msg.(type) {
+		case *CommitChainMsg:
+			commits = append(commits, m.CommitChain)
+			valid = append(valid, func() { m.validsig = true })
+		case *CommitEntryMsg:
+			commits = append(commits, m.CommitEntry)
+			valid = append(valid, func() { m.validsig = true })
+		}
+	}
+	for i, ok := range entryCreditBlock.ValidateCommits(commits) {
+		if ok {
+			valid[i]()
+		}
+	}
+}
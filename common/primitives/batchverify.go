@@ -0,0 +1,122 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package primitives
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/FactomProject/ed25519"
+)
+
+// Batches smaller than this are verified in the caller's goroutine, as handing
+// them to the workers costs more than it saves.
+const MinParallelBatch = 8
+
+// BatchVerifyWorkers is the number of workers verifying batches.  It must be set
+// before the first batch is verified.
+var BatchVerifyWorkers = runtime.NumCPU()
+
+// The pool of workers shared by all batches
+var verifyPool struct {
+	once sync.Once
+	jobs chan *verifyJob
+}
+
+func startVerifyPool() {
+	workers := BatchVerifyWorkers
+	if workers < 1 {
+		workers = 1
+	}
+	verifyPool.jobs = make(chan *verifyJob, workers*4)
+	for i := 0; i < workers; i++ {
+		go func() {
+			for job := range verifyPool.jobs {
+				job.verify()
+				job.done.Done()
+			}
+		}()
+	}
+}
+
+type verifyJob struct {
+	publicKey  [32]byte
+	signature  [64]byte
+	data       []byte
+	wellFormed bool // The key and signature had the right lengths
+
+	valid bool
+	done  *sync.WaitGroup
+}
+
+func (job *verifyJob) verify() {
+	job.valid = job.wellFormed && ed25519.VerifyCanonical(&job.publicKey, job.data, &job.signature)
+}
+
+// BatchVerifier collects ed25519 signatures, then verifies them all at once on a
+// pool of workers.  It is used where many signatures must be checked together,
+// such as the transactions of a factoid block or the signatures of a DBState.
+// Each signature is still verified on its own, with the result for each; this is
+// not ed25519 batch verification, which checks a batch in one combined equation.
+type BatchVerifier struct {
+	jobs []*verifyJob
+}
+
+func NewBatchVerifier() *BatchVerifier {
+	return new(BatchVerifier)
+}
+
+// Add adds a signature of data by publicKey to the batch, and returns its index
+// in the results of Verify.  Keys and signatures of the wrong length never verify.
+func (b *BatchVerifier) Add(publicKey, data, signature []byte) int {
+	job := new(verifyJob)
+	job.data = data
+	if len(publicKey) == 32 && len(signature) == 64 {
+		copy(job.publicKey[:], publicKey)
+		copy(job.signature[:], signature)
+		job.wellFormed = true
+	}
+	b.jobs = append(b.jobs, job)
+	return len(b.jobs) - 1
+}
+
+// Len returns the number of signatures in the batch
+func (b *BatchVerifier) Len() int {
+	return len(b.jobs)
+}
+
+// Verify checks every signature in the batch, and returns whether each is valid
+func (b *BatchVerifier) Verify() []bool {
+	if len(b.jobs) < MinParallelBatch {
+		for _, job := range b.jobs {
+			job.verify()
+		}
+	} else {
+		verifyPool.once.Do(startVerifyPool)
+		var done sync.WaitGroup
+		done.Add(len(b.jobs))
+		for _, job := range b.jobs {
+			job.done = &done
+			verifyPool.jobs <- job
+		}
+		done.Wait()
+	}
+
+	valid := make([]bool, len(b.jobs))
+	for i, job := range b.jobs {
+		valid[i] = job.valid
+	}
+	return valid
+}
+
+// VerifyAll is true if every signature in the batch is valid
+func (b *BatchVerifier) VerifyAll() bool {
+	for _, valid := range b.Verify() {
+		if !valid {
+			return false
+		}
+	}
+	return true
+}
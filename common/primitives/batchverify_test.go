@@ -0,0 +1,54 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package primitives_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/FactomProject/factomd/common/primitives"
+)
+
+func TestBatchVerifier(t *testing.T) {
+	for _, size := range []int{0, 3, MinParallelBatch, 100} {
+		batch := NewBatchVerifier()
+		want := make([]bool, size)
+		for i := 0; i < size; i++ {
+			pk := new(PrivateKey)
+			if err := pk.GenerateKey(); err != nil {
+				t.Fatal(err)
+			}
+			msg := []byte(fmt.Sprintf("message %d", i))
+			sig := pk.Sign(msg)
+			switch i % 5 {
+			case 1:
+				msg = []byte("another message") // Signature of something else
+			case 3:
+				if batch.Add(pk.Public()[1:], msg, sig.Bytes()) != i { // Bad key length
+					t.Fatalf("Add() returned the wrong index")
+				}
+				continue
+			default:
+				want[i] = true
+			}
+			if batch.Add(pk.Public(), msg, sig.Bytes()) != i {
+				t.Fatalf("Add() returned the wrong index")
+			}
+		}
+
+		if batch.Len() != size {
+			t.Errorf("Len() got %d, wanted %d", batch.Len(), size)
+		}
+		got := batch.Verify()
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("Batch of %d, signature %d: got %v, wanted %v", size, i, got[i], want[i])
+			}
+		}
+		if batch.VerifyAll() != (size < 2) {
+			t.Errorf("Batch of %d: VerifyAll() got %v", size, batch.VerifyAll())
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package primitives
+
+import (
+	"sync"
+	"time"
+
+	"github.com/FactomProject/factomd/common/interfaces"
+)
+
+// SystemClock is the wall clock
+type SystemClock struct{}
+
+var _ interfaces.IClock = SystemClock{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (SystemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// SimClock is a simulated clock.  Its time only moves when told to, and Sleep() returns once
+// the clock has moved far enough, so tests can go through minutes of timeouts instantly.
+type SimClock struct {
+	mutex    sync.Mutex
+	now      time.Time
+	sleepers []*simSleeper
+}
+
+var _ interfaces.IClock = (*SimClock)(nil)
+
+type simSleeper struct {
+	until time.Time
+	wake  chan struct{}
+}
+
+func NewSimClock(start time.Time) *SimClock {
+	c := new(SimClock)
+	c.now = start
+	return c
+}
+
+func (c *SimClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+// Sleep blocks until the clock is moved d past the time it was called
+func (c *SimClock) Sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	c.mutex.Lock()
+	s := &simSleeper{until: c.now.Add(d), wake: make(chan struct{})}
+	c.sleepers = append(c.sleepers, s)
+	c.mutex.Unlock()
+	<-s.wake
+}
+
+// Advance moves the clock forward by d
+func (c *SimClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.set(c.now.Add(d))
+}
+
+// Set moves the clock to t.  The clock never goes back.
+func (c *SimClock) Set(t time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.set(t)
+}
+
+func (c *SimClock) set(t time.Time) {
+	if t.After(c.now) {
+		c.now = t
+	}
+	sleeping := c.sleepers[:0]
+	for _, s := range c.sleepers {
+		if c.now.Before(s.until) {
+			sleeping = append(sleeping, s)
+		} else {
+			close(s.wake)
+		}
+	}
+	c.sleepers = sleeping
+}
+
+// Sleepers returns the number of goroutines in Sleep(), so a test can wait for them to block
+func (c *SimClock) Sleepers() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.sleepers)
+}
@@ -0,0 +1,60 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package primitives_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/FactomProject/factomd/common/primitives"
+)
+
+func TestSimClock(t *testing.T) {
+	start := time.Unix(1500000000, 0)
+	c := NewSimClock(start)
+	if !c.Now().Equal(start) {
+		t.Errorf("Now() got %v, expected %v", c.Now(), start)
+	}
+
+	done := make(chan time.Time)
+	go func() {
+		c.Sleep(time.Minute)
+		done <- c.Now()
+	}()
+	for c.Sleepers() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	c.Advance(59 * time.Second)
+	select {
+	case <-done:
+		t.Fatalf("Sleep() returned before its minute was up")
+	case <-time.After(10 * time.Millisecond):
+	}
+	c.Advance(10 * time.Minute)
+	select {
+	case now := <-done:
+		if !now.Equal(start.Add(10*time.Minute + 59*time.Second)) {
+			t.Errorf("Sleep() woke up at %v", now)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Sleep() did not return once the clock moved past its minute")
+	}
+	if c.Sleepers() != 0 {
+		t.Errorf("Sleepers() got %d", c.Sleepers())
+	}
+
+	// The clock does not go back
+	now := c.Now()
+	c.Set(start)
+	if !c.Now().Equal(now) {
+		t.Errorf("Set() moved the clock back to %v", c.Now())
+	}
+	c.Sleep(0)
+
+	if ts := NewTimestampFromTime(start.Add(1500 * time.Millisecond)); ts.GetTimeMilli() != 1500000001500 {
+		t.Errorf("NewTimestampFromTime() got %d", ts.GetTimeMilli())
+	}
+}
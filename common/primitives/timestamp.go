@@ -54,6 +54,10 @@ func NewTimestampFromMilliseconds(s uint64) *Timestamp {
 	return t
 }
 
+func NewTimestampFromTime(t time.Time) *Timestamp {
+	return NewTimestampFromMilliseconds(uint64(t.UnixNano() / 1000000))
+}
+
 func (t *Timestamp) SetTimestamp(b interfaces.Timestamp) {
 	if b == nil {
 		t.SetTimeMilli(0)
@@ -99,6 +99,7 @@ function updataDataDumps() {
     $("#dump4 #dumpAuth").text(obj.DataDump4.Authorities)
     $("#dump4 #dumpIdent").text(obj.DataDump4.Identities)
     $("#dump4 #dumpMyNode").text(obj.DataDump4.MyNode)
+    $("#dump4 #dumpFaults").text(obj.DataDump4.FaultAudit)
 
     $("#dump5 #dumpConRaw").text(obj.DataDump5.RawDump)
     $("#dump5 #dumpSort").text(obj.DataDump5.SortedDump)
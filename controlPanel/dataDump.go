@@ -23,6 +23,7 @@ type DataDump struct {
 		Authorities string
 		Identities  string
 		MyNode      string
+		FaultAudit  string
 	}
 	DataDump5 struct {
 		RawDump    string
@@ -47,6 +48,7 @@ func GetDataDumps() []byte {
 	holder.DataDump4.Authorities = dd.Authorities(*DsCopy)
 	holder.DataDump4.Identities = dd.Identities(*DsCopy)
 	holder.DataDump4.MyNode = dd.MyNodeInfo(*DsCopy)
+	holder.DataDump4.FaultAudit = dd.FaultAudit(*DsCopy)
 
 	holder.DataDump5.RawDump = AllConnectionsString()
 	holder.DataDump5.SortedDump = SortedConnectionString()
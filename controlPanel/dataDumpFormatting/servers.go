@@ -68,6 +68,15 @@ func MyNodeInfo(copyDS state.DisplayState) string {
 	return prt
 }
 
+func FaultAudit(copyDS state.DisplayState) string {
+	prt := ""
+	prt = prt + fmt.Sprintf("=== Fault Audit Trail ===   Total: %d Displaying: Newest first\n", len(copyDS.FaultAudit))
+	for i := len(copyDS.FaultAudit) - 1; i >= 0; i-- {
+		prt = prt + copyDS.FaultAudit[i].String() + "\n"
+	}
+	return prt
+}
+
 func returnStatString(i uint8) string {
 	var stat string
 	switch i {
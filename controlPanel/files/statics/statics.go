@@ -143,11 +143,11 @@ var staticFiles = map[string]*staticFilesFile{
 		size:  0,
 	},
 	"js/controlPanel.js": {
-		data:  "\x1f\x8b\b\x00\x00\tn\x88\x02\xff\xec\\[s\xdb8\xb2~\u05ef\xe8a\xb2+r,Qr2\x93:gm\xb9ʎ';\xde\xc9mb\x9f=\x0f9~\x80HHBB\x02\f\x00\xdaVM\xfc\xdfO\xe1B\x12\xa0H]ffS\xfb\xb0S5\xb1\x05t\u007f\xdd\xe8n4\x1a\x17\xf9\x0eqHJ\xce1\x95?c\xb2\\I\x98\xc1t\xa0Z3\x8cR̝Ɓ\xc0\xf2\x8aJ\xcc\xefP\x16\x96E\x8a$\xfe\xf9\xe6\xcd\xeb\xd1\xf3\xe9t\x1a\x9dh\x1e\x81\xf9\x1d\xe6\xefhF(\x86\x19,P&\xf0`2\x81\xff\x118\x05\xc9\xc0p\x81`9\x06\xb9\"t) \xc3B\xc0\x82\xe3/%\xa62[\x1b\x98Ϥ\xa8$\xd50\x83\xa7\xe1=\xa1)\xbb\x8f⌡4\x1c\x00\x00,J\x9aH\xc2h\x18\xc1o\xba\x01\xa0\xd1,\x8cl\x93\xc0\xf2\x86䘕2\xac\x18\xc0\xe1\xe8\xe5{\x1c\xc1\xb1\x19\x9c\xfe4\x88N\x06\x83\x1a\xc0\xa5\xd7POc\xf4\t=\x84\xc3x2\x1cYlQ&\t\x16\xe2o\x8e\x9e\xbf\xd5:y\xa6\x92\xbc\xc4F\xcaH\xff\xc0\x9c3\xbe\a\x9f\xb1\x8dQ\x0f\xe0Qi\b@\x16\x10~\xe7\x12Vc}\x1a\x06OL\xfbXH$K\x11D\xb1\xc4\x0f2\f^\xa1D\xb2<\x85\xb7L\u0087\x92RB\x97\x811\x03ǲ\xe4T\x81\x03\xce\x04\xde\x1b\xc9Ey\xac\xb4Rl\x84\xa6\xf8\x81\xa2\xbbq\x8e\b\r\xa2x\x85\xc4\xcb\f\t\x11\x06D\x8cQ\"\xc9\x1d\x0e\xa2J\xe3\xc9\x04\xde B\xe1\x06\xcd\a\x8e\x97tT\x86\x118m\xe7Y\xf6\x1ec.\xac\xf7&\x13\xb8dX\x00\xbe\xc3|\r\x882\xb9\xc2\x1c\x92u\x92\x19s\x91E\xf8\x9d\x1bg\x91\x1f?7\x1cQ\x81\xb4\xedE\x13G~\\6>s-\x03\xdd\xe1[\xbb\xc8ВE\xcb\x16\x8c\xe3=lq\x89%\"\x19N}{\xa0K\xf5\u007f\x99\x17F\xd5\xc7\xc1\xe0q\xa0\xa7\x9d\x99n\xf7+L\xe1\x1e\x83\xb8'2Y\x81Ds1x\x1a\x06\xb1\xfae\x9c0*9\xcb\xc6\x05\xa28\x83\x8c\x00\n\xa28\xc9H\xf29\xf4\x83ϙD\x03o\xe2\r\xc0\xeaךA\x8f#x>\x9dF\x83\xc7H\xcd\xdd\xe0IZ\xe6\x85\x16\x87\b\xc5\x1c\x9e,\xca,\x13\tǘ\x8eY\xa1\xb0j\xc1\xad\xb0\x97\x0f\xf2\x9cc\x043\xf8\xf4k\x89\xf9:\x94+\"\xa2X\x90y\xa6RH\x18Ď\xb1\x1a\xfaX\xb2\xe52\xc3֞\x8d4M\xe3!y\x84h.XVJ<\xee\xd0o+\xe3\x82<ഓKY@\xf9\xe3\x86\x15\xda\xfa\xc0(h\xcf\x0f6\xe6\x03\x9cu:@[XM O|o\xb4t\xcdV\xe9Dt\x10\xc5\x1c\xe7\xec\xaeR}ERl\xa7\xbb\"\xcdX\x82\xb2\x1d4\xa9\r\xb9 \x8aQ\x9a\xb6i\x1ek\xaf{\x11\xfe\xcdFס\x92?\xb4^\x02g\\\xdd\xc37C\xf3\x97\x01w\x02j\xa58\x16\x05\xcc\xe0\x8b\x1aεD\x12\x87A\r<\x82 \x18ՃW\x946\xf7\xb0\xf9'\x98\xc1?\xae\u07fd\x8d\v\xc4\x056}\x8dfe^\x1c\x83\xfeq\xbdb\\V\x19\x97\xcd?ŕ\xfc\xe3Xw\xa9_;\x19?\xa0\xfbn\xb6\x0f\xe8\xde0y\\϶r=\xeb\xe1z\xbe\x95\xeby\x0f\xd7\x0f\x86뼔\xab.\xb6\x1fb\xd5\xc38\x91\x04\x8b\xa8\x8b\xf3*\xc5Tv\xb3\xea\xae~\xce7\xeb\xb7Ly\xb7\x8b\xd5\xf4\xb5t\xfd\xd1\xf0\xbdd\xb4g\x90?6\x83\xec\xe0\xbb\xee\xf1ޏ\xb1\xea\xc1i\xc5\xf8\x18\xa9Dު8\xfc\x95\xa9/\xda8N0\x95.m0:<\xec&\x13\xbbN]^\\d,\xf9l\xd6\xddJ\xf5\b\xbe\x9b\x81֟p\x9cH\xc6ך(\xbe\xbc0tM\x85e ~\xc1\xeb7\x1fl\nh\xc6\xee\xf3j\x9a\xc8c\xbb`\xe9Z7oa\xabi|\xd6We\x96\xfd\x8c\xc4j\vgEҒ\xa9\xfa\xd4r'$ҹ\xa0\x8f\xbd\xa6\xe9\xe0\xf7\xad\xb5\xcdP\x0e\xafq\xdc8\xad(\xc7sE\xba\x17\x88E!\vM\xa6\xeb0\x92\xba!\xa0\xfcEˬ\xa9u\xb4\xf3\xbb(\xe3\x05\xe3?\xa1d\xd5$i\x9d`\xfd\x9a\x99,Lk|\xc3$ʮhQJ8\x83i<\x9dN\x8f\xdbյ\x8d\xa3\x02Q+M\xc0\x19\xa8\xac\xfd\xf0\x9a\b\xc5&\xe7,]Ó\x00\x8e\xc0\x82>\\]Fq\x86\xe9R\xae\x14\xac\xcd\xfc\xcd\u007f\xad\xaa\xab\xfao\x0f1A\x14\x17\x1c\x17\x98\xa6a\xf0\u007f-\xf6SɁ\xa4\xb3\xa1\xaf\b\x1cA0<k\xd3\x1a\xfa\xf4\xec\x14i\x96\x85\xae|\xc7\x02#\x9e\xac\xc6\x19\xa1\x9f\x87 \xd7\x05\xb6=$E\xc9\xe7\xe1\xd9&\xf0\xe9\x04\x9d\x9dNdڋ\xef\xb04\x96\u058c\a2\x89C\xb9ޕr+\xdb\xe9D\xf2\xb3 j\xb5V\x95\xfe.o\x9f\x81\xe4\x81\xe3\xe3\xe3\xe94\xda\xf0\xe7^\x1e\x853\x83\x84\x84\f\xab\x85;\xb4\xfb67b\xfc\x00\x1at\xfd\xfeX\xef\xfe\xfc\xf9\xf4\x13\x95\x9c\xe0\xbe9d{7\xe7\r\xa6\x92\xaf\xfdQ\xe9JP\xa2l\xe0\x0f\xd1\xce|\xcd0\x96\x8a\xa0\xdeS\x85\xca-\xd6\f\x95\x1e\xbb\rz\x04A\xe4\xf9\xc6\xf1K\x85\xa2'\x9c\x16\x19\xeb,ؚp\x1dS88jȕ\bx\x92\xac\x10\xa1W\x97M^\xd7\v\x83\xa1zi\xfa\xa2\xcei\xba\a\x94\x8f\xd2\xeb\xbefl\xfb\xaa\x17\xbc\xe7L\xed\xcd\xf5\x16uO\xed\x8ck\xf4\xbf+ը\x10\x91\x94<\f\xd44W\x85\x9d\xee\v\xb6\xeb٣&N\x12&d\x87\t\u007fz\xf9\x92\t\xb9\xb7\x8e\x1e\x8c\x87\xd0\x1f\xfc]\x99tw\xb8\xf5\xa7Q7\x89\xfa\nv%\xd1S\x99j\xea\x96y\x87\xbb\U000ea9ad\xb3\xaa/iKV\xad\x04\xda\xc8\xd8C\x90\xa6\\a\x94\xba\x92lT\u009eҌg\\\x00㗞\xe4ڕZ;&\xf0\xefΫ\xfb\xe0\xecN\xaa;s\xe8\xa03\xe79\xf9ή\x8d[2\xde\x01kH\x9d\xf2L\xe9<\x99\xc0sP{J\x82\xb9\x00B\xe1\x02\xc9d\xb5q\x86W\x9d&9\xb5\xf4\\\x11\xfe\xea\x14\xd4\xf9ڐ\x8d\xdcs\xd1Q\xc2\xf2\"\xc3\x15\xc4Ȝ\x88%\xac\xa4r\x94\xac\x10\xa58{\xad\x15;\xb8\xf2\xaeā\xae\xb0?Noc\xf3Ywf^߱ק4\xf2\xba\x9fy\xdd\v\x9c\n\xdb\xf1\xfc6^\xe0T\xb7\xa2\xd2mEejO\xfeD\xf1\x8a\xdca\xdb\xf3í\xb5\xf2\xa0u\x02\xb8\xc0\xa9\x1er\x10\xc5w(\v\x95\x88\xa8E\x82J\x8fDɳ\xf5j\xfb\xf0Y\x1b\xe2\x8aʰ\xb2@\x03EY\x8a\xeb\x9aZ\xc14$\xc6,\xfe\x89u\x8d\x94\xb9@\xc6\xe5\xef9[r,\xc4\x05\xe2J\xc75M^\x11\xae\xa3*.l\xd78\xc7\x12\xf3`\xe4k8\xf2\xa4\x18\xc8\x02s\x15\xc9\xfa\x90ܦx_\x95\x99\xbb\x986\xd4\xc7\xd3i\xd7\xf1aC\x10z\xa2'\x9ed\xf8\xbe\xe6wY\xde \xb9\x8a\x17\x19c<\xb4\x8dѠ\x99\x9bO\xc3\xe1\xb6\xc1n\xb6\x8c\xd5l\x1c\xdaIYI9\x82\xe0/p\xbd\xa6\tNA\xcfS߇j\x19b\vP\x1d\x9e\x19\xecܴ\x1b\xcbN\x876\xf1\xefO,כM\x80ow\xe85N\x18M\xbb=\xea\xcf\xda~\x97Z\x8c?ױ5h\xe8\xeb\xb1ۿ5禗MW\x97\xaf\xfb찟\xb3-\xf7\xa6\xcb}\xff\xf4\xfa\xdcu9\\\x12Qd\xc8dTxi\xf2#\u061cbI\x12F\x05\xcbp\x9c\xb1e\x18(\x120\t\xf4o\xc1\xa8\xceG\xbdG#n\x10\x90\xb4\x9e\xb9#\xc8\xd1Cu\xc8\x18\xe6\xe8\xc1s\xdc\xe6t\x9bh\xf2\xc6\xfeOC\x92F\xf1=I\xe5*\f\x8e\xa7ӿ\x98\x15\xc6u\xeea \x96Z\x19\xb5:R\x1c苯\x02c\xae\xea\x17\xac\xb2\xf1\xc7 \xb8\xd5K\xd83\xbb\x84\xf5\xaf`\xcd݇\xd6gc\xf1R\xb8z\xf9\x15#\xf5\xab\bF\xe0\xadG\x1f\xd0\xfd\xb6%Iu\xd7+\xc2;\x8a\xebE\xa9n\xac\x97\xa2\xe6@V\x89SJ\xbd\xb4y_G\x95\xa2\xb5\xabH\x13\x16F\xb3j\x86Y\x19Պ\xeeϱ\xfa2\xaa\x8arU\x11\xa9\x12\x8d-\x1a\xe5f\x10\x944\xc5\vBq\xea\xd4\xf6&\xe7\xa8\xf1W\x05Ă1\xfdS\xcd\x05\xdd\xf1\xa5D\x19\x91\xeb\xba\n\x99\xda\xfa\xab5\x91\x0fGZ0\x9e#\xf9\xabi\xd4\xdbIe\x1a\xfb\xf9\xfcn\x19\xb9G@\xbd\xc0e\xe1\xe3]\xac%\x16\xb5\xc1\xf4\xa7kL\xa5\xb6稲G\xfc\x06\v\x81\x96\xa6k?9)\xbb\xa7;%}\xc0\t&w8\xed\x91VuGnNR\xceF\xf3\f\xc3\x05K\u05eeû\xbd\xddR\xd3\x14|\xba\xdc\xc3\xde\x1e;rk[\xef\x0e\xa1*Z7+ӍH\xea\x98{u|\x02\xbac$\x85UIS\xae\n(\xb6\x00\x8a\xefa%\xf3\fp\x86sL\xa5\xb0S1U\x93\x14\xc1\x97\x92$\x9fA\x14\x88\x8e\x80H\xb8'Y\x06s\f\x19ɉ\xc4i\xac\xa1)\xbe׳\xb6^_\x16\x8cC\xa8/L\x14\x88^\f\x9d\xc5\x03s\x98\xe9Ə\x9a\xe4\xd6\xe90z\xc7E)Tr\xc1\\G\x97{\xaaY\xed:\xe1H\xd3o\xdd\xeb'\x8c\xaa\xf5V\x91\xbdd\x94bm\xe3\xc1\xc6>ۇZ\x10\xb5\v|B\n\xbb\x92\xeb\xfd\xab\xaf\x8a\xb7\x03\xd9\x06\xa1\xcdV\xc5_¨\x868OS\x95ڣ=1\xac\x1a-\r&\x13\xf8'\xca\xec\xad\xed.\x90\x94\x88Č\xbf\xde\xe6\xdf)\xe6`\xd4\x1a\xd8`\a\x1c+i\x8aL\xa0\x0e6\xf7J\xbb\fZY\xc3\x1e4\x10\x99\xe1@[WY\xa6q\xd0[&q\xeb|\xd6F&\xcc\xf6\xb1\xb6{4vO\x92\x95\xe1Z!1\x96ښ:\xe6B\v\x19\x9d\x80?\xe6X2\x96\x19B\xfc%T\xfcQ\xacfGإ\xe4\t\f\x0e\xb6\x83\xf5\x84J\xe8M|\xf9\xe0z\xad\xdb7\xca\xdax\x9dP\a\x85\x8b\x8bXGn\x1br\xe0\x1f\x9f\xbb\x93\f\xa7*\xfb\xe9\xc7\x02\x11\xfc\xa6d\xbf\xc5\xe6ՌJa\xea'\xa6\xe9\xe6\t\x8d\x93\xc3홌\xd6gC͎\\\xe8\xed\xd8\xf7\U00103ff6y\x9epV\xb4}\xbd\xb0\x89\xb6\x01\xd4\xe1\x03\xb2\b\xbf\xebR\xb1\xbe0\xf6W\xdb6d\x14E\x9b']-(\xf7\xea7\xdaE\\\xdf%\xef\x90\xdbc\xf8=-/p\xbd}\x8e\xdc\xe5\x12\xbe~\x85\xfd\xb8*GՅ¾nr@Z\xfc\a\xcd\x10\xe1\xdcϺe\x85\x879\xd2*v\xd6,\xfbG)\xb7\xc5\xc7\xe1\xf6jsz6\xabk\x9a=\xed\xd6\x02\xeb\xc09\xc8~\x0e\\\xbf\r+lߎ\xadj\xec\x10[\xe6Le\xfc\xce\xfc۪\x11nH\x8e_i\x9d\xe4\xfe6\xea\x86ߎ|\x90\xd56\x05\xd8\xfd\xed\x16\t\x1bF\xda8!wj\xb7\xfa\xd7#8\xf6lZw\x9c³\xa9\xcd\xe9W\v`w\x98ó\xa9\xae \xf5nl\x04\x8cfk\xc8\x18J\xe1\xd94\x86\xffU\xc5\xe2\x12K\xe08G\x84\x12\xba\x04\x8a\x1f$\x14H\x88\xb8}\x99`k\xa3W\x9c\xe57\xac\xb8\xd1\x0f\x92܅\xa4\xeb\xd4ws\xcd\xd8\xeb:\xb4\xb6\xed\xd6\xdbPMN\x8a\xe1٩*, E\x12\x8dmu\x00\x89J\x93\xb3\xa1\xad*@\xb2b\b\xba\xa2\x99\r\x87g\xaf\x19J\t]\xc6q|:Q\xac[\xefD\xcd\xf9}\xe5\xd4\xe1nZg\xa9ك\xba\x154{p\xa8\xe46\x04] Ά\xe3\xe3\xe9\x1e,\xd5|ޟ\xad\xba\xa8hJ\xd3ae\xd3y)%\xa3 \t]\x03\xca0\x97ó˚\xaa\xf7v\xa2\xeb\x92a۽\xfaf\xe4\xa0\xe2?\x81\xf3\x9f\xc0\xe9+j\x1e\xfd\xcd\xff\xcb\f#Z\x16\xf0\x81\x95\x92P<\xf8\x1d[|U\xfcy[\xfc\xeem\xa3ڰ$Y\x99b\x11\x066>\x02\xb7\xeeS0\xf6-\xaa\b\x9b-\xf4\b\xba\xb1\xabU/\xf22ꎣ\x86\xbe\xb5\x83,\xc2}F\xa0\x0f\xb2\x9a؎\x83C\x8e96\xe7\xf0\x1e\"}i\x9b\x03\x19\xd4'(\x8f\x11\xd8\xfb\xb5\xf34\x85\x8c\b\x89\xa9Z\xe9$\x83&\xc4\xc0\x84\x96~ll\xb3\x05\xa3\xe10g\xa5\xc0e1\x1c9~\aw\xb7\xddܕ\xd9\x05\xcc{F\xe9\xd0\xf9cr\xb7\xe8Q\xeb\x80s\xf7\x85\x9b}\xe5q\xae\x1f\xeckӧ\x98\x12\xef\x00\xb1\xaa1\x14\xddU\xdasNP\xbf+M\x89@\xf3L\x01\x1c\xc0n\xdcpi%\x0f:]\xf9\a\xd58D\x91s)q^\xc8\xe6\xcb\x00\x8f\xf6\xe8=\x1a\f\xae\x19\x97U\xb9a\x9e\xc2w\x97\"\xa6o2\x01\xc5@\xe8\xb2\xfa\x15\xe6k\xb8,\xb9>\x18\x19TI`\x9cږv\xac\x80\x13\x13\xfa\xcb\x16a\x10\v\x038&\xf9\xb2\xfbE/Y\x84\xae\x96F\x15\xf7\xbb\x10\x9eȱ³`\xdb^>\xf72\x99\x00\x14<\tF\x01ɗ\x93\xb2\x88\x8b\xea\v\x10\xed\xf7\xca\xffZ\xc9)\xbb\xa7\x8dl\x95\x8c\x11\xe7hmO\x82\xba\xb2\xed\x12K\x9d>\xeePv\xbe\x83\xb4\xb7\xae6\x18\x8e\xb0\xa5J\n(S>\b+\xb5\xaf\xc4k,\xc4\xcd\nё\xa1\x1b\xd525\xef\xa6\xd4\xc0\x1e%\xa1\x9a\xa6'\xd0\x1a_\xeb\x00u\xe2\xec\xea}\x13a:\x99\u007f\xab\xd8\"\xc5A\xbe\xf5\xc9\x0f\x8e\xa7?Uڷ\x88\xa1f\xfd\xd9\x1a;\xa4\xf8\xc3Q\xd3\n\x88kLe\x13\x12\xf6`\xe2[\x05\x85\x12w\x90\xa3\xda\f\a\aƟ.\xf1[\x04\x87\xf5\xca\xd6\xc8\xc8\xc5\xf2\x0f\x87\xc6\xef\xc8'\xd5qJ\x13B\xce\xd9̷\n\xa3J\xe4A\x8e\xedb:8\x9c\xfee\x92\xbfEX9\x9e\xfa\xb7\t\xad\xfa:\xdfU \xb3\xb2_\xd9NW\x87\x12\x1b-\xaaG\r\xfd\xe1\xe2\x95i\xd5W\x11=\xb7n\x16r\xa6\xce\xd3#\xc3\x14f\x8e\xc0\xb8~\xad\xb1`\xdc^U\xce`zb\xbe\xce\x06\xa7\x15\x93m8:\xaaԐy\xf1O\xfdm\xc8\x06˽Ɣy\x0133\xbe\xaa\xb9\xaa\xca\xfb\x87f\x94P\x15\xbd\x91>\x86\xe3\x13\xf8\x04g0>\x86\xbf\xfe\x15\xbek\x1b0td\u007f\xba\x8d\t\xa5\x98\xdf\xe0\a9\xb2\xda5-\xd1\t|\x1a\x8fݽ\x9e\xcbzt|\xeb\x0f\xe4\xd3mM\x87\\\x12\xe4\xf7>v\xd5\xf3[\x87\xf0o:\x02\xe3\x9bM@\xa3\xc4`\x03E慍)s\xebnz\xbd\a<\xdel\v\xd1\b\xe6ul\xdb\xe7\x1dH\xbf\xda\x16\x92\xab\xdd\b|\xfd\n\xb6}\xee\xb6W\x03\xb6r\xa6V,Y\x84\xa8}\a0\xefzs\xe0\xf1\xa9\xc4p]dD*C\xc4B\xfd\x16\x06\xa1\xceM\xfa\v\u007f\xaaY\xf7\u007f<\xbe\xad\xba\xc1t\x9bXO\x18\xbd\xc3*z\xcd\x19\xbdf\xfa8\xbd\x1d\x19\xf6\x8fǷ:\x8b\xcc+\x19s_\xc6\xdcʘw˘wʘ\xd72\xe6\xae\fe\x00E\u007f\xaa\xd9Z\xa3=\xf6\x9d3\x1dx\x9eq\x8b\xaf?\xcd1\xe3JfmWs\xe00w?\xaan\x93\x80P\x93w\xe6\xa6e\u07b4\xa8\xb1\xa9\xc6S\xdd\xd79\xb6*_\xd9\\\x05\xa7\x1a\xf8\x04\xc8ё=\x19 \x8b\xf0m\x99\xcf1\x0f\xe7\x1fɭ9{y\x8b\xde\x06\xed\xa7G\xf6\x88\xbf\xf9\x16\xb7\xe5B>W\x8bi\xeaΛ6\xd3)\xb8\x92\x0f\x13x\xe6\xf3\xf6\x88\xb5/\xccj\x97n\xee\xc5\x1cǢk\x9c\xb8qe^\x00\x8a\x10i\xff\xf4tΣA\xfb\xf5\x1e\x1ai\xa8Q\xf05\x18\xcdG\x9a\xd3\xd66F\xc2L\xe585\x0f\xebO}1R\xb1\x9c\xce\fJ\xcb\xc3\xfa\x89\x8e]\xb6\xdc\xdcZ\x19A\xf5\xbf\xaa\x16>\xcf\x0e\x1bÐ$\xc7\xed\xf0Vm\xfbD\xb2\xf9\xfb\x17\x1aG\xa5<\x92cg\xbe\x9e\x18H\xdb_e\x9eSx֍6\x00{W$W\x98c \x02\x10L!'t\xb2\xe2\x93T\xd5\x00D\x82X\xb12KAH}]\xc41\x92\x98\x1bF\xb9B\x142v\x8f9\xa4\x98\xb2\x9cP\xed\xee\x18\xceӔ\xd0%\x1cC\xc2\xee\xf4q\xdd\n\xc3\x14\x12\xa4mc\x95\xfb8\xbd=:\xf2\xd4U\xa9\xa79M\x158i\xfe$\x81U\xbba\x85\xef͔\xabõ\x13#'t;Ƌ\xe9n\x90\x15ߎ\xf1\xfc\xc5t\x0f\x94\x14\xad\xb7\xc3\xfc\u05cb\x1f\fNw蘴K\xf5,\x1c\x81\x89\x91:\x84\xccGG\xda/\x17\x1b\xc2\f\xaby)\xdaҷ\xcd\xfdf\a\xf7N\x80\xbf\xef\a\xd0\x1e\xa99%_\xa1\xb5\x90(\xf9<\x02\x8aq\x9a\xd5e\x98\n|\x95Y\xab~\x1b\xdd\xe6{\"\xf7+\x92a\b\x89W\x8b\x90\x05\xd4h\x1f\xc9-\xccf\xb3\x16&xyL\x15}\xf5\xd7N\xdc+\x05ۯ\xcb\xda\x13O\xeb%\x96W\xef\xf5\xd3S\xbe\x0e\x91};\xf6\xdb\x00&\xdf\xc3SU\xf7\xff\xe3\xfa\xdd\xdbp\xb8\x92\xb2\xf8\xdbdB\nB\x17,&l2\x84#\xb0\xd4p\x04Cw\xff\x96\"\x89l\x82uӜj\x8e\x13#\xc8\xfd\x83)\x10\\]\xbf\xd7O\xa55\x05\xe3K\xfd\x00\x1e\xdeq\xb2$\xb4鰬\xe6u\xbc>]\xfd~R\xfd\xf9\x0e\xa1&\xab\xbcg\x90\xb1%\x11\x92$\xb56\xa2\x19\xa9\xff\xe8\xe4\x8b\xfb\x00\x87,\xaa\xcfp6s\xbf\x05T\xa9\xc8\x11\xfd<^\xea?\x8a\xe1\x05\x8e\xc35\xfe\xb1\x87\x8beiГr\r\x05ǆ\xc0\xf3\x8b\xfbfa\xae\xfe\x1dAn\xdf(4\u007f\x06Bw\xa85\xa1~ƫ\x16\x8a\x8a\xce\xebh\xeb6\x85p\n\xbf̍)Ղ\x05\xb3z\x89Ԩ\x138\xc6GϣX\xb2W\xe4\x01\xa7\xe1qT\t\x85S\xd7D\x8aq\xaeߵ\xffr\xe1\x19\aB\x17\xe9E\xb4ɶ)\xefEK\x9e\v\xff\xe6bÌ}0\xff\xbd\x05\xe6\xef\x17Րs\x98ն\xb2c\xcb͗\xc0j-\xf3\x06\xbe\xb6\xea\xc4P\xb4%h4c\x87\xc0\xaf\x13u\xab\x0e乍\xde\xc7\xff\x0f\x00\x00\xff\xff*F*3NJ\x00\x00",
-		hash:  "08a577994b5b1f40cdfef3101d90678d885f1260255c157155d7793482f5b213",
+		data:  "\x1f\x8b\b\x00\x00\x00\x00\x00\x02\xff\xec<]s۶\xb2\xef\xfc\x15[&爬%JN\xdaν\xb5\xe5\x19;\xaeO}ۤi\xec{\xeeC\xae\x1f \x12\x92\x90P\x00\x03\x80\xb65\xa9\xff\xfb\x19|\x90\x04(R\x1f\xfdȜ\x87ә\xc6\x16\xf6\x13\xbb\x8b\xc5b\x01\xf9\x1eqHK\xce1\x95?b\xb2XJ\x98\xc2$P\xa39F\x19\xe6\xce` \xb0\xbc\xa6\x12\xf3{\x94Ge\x91!\x89\x7f\xbc}\xfd\xf3\xf0\xe5d2\x89O4\x8d\xc0\xfc\x1e\xf3_hN(\x86)\xccQ.p0\x1e\xc3\xff\n\x9c\x81d`\xa8@\xb0\x15\x06\xb9$t! \xc7B\xc0\x9c\xe3O%\xa62_\x1b6\x1fIQI\xaa\xd9\x04ϣ\aB3\xf6\x10'9CY\x14\x00\x00\xccK\x9aJ\xc2h\x14\xc3g=\x00\xd0h\x16\xc5vH`yKV\x98\x952\xaa\b\xc0\xa1\xe8\xa5{\x1a±\x99\x9c\xfe\x14\xc4'AP3p\xf15\xab\xe7\t\xfa\x80\x1e\xa3A2\x1e\f-oQ\xa6)\x16\xe2{G\xcfϵN\x9e\xa9$/\xb1\x912\xd4?0\xe7\x8c\xefAglc\xd4\x03xR\x1a\x02\x909D_\xb9\x88\xd5\\\x9fG\xe133>\x12\x12\xc9R\x84q\"\xf1\xa3\x8c\xc2+\x94J\xb6\xca\xe0\r\x93𮤔\xd0Eh\xcc\xc0\xb1,9U\xcc\x01\xe7\x02\xef\xcd\xc9\xe5\xf2Ti\xa5\xc8\b\xcd\xf0#E\xf7\xa3\x15\"4\x8c\x93%\x12\xafr$D\x14\x121B\xa9$\xf78\x8c+\x8d\xc7cx\x8d\b\x85[4\v\x1c/騌bp\xc6\xce\xf3\xfc-\xc6\\X\xef\x8d\xc7pɰ\x00|\x8f\xf9\x1a\x10er\x899\xa4\xeb47\xe6\"\xf3\xe8+7\xceb?~n9\xa2\x02iۋ&\x8e\xfc\xb8l|\xe6Z\x06\xba÷v\x91\xc1%\xf3\x96-\x18\xc7{\xd8\xe2\x12KDr\x9c\xf9\xf6@\x97\xea\xffrU\x18U\x9f\x82\xe0)\xd0\xcbNO\x05\x1e\x96\x98\xc2\x03\x06\xf1@d\xba\x04\x89f\"x\x1e\x85\x89\xfae\x942*9\xcbG\x05\xa28\x87\x9c\x00\n\xe3$\xcdI\xfa1\xf2\x83\xcfYD\x81\xb7\xf0\x02\x80ύ.\xcd\nz\x1a\xc2\xcb\xc9$\x0e\x9eb\xb5v\xc3gY\xb9*\xb48D(\xe6\xf0l^\xe6\xb9H9\xc6t\xc4\nū\x16\xdc\n{\xf9(\xcf9F0\x85\x0f\xbf\x96\x98\xaf#\xb9$\"N\x04\x99\xe5*\x85Da\xe2\x18\xab\xc1O$[,rl\xed\xd9H\xd38\x1e'\x0f\x11\xcd\x04\xcbK\x89G\x1d\xfam%\x9c\x93G\x9cuR)\v(\x7fܲB[\x1f\x18\x05\xed\xf9`c=\xc0Y\xa7\x03\xe0\xb3]@\x9e\xf8-Ѳ\xb1X\xa5\x13\xd0a\x9cp\xbcb\xf7\x95\xe6K\x92\xe10\xaeQs\x96\xa2|\aNf#.\x8c\x13\x94em\x9c\xa7\xda\xe9^\x80\x7f\xa9\xc9uh\xe4Ϭ\x17\xc1\x99V\xf7\xec\xcd\xcc\xfcM\xc0]~Z)\x8eE\x01S\xf8\xa4fs#\x91\xc4QX3\x1eB\x18\x0e\xeb\xb9+L\x9by\xd8\xec\x03L\xe1\x7fn~y\x93\x14\x88\vl`\x8df\xe5\xaa8\x06\xfd\xe3fɸ\xac\xf2-\x9b}H*\xf9ǉ\x06\xa9_;\tߡ\x87n\xb2w\xe8\xc1\x10yT/\xb6R\xbd\xe8\xa1z\xb9\x95\xeae\x0f\xd57\x86꼔\xcb.\xb2o\x12\x05a\x9cH\x82E\xdcEy\x9da*\xbbI5\xa8\x9f\xf2\xf5\xfa\r\xcbp7\xa9\x81u\x92]\xa12\x97\xa2\x9bL\xc3\xceˌ\xc8\xd64\xbf5\xb4\xaf\x18\xed\xb1Ϸ\x8d}:\xe8nz\x1c\xffm\xa2 8\xab\b\x9fb\xb5\x03\xb4J\x15\x7fK\xeb\vT\x8eSL\xa5\x8b\x1b\x0e\x0f\x8f\xd8\xf1\xd8np\x97\x17\x179K?\x9a\r\xbbR=\x86\xaf\xa6\xa0\xf5'\x1c\xa7\x92\xf1\xb5FJ./\f^S\x9a\x19\x16?\xe1\xf5\xebw6y4s\xf7i5N\xec\x91]\xb0l\xad\x87\xb7\x90\xd58>\xe9U\x99\xe7?\"\xb1\xdcBY\xa1\xb4d*\x98\xda'\x85D\xabb\vy\x8d\xd3A\xef[k\x9b\xa1\x1cZ\xe3\xb8QVa\x8ef\nu/&\x96\v\x99k4]\xc0\x91\xcc\r\x01\xe5/Z\xe6M\x91\x04Ѓ\x99\xcc\x19\xff\x01\xa5\xcb&\xbd\xeb\xdc\xec\x17\xdbdnF\x93[&Q~M\x8bR\xc2\x19L\x92\xc9dr\xeccBU(\x15\x88Zi\x02\xce@%\xfcǟ\x89PdrƲ5<\v\xe1\b,\xd3\xc7\xeb\xcb8\xc91]ȥb\xdb\xe6ت֪\xff\xf6\x90\x12\xc6I\xc1q\x81i\x16\x85\xff\xdf\"?\x95\x1cH6\x1d\xf8z\xc0\x11\x84\x83\xb36\xae\xc1\xcf\xceN\x91&\x99\xeb\x8ay$0\xe2\xe9r\x94\x13\xfaq\x00r]`\v!\x19J?\x0e\xce6\x19\x9f\x8e\xd1\xd9\xe9Xf\xbd\xfc\x1d\x92\xc6К\xf0@\"q(\xd5/\xa5\xdcJv:\x96\xfc,\x8c[\xa3\xd5\ta\x97\xb3\xcf@\xf2\xd0q\xf1\xf1d\xc3\xc9{z\x14\xce\f'$dTm\xf9\x91=\xef5\xff=\x81\x1f@A\xd7\xefO\xf5\xa9\xd1_N?P\xc9\t\xee[B\x16\xba\xb9l0\x95|\xed\xcfJW\x90\x12\xe5\x81?E\xbb\xf05\xc1H*\x84\xfa,\x16)\xb7X3Tz\xec6\xe8\x11\x84\xb1\xe7\x1b\xc7/\x15\x17\xbd\u07b4\xc8D'\xc1-\xebͮ\xe0\xf0\xa8AW\"\xe0Y\xbaD\x84^_\x02\xf2\xf6\x05\x83\xf5\xca\xc0\xe2\xcee\xba\a+\x9fK\xaf\xfb\x9a\xb9\xed\xab^\xf8\x963u\xa6\xd7G\xdb=\xb53\xae\xd1\xff.ՠ∤\xe4Q\xa8\x96\xb9*\t5,ܮg\x8f\x9a8M\x99\x90\x1d&\xfc\xe1\xd5+&\xe4\xde:zl<\x0e\xfd\xc1ߕIw\x87[\x7f\x1au\x93\xa8\xaf`W\x12=\x95\x99\xc6n\x99w\xb0;\xafj\xdc:\xab\xfa\x92\xb6d\xd5J\xa0\x8d\x8c=\x04i\xcc%F\x99+\xc9F%\xec)\xcdx\xc6e`\xfcғ\\\xbbRk\xc7\x02\xfe\xddyu\x1f>\xbb\x93\xea\xce\x1c\x1at\xe6<'\xdfٽqK\xc6;`\x0f\xa9S\x9e\xa9\x9c\xc7cx\t\xea0J0\x17@(\\ \x99.7z\x7fU\x17\xca)\xa5g\n\xf1W\xa7\x9e^\xad\r\xda\xd0\xed\xa7\x0eS\xb6*r\\\xb1\x18\x9aNZ\xcaJ*\x87\xe9\x12Q\x8a\xf3\x9f\xb5b\a\x17ޕ8\xd0\x05\xf6\xfb\xc9]b>k`\xee\xc1\x8e=\x98\xd2\xc8\x03\xbf\xf0\xc0s\x9c\t\vxy\x97\xccq\xa6GQ鎢2\xb3\x1dCQ\\\x91{l!\xdf\xdcY+\a\xad\xce\xe1\x1cgz\xcaa\x9c\xa8\x96\xb2\x12\x11\xb7PP\xe9\xa1(y\xb6\\m7\xad\xb5!\xae\xa9\x8c*\v4\xac(\xcbp]R+6\r\x8a1\x8b\xdf\xe9\xae9\xe5.#\xe3\xf2\xb7\x9c-8\x16\xe2\x02q\xa5㚦W\x84\xeb\xa8J\n\v\x1a\xad\xb0\xc4<\x1c\xfa\x1a\x0e=)\x86e\x81\xb9\x8ad\xdd\\\xb7)\xdeWe\xean\xa6\r\xf6\xf1d\xd2\xd5vl\x10\"O\xf4ؓ\f_\xd7\xf4.\xc9k$\x97\xc9<g\x8cGv0\x0e\x9a\xb5\xf9<\x1al\x9b\xec\xe6\xc8H\xadƁ]\x94\x95\x94#\b\xff\x067k\x9a\xe2\f\xf4:\xf5}x\x04!\xb09(\x80g\x06\xbb6\xed\xb9\xb2ӡM\xfc\xfb\v\xcb\xf5f\x13\xe0\xdb\x1dz\x83SF\xb3n\x8f\xfa\xab\xb6ߥ\x96ǟ\xebؚi\xe4\xeb\xb1ۿ5妗\r\xa8\xcb\xd7}v\xd8\xcfٖz\xd3\xe5\xbe\x7fz}\xee\xba\x1c.\x89(rd2*\xbc2\xf9\x11lN\xb1()\xa3\x82\xe58\xc9\xd9\"\n\x15\n\x98\x04\xfa}8\xac\xf3Qog\xc4\r\x02\x92\xd5+w\b+\xf4Xu'\xa3\x15z\xf4\x1c\xb7\xb9\xdc\xc6\x1a\xbd\xb1\xff\xf3\x88dq\xf2@2\xb9\x8c\xc2\xe3\xc9\xe4of\x87q\x9d{\x18\x13\x8b\xad\x8cZ5#\x03}aV`\xccU\xfd\x82\x05L\xe1}\x18\xde\xe9-\xec\x85\xdd\xc2\xfaw\xb0\xe6\xceD볱y)\xbez\xfb\x15C\xf5\xab\b\x87\xe0\xedG\xef\xd0ö-I\x81\xeb\x1d\xe1\x17\x8a\xebM\xa9\x1e\xac\xb7\xa2\xa6\x95\xab\xc4)\xa5^ټ\xaf\xa3J\xe1\xda]\xa4\t\v\xa3Y\xb5¬\f\x8b\xd5Zc\xf5%V\x15\xe5\xaa\"R%\x1a\x9b7\xcaM!,i\x86\xe7\x84\xe2̩\xedM\xceQ\xf3\xaf\n\x889c\xfa\xa7Z\v\x1a\xf0\xa9D9\x91\xeb\xba\n\x99\xd8\xfa\xab\xb5\x90\x0f\xe74g|\x85\xe4\xaffP\x1f'\x95i\xec\xe7\xf3\xfbE\xecv\x80z\x19\x97\x85\xcf\xefb-\xb1\xa8\r\xa6?ݨ\xa6\x9f\xb2簲G\xf2\x1a\v\x81\x16\x06\xb4\x9f\x9c\x8c=Н\x92\xde\xe1\x14\x93{\x9c\xf5H\xab\xc0\xb1\x9b\x93\x94\xb3\xd1,Ǡ\xdau\xaeû\xbd\xddR\xd3\x14|\xba\xdc\xc3\xde\x19\xdb9\x8b\xb7.v\xaa\xa2u\xb32݈\xa4\x8e\xb5W\xc7'\xa0{F2X\x964\xe38\x13\xc0\xe6@\xf1\x03,\xe5*\a\x9c\xe3\x15\xa6Rإ\x98\x01\xa1\x80\xe0SIҏ \nD\x87@$<\x90<\x87\x19\x86\x9c\xac\x88\xc4Y\xa2YS\xfc\xa0Wm\xbd\xbf\xcc\x19\x87Hߴ(&z3t6\x0f\xcca\xaa\a\xdfk\x94;\a`\xf4N\x8aR\xa8\xe4\x82y\xf2\xd6\x0eƁ\x7f\xea\x84#\x8d\xbf\xf5\xac\x9f2\nS\x83\xf6\x8aQ\x8a\xb5\x8d\x83\x8ds\xb6\xcfjN\xd4)\xf0\x19)\xecN\xaeϯ\xbe*\xf0\xb9\xd5\xf0\xe8c\xa1\xcdV\xc5_ʨfq\x9ee*\xb5\xc7{\xf2\xb0j\xb44\x18\x8f\xe1\x9f(\xb7\xb7\xbd\xbb\x98dD\xa4f\xfe\xf51\xff^\x11\x87\xc3\xd6Ă\x1d\xecXI3d\x025\xd8<+\xed2he\r\xa3\x81$2ǡ\xb6\xae\xb2L\xe3\xa07L\xe2V{\xd6F&L\xf7\xb1\xb6\xdb\x1a{ \xe9\xd2P-\x91\x18ImM\x1ds\x91e\x19\x9f\x80?\xe7D2\x96\x1bD\xfc)R\xf4q\xa2VGԥ\xe4\t\x04\a\xdb\xc1z\x02gֱ\x1d\x16\xd0{ݾQ\xd6\xe6\xd7\xc9\xea\xa0pq9֑\xdbf\x19\xf8\xddsw\x91\xe1\f\xa6\xf6\x91A\f\x9f\x95\xec7ؼ\xb6Q)L\xfd\xc44\xdb\xec\xd089\xdc\xf6d\xb4>\x1bjv\xe4B\xefľ\xa7\x1f\xfc\xbd\xcd\U000c4ce3\xed\xeb\x85Mn\x1b\x8c:|\xa0\x9e|t\xa9X\xdf4\xfb\xbbm\x9be\x1cǛ\x9d\xae\x16+\xf7\xd28ޅ\\\xdfB\xef\x90\xdbc\xf8=-/p}|\x8e\xdd\xed\x12~\xfb\r\xf6\xa3\xaa\x1cU\x17\n\xfb\xba\xc9aҢ?h\x85\b\xe7f\xd7-+<\x9eC\xadbgͲ\x7f\x94r[|\x1cn\xaf6\xa5g\xb3\xba\xa6\xd9\xd3n-f\x1d|\x0e\xb2\x9fî߆\x15oߎ\xadj\xec\x10[\xae\x98\xca\xf8\x9d\xf9\xb7U#\xa8\xab\xd0+\xad\x93\xdc\xdfF\xdd\xec\xb7s>\xc8j\x9b\x02\xec\xf9v\x8b\x84\r#mtȝڭ\xfe\xf5\b\x8e=\x9bրSx1\xb19\xfdz\x0e\xec\x1esx1QtZ]1\x04F\xf35\xa8א\xf0b\x92\xc0\xff\xa9bq\x81%p\xac\x9e\x12\x11\xba\x00\x8a\x1f%\x14H\x88\xa4}\x99`k\xa3+\xceV\xb7\xac\xb8\xd5\x0f\x99܍\xa4\xab뻹g\xecu\x1dZ\xdbv\xebm\xa8F'\xc5\xe0\xecT\x15\x16\xa0\x1ělu\x00\xa9J\x93Ӂ\xad*@\xb2b\x00\xba\xa2\x99\x0e\x06g?3\x94\x11\xbaH\x92\xe4t\xacH\xb7މj)\xb5S\a\xbbq\x9d\xadf\x0f\xecV\xd0\xecA\xa1\x92\xdb\x00t\x818\x1d\x8c\x8e'{\x90T\xeby\x7f\xb2ꢢ)M\a\x95Mg\xa5\x94\x8c\x82$t\r(\xc7\\\x0e\xce.k\xac\xdeۉ\xaeK\x86m\xf7ꛑ\x83\x8a\xff\x04\xce\x7f\x02\xa7\xaf\xa8y\xf2\x0f\xff\xafr\x8chY\xc0;VJBq\xf0;\x8e\xf8\xaa\xf8\xf3\x8e\xf8\xdd\xc7Fu`I\xf32\xc3\"\nm|\x84nݧ\xd8\xd87\xac\"j\x8e\xd0C\xe8\xe6]\xedz\xb1\x97Qw\xb4\x1a\xfa\xf6\x0e2\x8f\xf6\x99\x81nd5\xb1\x9d\x84\x87\xb496\xd7\xf0\x1e\"}i\x9b\x13\t\xea\x0e\xcaS\f\xf6~\xed<\xcb 'Bb\x8a\xb9\x00ɠ\t10\xa1\xa5\x1f)\xdbl\xc1h4X\xb1R\xe0\xb2\x18\f\x1d\xbf\x83{\xdan\xee\xca\xec\x06\xe6=\xc0t\xf0\xfc9\xb9G\xf4\xb8\xd5\xe0\xdc}\xe1f_y\x9c\xeb\x87\xfe\xda\xf4\x19\xa6\xc4k V5\x86»\xcez\xfa\x04\xf5\x8bԌ\b\xd5\xea\xca\xc2\xf8\x00r\xe3\x86K+9\xe8t\xe5\x1fT\xe3\x10EΥīB6_\"x\xb2\xad\xf78\b\xd4KŪ\xdc0O\xe8\xbbK\x11\x03\x1b\x8fA\x11\x10\xba\xa8~\x85\xd9\x1a.K\xae\x1b#A\x95\x04F\x99\x1di\xc7\n81\xa1\xbf\xa4\x11\x85\x890\fGd\xb5\xe8~\vL摫\xa5Q\xc5\xfd\x0e\x85'r\xa4\xf8Yf۞L\xf7\x12\x99\x00\x14<\r\x87!Y-\xc6e\x91\x14\xd5\x17'\xda/\x9d\xffZɪ\x81\xdb\xc8\x0e\x02\x00\xc49Zôf\xd3ζ\v,u\xfa\xb8G\xf9\xf9\x0e\xd4\u07ba\xda\xf0p\x84-TR@\xb9\xf2AT\xa9}-~\xc6B\xdc.UcT\xe3\rk\x99\x9avSjh[I\xa8\xc6\xe9\t\xb4\xc6\xd7:@\x9d8\xbb~\xdbD\x18)\xbe`l\x91\xe2 ߒb\x9bWw\xc6ӟ*\xedK\xc4P\xb3\xffl\x8d\x1dR\xfc\xe1\xa8i\x05\x84\xea+4!a\x1b\x13_*(\x94\xb8\x83\x1c\xd5&880\xfet\x89_\"8\xacW\xb6F\xc6J,\xfeph\xfc\x8e|R\xb5S\x9a\x10rz3_*\x8c*\x91\a9\xb6\x8b\xe8\xe0p\xfa\xcb$\x7f\x89\xb0r<\xf5o\x13ZU\x90x\n\xe4V\xf6\x95\x05\xba:\x94\xd8hQ=j\xe8\x0f\x17\x17R\x7f\x85\xd1s\xebf!g\xea<=3La\xea\bL\xea\xd7\x1as\xc6\xedU\xe5\x14&'\xe6kppZ\x11ف\xa3\xa3J\r\xb9*\xfe\x89r\x8f\x97{\x8d)W\x05L\x01\xb9\xc3UU\xde?5\xa3\x84\xaa\xe8\x8d\xf4\x11\x1c\x9f\xc0\a8\x83\xd11\xfc\xfd\xef\xf0Uۀ\x91#\xfb\xc3]B(\xc5\xfc\x16?ʡծ\x19\x89O\xe0\xc3h\xd4\xc8\x01W\xed\x0fG\xc7w\xfeD>\xdc\xd5x\xc8EA>\xf4\xa9\xab\x9e\xdf:\x85\x7f\xd3\x19\x18\xdfl24J\x04\x1b\\䪰1en\xdd\r\xd4{\xc0㭶\b\raVǶ}ށ\xf4\xabm!\xb9:\x8d\xa8&\xbe\x1d\x9f\xb9\xe3Մ\xad\x9c\x89\x15K\xe6\x11j\xdf\x01̺\xde\x1cxt\x01\x00\xba)r\"\x95!\x12\xa1~S\x0fSc5\xae\xbe\xb1\x05S\vWO0-\x18\f\xd8\xc4z\xca\xe8=V\xd1kz\xf4\x9a\xe8\xfd\xe4nh\xc8\xdf\x1f\xdf\xe9,2\xabd\xcc|\x193+c\xd6-c\xd6)cV˘\xb92\x94\x01\x14\xfe\xa9&k\xcd\xf6\xd8w\xce$\xf0<C\x8a\xbf\xc01\xa3JfmW\xd3p\x98\xb9\x1f\x15\xd8$ \xd4䝙\x19\x995#jnj\xf0T\xc3:\xe7V\xe5+\x9b\xab\xe0T3>\x01rtd;\x03d\x1e\xbd)W3̣\xd9{rgz/oЛ\xb0\xfd\xf4\b\x8e\xddE\xdcP!\x9f\xaaE4q\xd7M\x9b\xe8\x14\\ɇ\t<\xf3i{\xc4\xda\x17f\xb5K7\xcfb\x8ec\xd1\rNݸ2/\x00E\x84\xb4\x7fz\x80\xb38h\xbf\xdeCC\xcdj\x18\xfe\x16\x0egCMik\x1b#a\xaar\x9cZ\x87\xf5\xa7\xbe\x18\xa9HN\xa7\x86K\xcb\xc3\xfa\x89\x8eݶ\xdc\xdcZ\x19A\xc1\xaf\xaa\x8dϳ\xc3\xc64$Y\xe1vx\xab\xb1}\"\xd9\xfc\xdd\f\xcd\a\xa6\x9a\xcaY\xaf'\x86\xa5\x85W\x99\xe7\x14^ts\v\xc0\xde\x15\xc9%\xe6\x18\x88\x00\x04\x13X\x11:^\xf2q\xa6j\x00\"A,Y\x99g \xa4\xbe.\xe2\x18I\xcc\r\xa1\\\"\n9{\xc0\x1c2LيP\xed\xeeD5\xeb\xd4m\xd21\xa4\xea\x12J(\xf60\x81\x14i\xdbX\xe5\xdeO\ue38e<uU\xeai\xba\xa9\x02\xa7a\xdcR\xbb!U/\x1e\xbd\xbf\x8e\xd0\xc9cE\xe8v\x1e\xdfMv3Y\xf2\xed<^~7كK\x86\xd6\xdb\xd9\xfc\xd7w\xdfL&\xfd\xa1c\xd2.իp\b&F\xea\x102\x1f\x1di?]l\b3\xa4\xe6\xa5hK\xdf6\xf5\xeb\x1d\xd4;\x19\xfcc?\x06홚.\xf9\x12\xad\x85D\xe9\xc7!P\x8c\xb3\xbc.\xc3T\xe0\x13\x98B\x05\xb7\xd1}\xa2\x81\x0fK\x92c\x88\x88W\x8b\xa8\xcb\xd1\n\xfb=\xb9\x83\xe9t\xda\xe2\t^\x1eSE\xdfI\x00\x9bW\n\x16\xae\xcb\xda\x13O\xeb\x05\x96\xd7o\xf5\xd3S\xbe\x8e\x90};\xf69\x80\xf1\xd7\xf0\\\xd5\xfd\xaa\a\x1c\r\x96R\x16ߏǤ t\xce\x12\xc2\xc6\x038\x02\x8b\rG0p\xcfo\xea>\xca&X7ͩ\xe1$5\x82\xdc?\xb4\x02\xe1\xf5\xcd[\xfdTZc0\xbe\xd0\x0f\xe0\xe1\x17N\x16\x846\x00K\xaa\x81\xa1\xee\xae~=\xae\xfe\xec\x87\xfar\x1a\xc8\a\x069[\x10!IZk#\x9a\x99\xfa\x8fN>\xb9\x0fpȼ\xfa\fgS\xf7[@\x95\x8a\x1cя\xa3\x85\xfec\x1a^\xe08T\xa3o{\xa8X\x9e\x85=)\xd7`pl\x10<\xbf\xb8o\x16f\xea\xdf!\xac\xec\x1b\x85Jg0\x00\xb5'\xd4\xcfx\xd5FQ\xe1y\x80\xb6n\x13\x88&\xf0\xd3̘2\x00\x98\xc1\xb4\xde\"5\xd71\x1c㣗q\"ٕ\xfa;\x1f\xd1q\\\t\x85S\xd7D\x8ap\xa6\xbc\x02?]xƁ\xc8\xe5\xf4]\xbcI\xb6)ﻖ<\x97\xfd\xeb\x8b\r3\xf6\xb1\xf9\xef-l\xfeqQMy\x05\xd3\xdaVvn+\xf3%\xb0Z\xcbUþ\u0084\xb1\xc1hK\xd0܌\x1dB\xbfNԣ:\x90g6z\x9f\xfe5\x00%P\xb8Z\x86J\x00\x00",
+		hash:  "279e1de439e237d72b3e5e9dd6a1773c971911f7fbc4837806a174d480048cd8",
 		mime:  "application/javascript",
-		mtime: time.Unix(1483995499, 0),
-		size:  19022,
+		mtime: time.Unix(1792427452, 0),
+		size:  19078,
 	},
 	"js/factomd-ajax.js": {
 		data:  "\x1f\x8b\b\x00\x00\tn\x88\x02\xff\xdcW]o\xdb6\x14}\u05ef\xb8劅Be)[\xf6\xd4T\r\xd0u[1t\xe9Vw\xc0^i\xe9:b,\x93\nI\xc56V\xff\xf7\x81\x1f\xb2$\xc7N\xe3\x15\xd8\xc3\x1e\x02$\xe2\xe1\xb9_\xe7\x1e)\xf3V\x14\x86K\x01w-\xaa\xcd\xd40\x83\x94\x1b\\&p\xcf\xea\x16\x13\xb0\x80\x18\xfe\x8e\x00\xee\x99\x02\x85w\x90\x83\xc0\x15\xfc\xf5\xdb\xfbw\xc64\x1f\xf1\xaeEmh\x1cE`OS)\x14\xb2r\xa3-SQ1q\x83\x90C\x17\x85z&\x00>\xa7\x16\xec\xa0.(\xe49\xfcН\x02dY!\x85\x965\xa6\xb5\xbcq\t\xc1\v 0\x01\x02/\xc0\xdfԍ\x14\x1a\xe3p\xc1F\xa0\x0f\x0f\xb6\x91\xffq\x995((\xf9\xe5\xa7O$\x01\x92fsV\x18\xb9,\xaf,yni\xbb(ߺ\xcaݣ\xd0\x03\xa3Z\xc7gY4\x8a\x92\xc6\xd16\x8av\xad\x9b1ST\u007f\xec\xf7\xef\xff\u07b87\xb6\xea+W\xfb\xae}\xc7Z\xf5\x9c\x92o\xfc\xb5\x89F\xa6\x8a\x8a\xc4iQ\xf3bA\xf7\n|NI:\x02NP)\xa9H\x9cꚗ\xf8gC\xe1\xe2\xfc\x1c\xe2h\x1b\x1f`\x9d\xe8v\xb6\xe4\xe6\x18\xb9\a\xbdaj\xea`Ա<\x8cXHa\x18\x17h\xa3.p\xd3(Ժ\xa7\xc2~\xa6\v\xdc@\x0e\x98\xae*^T\xf0\xf93\xa0\xc5\xff(K\xbc\x8c준>\xa3\x0e\x93\xc3w\x17q7#\x85\xa6U\"\xb4\xf7`J\xbd\xb2\x1e\x1c\xefb\xaf\x8f\xa9\t`\xfdt)\xad\x8f\vi(\xa3\xf5\x03\xd5\xc8\xd9-\xe4\xf0\xeb\xf4\xc3u\xda0\xa5\xf1\x00\xc4\xd6/g\xb7\xe9\xa7M\xe3\xb8I9\xabe\xb1x\x87\xfc\xa62\xa4\x0f\x04\xb0⢔\xab\xb4\x96\x05sU\xe7@|\xe1W\\4\xadq\xea\xb2L\xbb\x055\x9b\x06sOG\x02\xcb\x16\xb0\xd68\x0e\xfa,\ar-\x05\x9e\x1c\xec\x90\\\xefYM\xe3>z\x97\x93\r\xd4qg\x99\u0092+,\f\xfdj\xce\x04H#\xb5!\t\f:\vY\x06S\xb9DSqq\x03sيr\\~_\xe6\x17\x16\xe9\xad\\\tzq~\x1e\xef.<>\xef\xed\xc8\x14\xac\x00\xe7R-\xdf2Â\x0e\u007f\x0e\u007f\xd2\xd8j\xbf;LY\xd3X\x13 6gYZ\xff\xe8\x8a?\x84\ng\xc9\xf1f9\xb7\\\aG\xfa\xfd\xc34X\x92k\x95\u05fe3\x9d\x8e\xb93\x9f\x99,7$N\xa5\xa0gK\xd9jl\x9b\xb3\x84h\xf4K\xb6\xe7!5\x17\v\x92\xec\xef\xbbq*\x86[g\xf3\xd4T\\\xc7)3FQbO\\\xec\x8a\xe9j\x1fbp\xed\x97\xf2?\xd9\xd9S\xb7\xf2\xe0\x82\xf09\xed,\xcd\x1aWܟ<my\\\x1bF\x9a6\x83\x1d\xe9\x17u\x18\xe5\xfba\x02\xbb0~\xca\xd9\x13#8\xe1\r\xd5\xfaŕ<\xcc\xf3\xef6\x8f\xcf\xc7f\xa7\x1b,8\xab'\xcc\xcdo2głħ\xb9У\x8d\xfc\xfa\x85\x1f\u007f)\x9c\xb2\xf2\xef\xb9X<\xba\xf6\x16\xf0\xb4\xd5\x1f!w\xebo+?\x8aZ\b\xb9\x12$\xf13?\xcd\x0e,\x8f\u007f\xc3f\x19|\f\u0080\x157\x15\xd8+\xd6\x03\r\nӿ\u007fw\xe2iU\x9d\x80\xaf$\xe9`\xfd\xcb\xd8\xcd\fr;\x83W\xee\xf7\xd7d\xe4\x0e\t\x90\x8a\x97%\x8a`c\x1dA\xc0\b\xb6t\x98\xf0\x98\f\xfd\xe29={e\xd3\u007f}\x96\xec\x86\xed\xf3x\xd9\xe5\x13\x9ez\xa5\xbd\x84V\xd5vf\xbe\xfc\xd04\x97ThH\xf8\x92\xb8\x8c\xb6\x97\xd1\xe0SC\xe0\xda\\\xcb\x12\x83\xd5X5@>\xfc\xaf\x80t\b\x92\x90\x81?Z`\x10\xb6u\xed\xa2U\n\x85\x99\bY\xe2D\xb4˙\xfb\x8cr6\xe8\x90>\xb5m\xf4O\x00\x00\x00\xff\xff\xe0\xe4EHx\f\x00\x00",
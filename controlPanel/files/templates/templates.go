@@ -52,11 +52,11 @@ var staticFiles = map[string]*staticFilesFile{
 		size:  0,
 	},
 	"index/datadump.html": {
-		data:  "\x1f\x8b\b\x00\x00\tn\x88\x02\xff\xecW]o\xda0\x14}\x0e\xbf\xc2\U000decc8~\xbc9\x96\xa6\xeee\xd2ئ\xb2?`\xe2\v\xb1\xe6ؑ\xed@\x11\xe2\xbfOv\x12\b\x19\xedhJ5\xb4\x95\x97\\\xf9枋\xcf9\xfe\xc8f\xc3a.\x14 ̙c\xbc*J\xbcݎ\x88\x85\xcc\t\xad\x90\xe0iH|\xf2\t\x94Ifm\x8as\xc1\x01\xd3\x11B\b\x11.\x96\xed\xb0\xd1+LGQ\u007f8Ӳ*\x94mS!\x9d\x8f\xe9\x0f0\x85PL\"\x0f\x8fDQj\xe3H\x92\x8f\xe9(\x8a\"Rɶܱ\x99\xc5\xe1\xa5؇\xe1\x1f\xc1\x03+J\ta\x00\x87\x82\x88Hѭ\x88\x9dp\x12\x90\xb01˜X\x02\xa6\x84\xa1\xdc\xc0<\xc5\xef\xfd$\xc7\x181#XlAB性ؙ\n0\x9dVE\xc1̚$\x8c\x92D\x8a'\xb0\xfb\x88W\x98~7:\x03k\xd1\x17a\xdd\x00\x84k\x8f \x94\x9b\xb0r@\xf5\r\xa6S0K0v@\xf1-\xa6wZ\xa9Z\xf4C\x00\x92T\xb2\x0e:\x9a\x06\xa4L+\a\xcau\xc4i\x87\x8e+ԯ/\x99\x02ّ\xa86[\x10\xa7\xae\xe8\xdb\x00\xf9d|\xa2!\xa2\x88\xbc\x8b\xe3\xce\xec\xdbbt\x8a?\xa6\xb96\xee1\x8f\xe4\xc1\xaa\rGq\xbc\xeb7\xb0\xd7=[az\xcfV\x87\xba\xed\x89o\xa8k\xd9\t$3\xa1\xc0\xecg*\x8aE\xc8\xcf+)mf\x00T\xacK\xaf\xe5nͲ\x99ղr\x10\x1fyŚ,ŢX$\xfb\xdc\a\xbb\\`J\x12Q,|\x13\xd4\xf9ռ:xp\xcc\x00C\\X6\x93\xc0\x91-A\xca,\x87\xecg\x8a\xe7LZ\xc0\xa7\xa9]SMI\xd2Bv)=_\x9b\xc0r\xa7IK2\x17\xcbƟ\x9d\xf0\xa8U\xf7XW\xddͬ\xe6\xe4eN}\x81s\x8e{\xf4\xb2\xedt\xc1\xd2^\xbfI\xfb\xafJ{s\xa6ce\xa0\xa2\x1f+\x97?\"\xa9Oi#\x9c\x80\xde\xe1\xfd\xa7n\xfd\x1e\x9f\xb9?\x90ix\x9c\x01n\xb2\xfe\xaa\xfdUo\xb2F>\xb8\\\xdb\x1d,\xd8s\xfa/\x88\xf6\xbb\x01\x87\xb6\xd8\x037J\xbd\x02r+ڙV\xcd\xed\xdf]5wZ=g+|\xa6\xc1\xa7\xe1\xea\xf1\xcd\xe5`\xfeCs7ܾ\x86\t\xa7\xfd;\xdd\x13\x16\xdcEm\xd0<I\xd2|\x85\xd2\xd1f\x03\x8ao\xb7\xbf\x02\x00\x00\xff\xff\xfd{\xba0\xad\x0e\x00\x00",
-		hash:  "a5d8ed9393d5aca2cccbb9ffbcb6c0d30f95a92103ed55283d162b742698aea2",
+		data:  "\x1f\x8b\b\x00\x00\x00\x00\x00\x02\xff\xecW]o\xd30\x14}N~\x851\xcf!\xdaכc\t\r!!1@+\x7f\xc0\x8bo\x1b\vǎl\xa7[U\xf5\xbf#;I\x9b\x86nti+*`/\xb9\xf2\xcd=w>\xe7\xdc\xd8].9L\x85\x02\x849s\x8c\xd7e\x85W\xab\x98Xȝ\xd0\n\t\x9e\x85\xc4\a\x9f@\xb9d\xd6f\xb8\x10\x1c0\x8d\x11B\x88p1\uf58d~\xc44\x8e\x86˹\x96u\xa9l\x97\n\xe9\xe2\x82~\aS\n\xc5$\xf2\xf0H\x94\x956\x8e\xa4\xc5\x05\x8d\xa3(\"\xb5\xec\xca\x1d{\xb08\xbc\x94\xf80\xfcG\xf0\xc4\xcaJBX\xc0\xa1 \"R\xf4+\x12'\x9c\x04$l\xc2r'\xe6\x80)a\xa800\xcd\xf0[\xbf\xc9\v\x8c\x98\x11,\xb1 !w\xc03\xecL\r\x98N\xea\xb2dfARFI*\xc5\v\xd8C\xc4KL\xbf\x19\x9d\x83\xb5賰n\x04\u0095G\x10\xcaݱjD\xf55\xa6\x130s0vD\xf1\r\xa6\xb7Z\xa9F\xf4m\x00\x92ֲ\tz\x9a\x06\xa4\\+\a\xca\xf5\xc4\xe9\x96v+4\xac\xaf\x98\x02ٓ\xa81[\x10\xa7\xa9\x18\xda\x00\xf9d\xb2\xa7!\xa2\x88\xbcI\x92\xde\xee\xbbb\xb4\x8f?&\x856\xee9\x8f\x14\xc1\xaa-GI\xb2\xee7\xb2\xd7={\xc4\xf4\x9e=n\xeb\xb6!\xbe\xa5\xaec'\x90̄\x02\xb3٩(g!?\xad\xa5\xb4\xb9\x01P\x89\xae\xbc\x96\xeb\x99e\x0fV\xcb\xdaA\xb2\xe3\x15k\xf2\f\x8br\x96nr\xef\xec|\x86)IE9\xf3MP\xef\xaf\xe1\xd5\xc1\x93c\x06\x18\xe2²\a\t\x1c\xd9\n\xa4\xcc\v\xc8\x7fdxʤ\x05\xbc\x9f\xda\rՔ\xa4\x1dd\x9f\xd2\xe3\xb5\t,\xf7\x9at$s1\xa7\xf10\xdci\xd5\r\xd6e\xffc\xd6pr\x98S\x0fp\xcen\x8f\x9e\xb7\x9d\xceXګ\xff\xd2\xfe\xad\xd2^\x1f\xe9X\x19\xa9\xe8\xfb\xda\x15\xcfH\xeaS\xda\b'`px\xff\xae۰\xc7'\xee\x0fd\x1a\x1eG\x80\xbb[|\xd1\xfe\xaaw\xb7@>8\f\xec#\xab\xa5\xb3\x986\xcf\xf3\xb5\xf0\xd6\xf0\x1f\xd3\xcb\xc1\x00\xbf\x9ayl\x8b\rp\xab\xfa\t\x90;\x03\x9c\x00\xba\xb3Ñ\x86\xfb\xe6\xcf\x0e\xf7\xadV\xaf\xf9b\xbfrt&\xe1\x86\xf4\xd5\x15`\xfe\xc1\xb9i\xb9=\x85\t'ë\xe7\v\x16\\G]\xd0>I\xda\xfeX\xa6\xf1r\t\x8a\xafV?\a\x00\x8f>\t\xd7T\x0f\x00\x00",
+		hash:  "c1a42cda4ad25b79bcf40b18823044b98ccc13297f9a254e2d992980f80f5496",
 		mime:  "text/html; charset=utf-8",
-		mtime: time.Unix(1479232354, 0),
-		size:  3757,
+		mtime: time.Unix(1792427452, 0),
+		size:  3924,
 	},
 	"index/index.html": {
 		data:  "\x1f\x8b\b\x00\x00\tn\x88\x02\xfft\x8fA\xaa\x021\f\x86\xd7\uf762v_O .\x04\xf7\x03z\x81\xd0d\xb4\xd0&\xa5͈C\xe9݅q!:\xcc6\xf9\xbe\xe4\xff[C\x1a\x03\x93\xb1\x81\x91\x9e\x03\xdc\xc8\xf6\xfe\xffךR\xca\x11\x94\x8c\xbd\x13 \x95e|\xd89gN\x82\xb3q\xee\xf8M->\xc3c\xa5G\xf1\x10\xaf\x92\xad\xd9\xff\xae\xb4\x00W\xf0\x1a\x84\xeb\x94\x12\x94ye#(\xe0\x94\xf2\xe7\xfd\x99q#B\xf5%d\xad\xab\x1b^X\x8b\xc4\x01\x98\xe2e\x83\x19E\xf4]\xb25b\xec\xfd\x15\x00\x00\xff\xff)\xb2x\xeb\x1a\x01\x00\x00",
@@ -508,6 +508,14 @@ func getAblock(hash string) *AblockHolder {
 			}
 			disp.Type = "Add Bitcoin Server Key"
 			disp.OtherInfo = "Identity ChainID: <a href='' id='factom-search-link' type='chainhead'>" + b.IdentityChainID.String() + "</a>"
+		case constants.TYPE_ADD_FACTOID_ADDRESS:
+			f := new(adminBlock.AddFactoidAddress)
+			err := f.UnmarshalBinary(data)
+			if err != nil {
+				continue
+			}
+			disp.Type = "Add Factoid Address"
+			disp.OtherInfo = "Identity ChainID: <a href='' id='factom-search-link' type='chainhead'>" + f.IdentityChainID.String() + "</a><br />Address: " + primitives.ConvertFctAddressToUserStr(factoid.NewAddress(f.FactoidAddress.Bytes()))
 		}
 		holder.ABDisplay = append(holder.ABDisplay, *disp)
 	}
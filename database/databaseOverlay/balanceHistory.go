@@ -0,0 +1,75 @@
+package databaseOverlay
+
+import (
+	"encoding/binary"
+	"sort"
+
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/common/primitives"
+)
+
+func heightKey(height uint32) []byte {
+	key := make([]byte, 4)
+	binary.BigEndian.PutUint32(key, height)
+	return key
+}
+
+// SaveBalanceHistory saves the balances changed by the block at height, a checkpoint of all
+// the balances after it if checkpoint isn't nil, and that the history is recorded up to height+1
+func (db *Overlay) SaveBalanceHistory(height uint32, changes []byte, checkpoint []byte) error {
+	key := heightKey(height)
+	batch := []interfaces.Record{}
+	batch = append(batch, interfaces.Record{Bucket: BALANCE_CHANGES, Key: key, Data: &primitives.ByteSlice{Bytes: changes}})
+	if checkpoint != nil {
+		batch = append(batch, interfaces.Record{Bucket: BALANCE_CHECKPOINTS, Key: key, Data: &primitives.ByteSlice{Bytes: checkpoint}})
+	}
+	batch = append(batch, interfaces.Record{Bucket: BALANCE_HISTORY_HEIGHT, Key: BALANCE_HISTORY_HEIGHT, Data: &primitives.ByteSlice{Bytes: heightKey(height + 1)}})
+
+	return db.DB.PutInBatch(batch)
+}
+
+// FetchBalanceHistoryHeight returns the heights of the balance checkpoints saved, in order,
+// and the height the history is recorded up to
+func (db *Overlay) FetchBalanceHistoryHeight() ([]uint32, uint32, error) {
+	value, err := db.DB.Get(BALANCE_HISTORY_HEIGHT, BALANCE_HISTORY_HEIGHT, new(primitives.ByteSlice))
+	if err != nil {
+		return nil, 0, err
+	}
+	if value == nil || len(value.(*primitives.ByteSlice).Bytes) != 4 {
+		return nil, 0, nil
+	}
+	height := binary.BigEndian.Uint32(value.(*primitives.ByteSlice).Bytes)
+
+	keys, err := db.ListAllKeys(BALANCE_CHECKPOINTS)
+	if err != nil {
+		return nil, 0, err
+	}
+	checkpoints := []uint32{}
+	for _, k := range keys {
+		if len(k) == 4 {
+			checkpoints = append(checkpoints, binary.BigEndian.Uint32(k))
+		}
+	}
+	sort.Slice(checkpoints, func(i, j int) bool { return checkpoints[i] < checkpoints[j] })
+	return checkpoints, height, nil
+}
+
+// FetchBalanceChanges returns the balances changed by the block at height, or nil if
+// they were not recorded
+func (db *Overlay) FetchBalanceChanges(height uint32) ([]byte, error) {
+	return db.fetchBalanceBytes(BALANCE_CHANGES, height)
+}
+
+// FetchBalanceCheckpoint returns the balances after the block at height, or nil if there
+// is no checkpoint at that height
+func (db *Overlay) FetchBalanceCheckpoint(height uint32) ([]byte, error) {
+	return db.fetchBalanceBytes(BALANCE_CHECKPOINTS, height)
+}
+
+func (db *Overlay) fetchBalanceBytes(bucket []byte, height uint32) ([]byte, error) {
+	value, err := db.DB.Get(bucket, heightKey(height), new(primitives.ByteSlice))
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return value.(*primitives.ByteSlice).Bytes, nil
+}
@@ -0,0 +1,52 @@
+package databaseOverlay
+
+import (
+	"encoding/binary"
+	"sort"
+
+	"github.com/FactomProject/factomd/common/entryBlock/specialEntries"
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/common/primitives"
+)
+
+// SaveFEREpoch saves a change of the Factoshis per EC rate, and that the history is
+// recorded up to height
+func (db *Overlay) SaveFEREpoch(epoch interfaces.IFEREpoch, height uint32) error {
+	batch := []interfaces.Record{}
+	if epoch != nil {
+		key := make([]byte, 4)
+		binary.BigEndian.PutUint32(key, epoch.GetActivationHeight())
+		batch = append(batch, interfaces.Record{Bucket: FER_HISTORY, Key: key, Data: epoch})
+	}
+	value := make([]byte, 4)
+	binary.BigEndian.PutUint32(value, height)
+	batch = append(batch, interfaces.Record{Bucket: FER_HISTORY_HEIGHT, Key: FER_HISTORY_HEIGHT, Data: &primitives.ByteSlice{Bytes: value}})
+
+	return db.DB.PutInBatch(batch)
+}
+
+// FetchAllFEREpochs returns the Factoshis per EC rate changes saved, in order of activation
+// height, and the height the history is recorded up to
+func (db *Overlay) FetchAllFEREpochs() ([]interfaces.IFEREpoch, uint32, error) {
+	value, err := db.DB.Get(FER_HISTORY_HEIGHT, FER_HISTORY_HEIGHT, new(primitives.ByteSlice))
+	if err != nil {
+		return nil, 0, err
+	}
+	if value == nil || len(value.(*primitives.ByteSlice).Bytes) != 4 {
+		return nil, 0, nil
+	}
+	height := binary.BigEndian.Uint32(value.(*primitives.ByteSlice).Bytes)
+
+	list, err := db.FetchAllBlocksFromBucket(FER_HISTORY, new(specialEntries.FEREpoch))
+	if err != nil {
+		return nil, 0, err
+	}
+	epochs := make([]interfaces.IFEREpoch, len(list))
+	for i, v := range list {
+		epochs[i] = v.(interfaces.IFEREpoch)
+	}
+	sort.Slice(epochs, func(i, j int) bool {
+		return epochs[i].GetActivationHeight() < epochs[j].GetActivationHeight()
+	})
+	return epochs, height, nil
+}
@@ -66,6 +66,15 @@ var (
 
 	//Which EC transaction paid for this Entry
 	PAID_FOR = []byte("PaidFor")
+
+	//Factoshis per EC rate changes, by activation height, and how far they have been recorded
+	FER_HISTORY        = []byte("FERHistory")
+	FER_HISTORY_HEIGHT = []byte("FERHistoryHeight")
+
+	//Balances changed by each block, periodic checkpoints of all balances, and how far they have been recorded
+	BALANCE_CHANGES        = []byte("BalanceChanges")
+	BALANCE_CHECKPOINTS    = []byte("BalanceCheckpoints")
+	BALANCE_HISTORY_HEIGHT = []byte("BalanceHistoryHeight")
 )
 
 var ConstantNamesMap map[string]string
@@ -105,6 +114,13 @@ func init() {
 
 	ConstantNamesMap[string(PAID_FOR)] = "PaidFor"
 
+	ConstantNamesMap[string(FER_HISTORY)] = "FERHistory"
+	ConstantNamesMap[string(FER_HISTORY_HEIGHT)] = "FERHistoryHeight"
+
+	ConstantNamesMap[string(BALANCE_CHANGES)] = "BalanceChanges"
+	ConstantNamesMap[string(BALANCE_CHECKPOINTS)] = "BalanceCheckpoints"
+	ConstantNamesMap[string(BALANCE_HISTORY_HEIGHT)] = "BalanceHistoryHeight"
+
 	RegisterPrometheus()
 }
 
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"sync"
 	"time"
 
 	"math"
@@ -108,6 +109,9 @@ func NetStart(s *state.State, p *FactomParams, listenToStdin bool) {
 	}
 
 	s.FaultTimeout = p.FaultTimeout
+	s.MempoolMaxSize = p.MempoolSize
+	s.MempoolMaxAge = p.MempoolAge
+	s.ShutdownTimeout = p.ShutdownTimeout
 
 	if p.Follower {
 		p.Leader = false
@@ -148,6 +152,19 @@ func NetStart(s *state.State, p *FactomParams, listenToStdin bool) {
 	if p.fastLocation != "" {
 		s.StateSaverStruct.FastBootLocation = p.fastLocation
 	}
+	if p.fastSync {
+		s.FastSync = true
+	}
+	if p.checkPoints != "" {
+		if len(s.CheckPointList) > 0 {
+			s.CheckPointList += ","
+		}
+		s.CheckPointList += p.checkPoints
+	}
+	if p.keystore != "" {
+		s.KeystoreOverride = "keystore:" + p.keystore
+		s.LocalServerSigner = s.KeystoreOverride
+	}
 
 	fmt.Println(">>>>>>>>>>>>>>>>")
 	fmt.Println(">>>>>>>>>>>>>>>> Net Sim Start!")
@@ -158,18 +175,36 @@ func NetStart(s *state.State, p *FactomParams, listenToStdin bool) {
 	AddInterruptHandler(func() {
 		fmt.Print("<Break>\n")
 		fmt.Print("Gracefully shutting down the server...\n")
-		for _, fnode := range fnodes {
-			fmt.Print("Shutting Down: ", fnode.State.FactomNodeName, "\r\n")
-			fnode.State.ShutdownChan <- 0
-		}
+		// Stop taking API requests and messages from the network, then let every node finish
+		// writing what it has
+		wsapi.Stop(fnodes[0].State)
 		if p.EnableNet {
 			p2pNetwork.NetworkStop()
 			// NODE_TALK_FIX
 			p2pProxy.stopProxy()
 		}
+		status := 0
+		var wg sync.WaitGroup
+		var statusMutex sync.Mutex
+		for _, fnode := range fnodes {
+			fmt.Print("Shutting Down: ", fnode.State.FactomNodeName, "\r\n")
+			wg.Add(1)
+			go func(s *state.State) {
+				defer wg.Done()
+				if err := s.Shutdown(); err != nil {
+					fmt.Print("Shutdown of ", s.FactomNodeName, " failed: ", err, "\r\n")
+					statusMutex.Lock()
+					status = 1
+					statusMutex.Unlock()
+				}
+			}(fnode.State)
+		}
 		fmt.Print("Waiting...\r\n")
-		time.Sleep(3 * time.Second)
-		os.Exit(0)
+		wg.Wait()
+		if status == 0 {
+			fmt.Print("Shut down cleanly\r\n")
+		}
+		os.Exit(status)
 	})
 
 	if p.Journal != "" {
@@ -235,6 +270,11 @@ func NetStart(s *state.State, p *FactomParams, listenToStdin bool) {
 	os.Stderr.WriteString(fmt.Sprintf("%20s \"%t\"\n", "exclusive", p.Exclusive))
 	os.Stderr.WriteString(fmt.Sprintf("%20s %d\n", "block time", p.BlkTime))
 	os.Stderr.WriteString(fmt.Sprintf("%20s %d\n", "faultTimeout", p.FaultTimeout))
+	os.Stderr.WriteString(fmt.Sprintf("%20s %d\n", "mempoolSize", p.MempoolSize))
+	os.Stderr.WriteString(fmt.Sprintf("%20s %d\n", "mempoolAge", p.MempoolAge))
+	os.Stderr.WriteString(fmt.Sprintf("%20s %d\n", "shutdownTimeout", p.ShutdownTimeout))
+	os.Stderr.WriteString(fmt.Sprintf("%20s %v\n", "fastSync", s.FastSync))
+	os.Stderr.WriteString(fmt.Sprintf("%20s %v\n", "serverSigner", s.LocalServerSigner))
 	os.Stderr.WriteString(fmt.Sprintf("%20s %v\n", "runtimeLog", p.RuntimeLog))
 	os.Stderr.WriteString(fmt.Sprintf("%20s %v\n", "rotate", p.rotate))
 	os.Stderr.WriteString(fmt.Sprintf("%20s %v\n", "timeOffset", p.timeOffset))
@@ -325,6 +365,9 @@ func NetStart(s *state.State, p *FactomParams, listenToStdin bool) {
 			SeedURL:                  seedURL,
 			SpecialPeers:             specialPeers,
 			ConnectionMetricsChannel: connectionMetricsChannel,
+			ExternalAddress:          s.ExternalAddress,
+			NATTraversal:             s.NATTraversal,
+			NATGateway:               s.NATGateway,
 		}
 		p2pNetwork = new(p2p.Controller).Init(ci)
 		fnodes[0].State.NetworkControler = p2pNetwork
@@ -532,6 +575,7 @@ func startServers(load bool) {
 			go state.LoadDatabase(fnode.State)
 		}
 		go fnode.State.GoSyncEntries()
+		go fnode.State.GoBackfillFERHistory()
 		go Timer(fnode.State)
 		go fnode.State.ValidatorLoop()
 	}
@@ -29,6 +29,9 @@ type FactomParams struct {
 	LogPort                  string
 	BlkTime                  int
 	FaultTimeout             int
+	MempoolSize              int
+	MempoolAge               int
+	ShutdownTimeout          int
 	RuntimeLog               bool
 	Netdebug                 int
 	Exclusive                bool
@@ -46,6 +49,9 @@ type FactomParams struct {
 	memProfileRate           int
 	fast                     bool
 	fastLocation             string
+	fastSync                 bool
+	checkPoints              string
+	keystore                 string
 	loglvl                   string
 	logjson                  bool
 	svm                      bool
@@ -76,6 +82,9 @@ func ParseCmdLine(args []string) *FactomParams {
 	peersPtr := flag.String("peers", "", "Array of peer addresses. ")
 	blkTimePtr := flag.Int("blktime", 0, "Seconds per block.  Production is 600.")
 	faultTimeoutPtr := flag.Int("faulttimeout", 60, "Seconds before considering Federated servers at-fault. Default is 60.")
+	mempoolSizePtr := flag.Int("mempoolsize", 20000, "Most commits, reveals and factoid transactions waiting in holding before the oldest are evicted. 0 for no limit.")
+	mempoolAgePtr := flag.Int("mempoolage", 3600, "Seconds commits, reveals and factoid transactions may wait in holding before they are evicted. 0 for no limit.")
+	shutdownTimeoutPtr := flag.Int("shutdowntimeout", 30, "Seconds to finish writing blocks and the fast boot state when shutting down, before exiting with an error.")
 	runtimeLogPtr := flag.Bool("runtimeLog", false, "If true, maintain runtime logs of messages passed.")
 	netdebugPtr := flag.Int("netdebug", 0, "0-5: 0 = quiet, >0 = increasing levels of logging")
 	exclusivePtr := flag.Bool("exclusive", false, "If true, we only dial out to special/trusted peers.")
@@ -99,6 +108,9 @@ func ParseCmdLine(args []string) *FactomParams {
 
 	fastPtr := flag.Bool("fast", true, "If true, factomd will fast-boot from a file.")
 	fastLocationPtr := flag.String("fastlocation", "", "Directory to put the fast-boot file in.")
+	fastSyncPtr := flag.Bool("fastsync", false, "If true, skip signature and transaction validation of blocks up to the highest checkpoint.")
+	checkPointsPtr := flag.String("checkpoints", "", "Extra trusted directory block KeyMRs, as height:keymr,height:keymr")
+	keystorePtr := flag.String("keystore", "", "Keystore file holding the server identity key, in place of LocalServerPrivKey.  Manage it with factomd keystore.")
 
 	logLvlPtr := flag.String("loglvl", "none", "Set log level to either: none, debug, info, warning, error, fatal or panic")
 	logJsonPtr := flag.Bool("logjson", false, "Use to set logging to use a json formatting")
@@ -138,6 +150,9 @@ func ParseCmdLine(args []string) *FactomParams {
 	p.LogPort = *logportPtr
 	p.BlkTime = *blkTimePtr
 	p.FaultTimeout = *faultTimeoutPtr
+	p.MempoolSize = *mempoolSizePtr
+	p.MempoolAge = *mempoolAgePtr
+	p.ShutdownTimeout = *shutdownTimeoutPtr
 	p.RuntimeLog = *runtimeLogPtr
 	p.Netdebug = *netdebugPtr
 	p.Exclusive = *exclusivePtr
@@ -155,6 +170,9 @@ func ParseCmdLine(args []string) *FactomParams {
 	p.memProfileRate = *memProfileRate
 	p.fast = *fastPtr
 	p.fastLocation = *fastLocationPtr
+	p.fastSync = *fastSyncPtr
+	p.checkPoints = *checkPointsPtr
+	p.keystore = *keystorePtr
 	p.loglvl = *logLvlPtr
 	p.logjson = *logJsonPtr
 	p.disableSimControl = *disableSimControlPtr
@@ -6,14 +6,13 @@ package engine
 
 import (
 	"bufio"
-	"encoding/hex"
 	"fmt"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/FactomProject/factomd/common/interfaces"
-	"github.com/FactomProject/factomd/common/messages"
+	"github.com/FactomProject/factomd/state"
 )
 
 func LoadJournal(s interfaces.IState, journal string) {
@@ -53,33 +52,16 @@ func LoadJournalFromReader(s interfaces.IState, r *bufio.Reader) {
 			break
 		}
 
-		// Get the next word.  If not MsgHex:, then go to next line.
-		adv, word, err := bufio.ScanWords(line, true)
-		if string(word) != "MsgHex:" {
-			continue // Go to next line.
-		}
-		line = line[adv:] // Remove "MsgHex:" from the line.
-
-		// Remove spaces.
-		adv, data, err := bufio.ScanWords(line, true)
+		// Lines written by JournalMessage, or older "MsgHex:" lines.  Skip anything else.
+		entry, err := state.ParseJournalLine(line)
 		if err != nil {
 			fmt.Println(err)
 			return
 		}
-
-		// Decode the hex
-		binary, err := hex.DecodeString(string(data))
-		if err != nil {
-			fmt.Println(err)
-			return
-		}
-
-		// Unmarshal the message.
-		msg, err := messages.UnmarshalMessage(binary)
-		if err != nil {
-			fmt.Println(err)
-			return
+		if entry == nil {
+			continue
 		}
+		msg := entry.Msg
 
 		// Process the message.
 		s.InMsgQueue().Enqueue(msg)
@@ -15,23 +15,24 @@ import (
 var _ = (*s.State)(nil)
 
 func Timer(state interfaces.IState) {
-	time.Sleep(2 * time.Second)
+	clock := state.GetClock()
+	clock.Sleep(2 * time.Second)
 
 	billion := int64(1000000000)
 	period := int64(state.GetDirectoryBlockInSeconds()) * billion
 	tenthPeriod := period / 10
 
-	now := time.Now().UnixNano() // Time in billionths of a second
+	now := clock.Now().UnixNano() // Time in billionths of a second
 
 	wait := tenthPeriod - (now % tenthPeriod)
 
 	next := now + wait + tenthPeriod
 
 	if state.GetOut() {
-		state.Print(fmt.Sprintf("Time: %v\r\n", time.Now()))
+		state.Print(fmt.Sprintf("Time: %v\r\n", clock.Now()))
 	}
 
-	time.Sleep(time.Duration(wait))
+	clock.Sleep(time.Duration(wait))
 
 	for {
 		for i := 0; i < 10; i++ {
@@ -41,7 +42,7 @@ func Timer(state interfaces.IState) {
 				time.Sleep(time.Millisecond * 10)
 			}
 
-			now = time.Now().UnixNano()
+			now = clock.Now().UnixNano()
 			if now > next {
 				wait = 1
 				for next < now {
@@ -52,13 +53,13 @@ func Timer(state interfaces.IState) {
 				wait = next - now
 				next += tenthPeriod
 			}
-			time.Sleep(time.Duration(wait))
+			clock.Sleep(time.Duration(wait))
 			for state.InMsgQueue().Length() > 5000 {
 				time.Sleep(100 * time.Millisecond)
 			}
 
 			// Delay some number of milliseconds.
-			time.Sleep(time.Duration(state.GetTimeOffset().GetTimeMilli()) * time.Millisecond)
+			clock.Sleep(time.Duration(state.GetTimeOffset().GetTimeMilli()) * time.Millisecond)
 
 			state.TickerQueue() <- i
 
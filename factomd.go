@@ -9,10 +9,19 @@ import (
 
 	"fmt"
 	"github.com/FactomProject/factomd/engine"
+	"github.com/FactomProject/factomd/signer"
 	"time"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "keystore" {
+		if err := signer.KeystoreCommand(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// uncomment StartProfiler() to run the pprof tool (for testing)
 	params := engine.ParseCmdLine(os.Args[1:])
 	state := engine.Factomd(params, true)
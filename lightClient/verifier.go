@@ -0,0 +1,224 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package lightClient verifies directory blocks without running a node.  Starting from
+// genesis, a Verifier is fed each directory block with its admin block.  It follows the
+// authority set changes in the admin blocks and checks that a majority of the federated
+// servers signed every directory block header, keeping only the headers.  Those headers
+// can then vouch for other headers and for entry receipts.
+package lightClient
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/FactomProject/factomd/common/adminBlock"
+	"github.com/FactomProject/factomd/common/constants"
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/common/messages"
+	"github.com/FactomProject/factomd/common/primitives"
+	"github.com/FactomProject/factomd/receipts"
+)
+
+type Verifier struct {
+	mutex       sync.Mutex
+	authorities *adminBlock.AuthorityHistory
+	headers     []interfaces.IDirectoryBlockHeader
+	keyMRs      []interfaces.IHash
+	heights     map[[32]byte]uint32 // KeyMR to height
+	fullHash    interfaces.IHash    // of the last block added
+}
+
+// NewVerifier returns a Verifier for the network with this bootstrap identity and key (see
+// State.GetNetworkBootStrapIdentity() and State.GetNetworkBootStrapKey())
+func NewVerifier(bootstrapIdentity interfaces.IHash, bootstrapKey interfaces.IHash) (*Verifier, error) {
+	key := new(primitives.PublicKey)
+	if err := key.UnmarshalBinary(bootstrapKey.Bytes()); err != nil {
+		return nil, err
+	}
+	v := new(Verifier)
+	v.authorities = adminBlock.NewAuthorityHistory()
+	v.authorities.AddBootstrapServer(bootstrapIdentity, key)
+	v.heights = make(map[[32]byte]uint32)
+	return v, nil
+}
+
+// HeaderKeyMR computes the KeyMR of a directory block from its header alone
+func HeaderKeyMR(header interfaces.IDirectoryBlockHeader) (interfaces.IHash, error) {
+	data, err := header.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return primitives.HashMerkleBranches(primitives.Sha(data), header.GetBodyMR()), nil
+}
+
+// Height returns the number of blocks added, which is the height of the next one expected
+func (v *Verifier) Height() uint32 {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return uint32(len(v.headers))
+}
+
+// VerifiedHeight returns the highest verified height.  The signatures for a block are in the
+// next admin block, so the last block added is not verified yet.  False if nothing is verified.
+func (v *Verifier) VerifiedHeight() (uint32, bool) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	if len(v.headers) < 2 {
+		return 0, false
+	}
+	return uint32(len(v.headers) - 2), true
+}
+
+// AddBlock checks the next directory block and its admin block, and the signatures the admin block
+// holds for the previous directory block.  Nothing changes if it returns an error.
+func (v *Verifier) AddBlock(dblock interfaces.IDirectoryBlock, ablock interfaces.IAdminBlock) error {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if dblock == nil || ablock == nil {
+		return fmt.Errorf("A directory block and its admin block are needed")
+	}
+	dbheight := dblock.GetDatabaseHeight()
+	if dbheight != uint32(len(v.headers)) {
+		return fmt.Errorf("Expected directory block %d, got %d", len(v.headers), dbheight)
+	}
+	if ablock.GetDBHeight() != dbheight {
+		return fmt.Errorf("Directory block %d came with admin block %d", dbheight, ablock.GetDBHeight())
+	}
+
+	// Building the KeyMR makes the header agree with the body
+	keyMR, err := dblock.BuildKeyMerkleRoot()
+	if err != nil {
+		return err
+	}
+	header := dblock.GetHeader()
+	if dbheight > 0 {
+		if !header.GetPrevKeyMR().IsSameAs(v.keyMRs[dbheight-1]) {
+			return fmt.Errorf("Directory block %d does not follow the KeyMR of block %d", dbheight, dbheight-1)
+		}
+		if !header.GetPrevFullHash().IsSameAs(v.fullHash) {
+			return fmt.Errorf("Directory block %d does not follow the full hash of block %d", dbheight, dbheight-1)
+		}
+	}
+
+	adminChain := primitives.NewHash(constants.ADMIN_CHAINID)
+	found := false
+	for _, entry := range dblock.GetDBEntries() {
+		if entry.GetChainID().IsSameAs(adminChain) {
+			found = entry.GetKeyMR().IsSameAs(ablock.DatabasePrimaryIndex())
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("Admin block %d is not the one in the directory block", dbheight)
+	}
+
+	if dbheight > 0 {
+		if err := v.checkSignatures(v.headers[dbheight-1], ablock); err != nil {
+			return err
+		}
+	}
+	if err := v.authorities.AddAdminBlock(ablock); err != nil {
+		return err
+	}
+
+	v.headers = append(v.headers, header)
+	v.keyMRs = append(v.keyMRs, keyMR)
+	v.heights[keyMR.Fixed()] = dbheight
+	v.fullHash = dblock.GetFullHash()
+	return nil
+}
+
+// checkSignatures makes sure a majority of the federated servers signed the header with the
+// DBSignatures in the admin block that follows it.  Only the servers in force at the header's
+// height count.  The header's own admin block can't change who that is, as its changes take
+// effect at the next block at the earliest (see AuthorityHistory), so a forged block can't
+// vouch for itself by swapping in its own servers.
+func (v *Verifier) checkSignatures(header interfaces.IDirectoryBlockHeader, ablock interfaces.IAdminBlock) error {
+	dbheight := header.GetDBHeight()
+	data, err := header.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	current := v.authorities.AuthoritySetAt(dbheight)
+	signers := map[string]bool{}
+	for _, server := range current.FederatedServers {
+		if server.SigningKey != nil {
+			signers[string(server.SigningKey[:])] = true
+		}
+	}
+
+	var sigs messages.SigList
+	signed := map[string]bool{}
+	for _, entry := range ablock.GetABEntries() {
+		e, ok := entry.(*adminBlock.DBSignatureEntry)
+		if !ok {
+			continue
+		}
+		sig := e.PrevDBSig
+		sigs.List = append(sigs.List, &sig)
+		key := string(sig.GetKey())
+		if signed[key] || !signers[key] || !sig.Verify(data) {
+			continue
+		}
+		signed[key] = true
+	}
+	sigs.Length = uint32(len(sigs.List))
+
+	tally := len(signed) + messages.CheckpointSignatures(dbheight, sigs)
+	needed := len(current.FederatedServers)/2 + 1
+	if tally < needed {
+		return fmt.Errorf("Directory block %d has %d valid signatures, %d are needed", dbheight, tally, needed)
+	}
+	return nil
+}
+
+// GetHeader returns the verified header at dbheight
+func (v *Verifier) GetHeader(dbheight uint32) (interfaces.IDirectoryBlockHeader, error) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	if int(dbheight)+1 >= len(v.headers) {
+		return nil, fmt.Errorf("Directory block %d has not been verified", dbheight)
+	}
+	return v.headers[dbheight], nil
+}
+
+// VerifyHeader checks a directory block header against the verified one at its height
+func (v *Verifier) VerifyHeader(header interfaces.IDirectoryBlockHeader) error {
+	keyMR, err := HeaderKeyMR(header)
+	if err != nil {
+		return err
+	}
+	_, err = v.VerifyKeyMR(keyMR)
+	return err
+}
+
+// VerifyKeyMR returns the height of the verified directory block with this KeyMR
+func (v *Verifier) VerifyKeyMR(keyMR interfaces.IHash) (uint32, error) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	dbheight, ok := v.heights[keyMR.Fixed()]
+	if !ok || int(dbheight)+1 >= len(v.headers) {
+		return 0, fmt.Errorf("Directory block %s has not been verified", keyMR.String())
+	}
+	return dbheight, nil
+}
+
+// VerifyReceipt checks the receipt's merkle path, and that it ends in a verified directory
+// block.  Returns the height of that block.
+func (v *Verifier) VerifyReceipt(receipt *receipts.Receipt) (uint32, error) {
+	if err := receipt.Validate(); err != nil {
+		return 0, err
+	}
+	return v.VerifyKeyMR(receipt.DirectoryBlockKeyMR)
+}
+
+// AuthoritiesAt returns the authority set at dbheight, as far as the blocks added so far tell
+func (v *Verifier) AuthoritiesAt(dbheight uint32) *adminBlock.AuthoritySet {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.authorities.AuthoritySetAt(dbheight)
+}
@@ -0,0 +1,204 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package lightClient_test
+
+import (
+	"testing"
+
+	"github.com/FactomProject/factomd/common/adminBlock"
+	"github.com/FactomProject/factomd/common/directoryBlock"
+	"github.com/FactomProject/factomd/common/entryBlock"
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/common/primitives"
+	"github.com/FactomProject/factomd/database/databaseOverlay"
+	. "github.com/FactomProject/factomd/lightClient"
+	"github.com/FactomProject/factomd/receipts"
+	"github.com/FactomProject/factomd/testHelper"
+)
+
+type testChain struct {
+	dblocks []interfaces.IDirectoryBlock
+	ablocks []interfaces.IAdminBlock
+	entries [][]*entryBlock.Entry
+	dbo     *databaseOverlay.Overlay
+	ids     []interfaces.IHash
+	keys    []*primitives.PrivateKey
+}
+
+// newTestChain builds n blocks, with server identities and keys that depend on seed.  Server 0 is
+// the bootstrap server, server 1 is promoted in admin block 2 so it is federated from height 3.
+// Every header is signed in the next admin block by the federated servers, unless skip says otherwise.
+// extra, if set, can add more entries to each admin block.
+func newTestChain(n int, seed uint64, skip func(dbheight uint32, server int) bool, extra func(c *testChain, a interfaces.IAdminBlock)) *testChain {
+	c := new(testChain)
+	c.dbo = testHelper.CreateEmptyTestDatabaseOverlay()
+	for i := 0; i < 2; i++ {
+		c.ids = append(c.ids, primitives.Sha([]byte{byte(seed), byte(i)}))
+		c.keys = append(c.keys, testHelper.NewPrimitivesPrivateKey(seed+uint64(i)))
+	}
+
+	var prevD interfaces.IDirectoryBlock
+	var prevA interfaces.IAdminBlock
+	var prevE interfaces.IEntryBlock
+	for h := 0; h < n; h++ {
+		a := adminBlock.NewAdminBlock(prevA)
+		if prevD != nil {
+			header, _ := prevD.GetHeader().MarshalBinary()
+			for s := range c.ids {
+				signed := uint32(h - 1)
+				if (s == 1 && signed < 3) || (skip != nil && skip(signed, s)) {
+					continue
+				}
+				a.AddDBSig(c.ids[s], c.keys[s].Sign(header))
+			}
+		}
+		if h == 2 {
+			a.AddFedServer(c.ids[1])
+			a.AddFederatedServerSigningKey(c.ids[1], *c.keys[1].Pub)
+		}
+		if extra != nil {
+			extra(c, a)
+		}
+
+		e, entries := testHelper.CreateTestEntryBlock(prevE)
+		d := directoryBlock.NewDirectoryBlock(prevD)
+		d.SetABlockHash(a)
+		d.AddEntry(e.GetChainID(), e.DatabasePrimaryIndex())
+		d.BuildKeyMerkleRoot()
+
+		c.dbo.StartMultiBatch()
+		c.dbo.ProcessABlockMultiBatch(a)
+		c.dbo.ProcessEBlockMultiBatch(e, true)
+		for _, entry := range entries {
+			c.dbo.InsertEntryMultiBatch(entry)
+		}
+		c.dbo.ProcessDBlockMultiBatch(d)
+		if err := c.dbo.ExecuteMultiBatch(); err != nil {
+			panic(err)
+		}
+
+		c.dblocks = append(c.dblocks, d)
+		c.ablocks = append(c.ablocks, a)
+		c.entries = append(c.entries, entries)
+		prevD, prevA, prevE = d, a, e
+	}
+	return c
+}
+
+func (c *testChain) verifier(t *testing.T) *Verifier {
+	v, err := NewVerifier(c.ids[0], primitives.NewHash(c.keys[0].Pub[:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestVerifier(t *testing.T) {
+	c := newTestChain(6, 0, nil, nil)
+	v := c.verifier(t)
+
+	if err := v.AddBlock(c.dblocks[1], c.ablocks[1]); err == nil {
+		t.Errorf("AddBlock() should start from genesis")
+	}
+	if err := v.AddBlock(c.dblocks[0], c.ablocks[1]); err == nil {
+		t.Errorf("AddBlock() should refuse the wrong admin block")
+	}
+	if _, ok := v.VerifiedHeight(); ok {
+		t.Errorf("Nothing should be verified yet")
+	}
+	for i := range c.dblocks {
+		if err := v.AddBlock(c.dblocks[i], c.ablocks[i]); err != nil {
+			t.Fatalf("AddBlock(%d) got %v", i, err)
+		}
+	}
+	if h, ok := v.VerifiedHeight(); !ok || h != 4 || v.Height() != 6 {
+		t.Errorf("VerifiedHeight() got %d %v, Height() got %d", h, ok, v.Height())
+	}
+	if set := v.AuthoritiesAt(3); len(set.FederatedServers) != 2 || !set.IsFederatedKey(c.keys[1].Pub[:]) {
+		t.Errorf("AuthoritiesAt(3) got %+v", set)
+	}
+
+	for i, d := range c.dblocks {
+		keyMR, err := HeaderKeyMR(d.GetHeader())
+		if err != nil || !keyMR.IsSameAs(d.GetKeyMR()) {
+			t.Errorf("HeaderKeyMR(%d) got %v %v, expected %v", i, keyMR, err, d.GetKeyMR())
+		}
+	}
+	if err := v.VerifyHeader(c.dblocks[3].GetHeader()); err != nil {
+		t.Errorf("VerifyHeader(3) got %v", err)
+	}
+	if err := v.VerifyHeader(c.dblocks[5].GetHeader()); err == nil {
+		t.Errorf("Header 5 is not signed yet")
+	}
+	other := newTestChain(2, 10, nil, nil)
+	if err := v.VerifyHeader(other.dblocks[1].GetHeader()); err == nil {
+		t.Errorf("VerifyHeader() accepted a header from another chain")
+	}
+
+	receipt, err := receipts.CreateFullReceipt(c.dbo, c.entries[4][0].GetHash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h, err := v.VerifyReceipt(receipt); err != nil || h != 4 {
+		t.Errorf("VerifyReceipt() got %d %v", h, err)
+	}
+	receipt, _ = receipts.CreateFullReceipt(c.dbo, c.entries[5][0].GetHash())
+	if _, err := v.VerifyReceipt(receipt); err == nil {
+		t.Errorf("VerifyReceipt() accepted an entry in a block that isn't signed yet")
+	}
+}
+
+func TestVerifierSignatures(t *testing.T) {
+	// Once there are two federated servers, both must sign
+	c := newTestChain(6, 0, func(dbheight uint32, server int) bool { return dbheight == 4 && server == 1 }, nil)
+	v := c.verifier(t)
+	for i := 0; i < 5; i++ {
+		if err := v.AddBlock(c.dblocks[i], c.ablocks[i]); err != nil {
+			t.Fatalf("AddBlock(%d) got %v", i, err)
+		}
+	}
+	if err := v.AddBlock(c.dblocks[5], c.ablocks[5]); err == nil {
+		t.Errorf("AddBlock() accepted a block with too few signatures")
+	}
+	if v.Height() != 5 {
+		t.Errorf("Height() got %d after a failed AddBlock()", v.Height())
+	}
+
+	// A block from a chain signed by someone else
+	other := newTestChain(2, 10, nil, nil)
+	v = c.verifier(t)
+	v.AddBlock(c.dblocks[0], c.ablocks[0])
+	if err := v.AddBlock(other.dblocks[1], other.ablocks[1]); err == nil {
+		t.Errorf("AddBlock() accepted a block from another chain")
+	}
+}
+
+func TestVerifierForgedAuthorities(t *testing.T) {
+	// Admin block 3 swaps the servers for the attacker's, and only the attacker signs header 3
+	attacker := primitives.Sha([]byte("attacker"))
+	attackerKey := testHelper.NewPrimitivesPrivateKey(99)
+	forge := func(c *testChain, a interfaces.IAdminBlock) {
+		switch a.GetDBHeight() {
+		case 3:
+			a.RemoveFederatedServer(c.ids[0])
+			a.RemoveFederatedServer(c.ids[1])
+			a.AddFedServer(attacker)
+			a.AddFederatedServerSigningKey(attacker, *attackerKey.Pub)
+		case 4:
+			header, _ := c.dblocks[3].GetHeader().MarshalBinary()
+			a.AddDBSig(attacker, attackerKey.Sign(header))
+		}
+	}
+	c := newTestChain(5, 0, func(dbheight uint32, server int) bool { return dbheight == 3 }, forge)
+	v := c.verifier(t)
+	for i := 0; i < 4; i++ {
+		if err := v.AddBlock(c.dblocks[i], c.ablocks[i]); err != nil {
+			t.Fatalf("AddBlock(%d) got %v", i, err)
+		}
+	}
+	if err := v.AddBlock(c.dblocks[4], c.ablocks[4]); err == nil {
+		t.Errorf("AddBlock() accepted a block signed only by the servers it promoted itself")
+	}
+}
@@ -47,6 +47,8 @@ type Connection struct {
 	isPersistent    bool              // Persistent connections we always redail.
 	notes           string            // Notes about the connection, for debugging (eg: error)
 	metrics         ConnectionMetrics // Metrics about this connection
+	nodeID          uint64            // NodeID of our node, for loopback protection
+	transport       Transport         // How we dial the peer
 	Logger          *log.Entry
 }
 
@@ -105,8 +107,10 @@ type ConnectionMetrics struct {
 	// Red: Below -50
 	// Yellow: -50 - 100
 	// Green: > 100
-	ConnectionState string // Basic state of the connection
-	ConnectionNotes string // Connectivity notes for the connection
+	ConnectionState string        // Basic state of the connection
+	ConnectionNotes string        // Connectivity notes for the connection
+	PingLatency     time.Duration // Round trip time of our last ping
+	LastMessage     time.Time     // When we last got a valid parcel from the peer
 }
 
 // ConnectionCommand is used to instruct the Connection to carry out some functionality.
@@ -165,6 +169,14 @@ func (c *Connection) Init(peer Peer, persistent bool) *Connection {
 	return c
 }
 
+// setNode ties the connection to the node (Controller) that owns it, needed when there is more
+// than one node in the process (eg: simulations.)  Must be called before Start().
+func (c *Connection) setNode(nodeID uint64, transport Transport) *Connection {
+	c.nodeID = nodeID
+	c.transport = transport
+	return c
+}
+
 func (c *Connection) IsOutGoing() bool {
 	return c.isOutGoing
 }
@@ -204,6 +216,8 @@ func (c *Connection) commonInit(peer Peer) {
 	c.timeLastMetrics = time.Now()
 	c.timeLastAttempt = time.Now()
 	c.timeLastStatus = time.Now()
+	c.nodeID = NodeID
+	c.transport = TCPTransport{}
 
 	c.Logger = conLogger.WithField("peer", c.peer.PeerFixedIdent())
 }
@@ -322,7 +336,7 @@ func (c *Connection) dialLoop() {
 func (c *Connection) dial() bool {
 	address := c.peer.AddressPort()
 	// conn, err := net.Dial("tcp", c.peer.Address)
-	conn, err := net.DialTimeout("tcp", address, time.Second*10)
+	conn, err := c.transport.Dial(address, time.Second*10)
 	if nil == err {
 		c.conn = conn
 		return true
@@ -351,7 +365,14 @@ func (c *Connection) goOnline() {
 	// Now ask the other side for the peers they know about.
 	parcel := NewParcel(CurrentNetwork, []byte("Peer Request"))
 	parcel.Header.Type = TypePeerRequest
+	if !c.isOutGoing { // Tell them where we see them connecting from (see Discovery.observeAddress())
+		parcel.Header.ObservedAddress = c.peer.Address
+	}
 	BlockFreeChannelSend(c.SendChannel, ConnectionParcel{Parcel: *parcel})
+	// And ping them so we know the latency before the connection goes idle.
+	ping := NewParcel(CurrentNetwork, []byte("Ping"))
+	ping.Header.Type = TypePing
+	BlockFreeChannelSend(c.SendChannel, ConnectionParcel{Parcel: *ping})
 }
 
 func (c *Connection) goOffline() {
@@ -447,7 +468,7 @@ func (c *Connection) handleCommand() {
 
 func (c *Connection) sendParcel(parcel Parcel) {
 
-	parcel.Header.NodeID = NodeID // Send it out with our ID for loopback.
+	parcel.Header.NodeID = c.nodeID // Send it out with our ID for loopback.
 	c.conn.SetWriteDeadline(time.Now().Add(NetworkDeadline * 500))
 
 	//deadline := time.Now().Add(NetworkDeadline)
@@ -564,6 +585,7 @@ func (c *Connection) handleParcel(parcel Parcel) {
 		c.peer.LastContact = time.Now() // We only update for valid messages (incluidng pings and heartbeats)
 		c.attempts = 0                  // reset since we are clearly in touch now.
 		c.peer.merit()                  // Increase peer quality score.
+		c.metrics.LastMessage = c.peer.LastContact
 		debug(c.peer.PeerIdent(), "Connection.handleParcel() got ParcelValid %s", parcel.MessageType())
 		if Notes <= CurrentLoggingLevel {
 			parcel.PrintMessageType()
@@ -588,7 +610,7 @@ func (c *Connection) parcelValidity(parcel Parcel) uint8 {
 	verbose(c.peer.PeerIdent(), "Connection.isValidParcel(%s)", parcel.MessageType())
 	crc := crc32.Checksum(parcel.Payload, CRCKoopmanTable)
 	switch {
-	case parcel.Header.NodeID == NodeID: // We are talking to ourselves!
+	case parcel.Header.NodeID == c.nodeID: // We are talking to ourselves!
 		parcel.Trace("Connection.isValidParcel()-loopback", "H")
 		c.setNotes(fmt.Sprintf("Connection.isValidParcel(), failed due to loopback!: %+v", parcel.Header))
 		c.peer.QualityScore = MinumumQualityScore - 50 // Ban ourselves for a week
@@ -624,7 +646,7 @@ func (c *Connection) handleParcelTypes(parcel Parcel) {
 		pong.Header.Type = TypePong
 		BlockFreeChannelSend(c.SendChannel, ConnectionParcel{Parcel: *pong})
 	case TypePong: // all we need is the timestamp which is set already
-		return
+		c.metrics.PingLatency = time.Since(c.timeLastPing)
 	case TypePeerRequest:
 		BlockFreeChannelSend(c.ReceiveChannel, ConnectionParcel{Parcel: parcel}) // Controller handles these.
 	case TypePeerResponse:
@@ -633,13 +655,17 @@ func (c *Connection) handleParcelTypes(parcel Parcel) {
 		c.peer.QualityScore = c.peer.QualityScore + 1
 		// Store our connection ID so the controller can direct response to us.
 		parcel.Header.TargetPeer = c.peer.Hash
-		parcel.Header.NodeID = NodeID
+		parcel.Header.NodeID = c.nodeID
+		BlockFreeChannelSend(c.ReceiveChannel, ConnectionParcel{Parcel: parcel}) // Controller handles these.
+	case TypeInventory:
+		BlockFreeChannelSend(c.ReceiveChannel, ConnectionParcel{Parcel: parcel}) // Controller handles these.
+	case TypeInventoryRequest:
 		BlockFreeChannelSend(c.ReceiveChannel, ConnectionParcel{Parcel: parcel}) // Controller handles these.
 	case TypeMessagePart:
 		c.peer.QualityScore = c.peer.QualityScore + 1
 		// Store our connection ID so the controller can direct response to us.
 		parcel.Header.TargetPeer = c.peer.Hash
-		parcel.Header.NodeID = NodeID
+		parcel.Header.NodeID = c.nodeID
 		BlockFreeChannelSend(c.ReceiveChannel, ConnectionParcel{Parcel: parcel}) // Controller handles these.
 	default:
 		significant(c.peer.PeerIdent(), "!!!!!!!!!!!!!!!!!! Got message of unknown type?")
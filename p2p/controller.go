@@ -11,7 +11,6 @@ package p2p
 // Other than Init and NetworkStart, all administration is done via the channel.
 
 import (
-	"fmt"
 	"math/rand"
 	"net"
 	"strings"
@@ -55,9 +54,18 @@ type Controller struct {
 	lastDiscoveryRequest       time.Time
 	NodeID                     uint64
 	lastStatusReport           time.Time
-	lastPeerRequest            time.Time       // Last time we asked peers about the peers they know about.
-	specialPeersString         string          // configuration set special peers
-	partsAssembler             *PartsAssembler // a data structure that assembles full messages from received message parts
+	lastPeerRequest            time.Time            // Last time we asked peers about the peers they know about.
+	specialPeersString         string               // configuration set special peers
+	partsAssembler             *PartsAssembler      // a data structure that assembles full messages from received message parts
+	inventory                  *Inventory           // messages we announce and serve to peers instead of flooding them (see inventory.go)
+	lastInventoryCleanup       time.Time            // Last time we dropped expired inventory.
+	protocolVersions           map[string]uint16    // protocol version of each peer, indexed by peer hash, as seen on their parcels
+	natTraversal               string               // NAT traversal mode (see nat.go)
+	natGateway                 string               // NAT-PMP gateway, if not the default gateway
+	natStop                    chan struct{}        // closed on shutdown to have the NAT goroutine remove our mapping
+	transport                  Transport            // how we reach the network (TCP, or a simulation in tests)
+	listener                   net.Listener         // where we accept connections, closed on shutdown
+	bannedAddresses            map[string]time.Time // addresses banned through the debug API, and when
 }
 
 type ControllerInit struct {
@@ -70,6 +78,10 @@ type ControllerInit struct {
 	ConnectionMetricsChannel chan interface{} // Channel on which we put the connection metrics map, periodically.
 	LogPath                  string           // Path for logs
 	LogLevel                 string           // Logging level
+	ExternalAddress          string           // Address (and optionally port) peers can reach us at, overrides what we learn
+	NATTraversal             string           // How to open our port on a NAT router: NONE, ANY, UPNP or NATPMP
+	NATGateway               string           // Address of the NAT-PMP gateway, if not the default gateway
+	Transport                Transport        // How to reach the network, nil means TCP.  Tests use a SimNetwork.
 }
 
 // CommandDialPeer is used to instruct the Controller to dial a peer address
@@ -174,8 +186,13 @@ func (e *CommandChangeLogging) String() string {
 func (c *Controller) Init(ci ControllerInit) *Controller {
 	note("ctrlr", "\n\n\n\n\nController.Init(%s) %#x", ci.Port, ci.Network)
 	note("ctrlr", "\n\n\n\n\nController.Init(%s) ci: %+v\n\n", ci.Port, ci)
-	RandomGenerator = rand.New(rand.NewSource(time.Now().UnixNano()))
-	NodeID = uint64(RandomGenerator.Int63()) // This is a global used by all connections
+	// Our connections use this for loopback protection.  It is kept off the globals, as there can be
+	// several nodes in the process (eg: simulations.)
+	c.NodeID = uint64(rand.New(rand.NewSource(time.Now().UnixNano())).Int63())
+	c.transport = ci.Transport
+	if nil == c.transport {
+		c.transport = TCPTransport{}
+	}
 	c.keepRunning = true
 	c.commandChannel = make(chan interface{}, StandardChannelSize) // Commands from App
 	c.FromNetwork = make(chan interface{}, StandardChannelSize)    // Channel to the app for network data
@@ -194,8 +211,23 @@ func (c *Controller) Init(ci ControllerInit) *Controller {
 	c.lastDiscoveryRequest = time.Now() // Discovery does its own on startup.
 	c.lastConnectionMetricsUpdate = time.Now()
 	c.partsAssembler = new(PartsAssembler).Init()
+	c.inventory = new(Inventory).Init()
+	c.lastInventoryCleanup = time.Now()
+	c.protocolVersions = make(map[string]uint16)
+	c.bannedAddresses = make(map[string]time.Time)
 	discovery := new(Discovery).Init(ci.PeersFile, ci.SeedURL)
 	c.discovery = *discovery
+	c.natTraversal = strings.ToUpper(ci.NATTraversal)
+	c.natGateway = ci.NATGateway
+	if 0 < len(ci.ExternalAddress) {
+		address, port, err := SplitAddressPort(ci.ExternalAddress)
+		if nil != err { // No port given
+			address = NormalizeAddress(ci.ExternalAddress)
+		} else {
+			setNetworkPublicPort(port)
+		}
+		c.discovery.setPublicAddress(address, PublicAddressFromConfiguration)
+	}
 	// Set this to the past so we will do peer management almost right away after starting up.
 	note("ctrlr", "\n\n\n\n\nController.Init(%s) Controller is: %+v\n\n", ci.Port, c)
 	return c
@@ -209,6 +241,11 @@ func (c *Controller) StartNetwork() {
 	c.listen()
 	// Dial the peers in from configuration
 	c.DialSpecialPeersString(c.specialPeersString)
+	// Ask the NAT router to forward our port
+	if 0 < len(c.natTraversal) && NATNone != c.natTraversal {
+		c.natStop = make(chan struct{})
+		go c.manageNATMapping(c.natTraversal, c.natGateway, c.natStop)
+	}
 	// Start the runloop
 	go c.runloop()
 }
@@ -220,13 +257,13 @@ func (c *Controller) DialSpecialPeersString(peersString string) {
 	}
 	peerAddresses := strings.FieldsFunc(peersString, parseFunc)
 	for _, peerAddress := range peerAddresses {
-		ipPort := strings.Split(peerAddress, ":")
-		if len(ipPort) == 2 {
-			peer := new(Peer).Init(ipPort[0], ipPort[1], 0, SpecialPeer, 0)
+		address, port, err := SplitAddressPort(peerAddress)
+		if nil == err {
+			peer := new(Peer).Init(address, port, 0, SpecialPeer, 0)
 			peer.Source["Local-Configuration"] = time.Now()
 			c.DialPeer(*peer, true) // these are persistent connections
 		} else {
-			logfatal("Controller", "Error: %s is not a valid peer, use format: 127.0.0.1:8999", peerAddress)
+			logfatal("Controller", "Error: %s is not a valid peer, use format: 127.0.0.1:8999 or [::1]:8999", peerAddress)
 		}
 	}
 }
@@ -286,12 +323,13 @@ func (c *Controller) GetNumberConnections() int {
 //////////////////////////////////////////////////////////////////////
 
 func (c *Controller) listen() {
-	address := fmt.Sprintf(":%s", c.listenPort)
+	address := net.JoinHostPort("", c.listenPort) // empty host listens on all IPv4 and IPv6 interfaces
 	debug("ctrlr", "Controller.listen(%s) got address %s", c.listenPort, address)
-	listener, err := net.Listen("tcp", address)
+	listener, err := c.transport.Listen(address)
 	if nil != err {
 		logfatal("ctrlr", "Controller.listen() Error: %+v", err)
 	} else {
+		c.listener = listener
 		go c.acceptLoop(listener)
 	}
 }
@@ -314,6 +352,9 @@ func (c *Controller) acceptLoop(listener net.Listener) {
 			}
 		default:
 			logerror("ctrlr", "Controller.acceptLoop() Error: %+v", err)
+			if nerr, ok := err.(net.Error); !ok || !nerr.Temporary() {
+				return // The listener was closed
+			}
 		}
 	}
 }
@@ -412,6 +453,10 @@ func (c *Controller) route() {
 			}
 		}
 	}
+	// Announcements are collected per connection so each peer gets one inventory parcel per pass.
+	announcements := map[string][]string{}
+	defer c.sendAnnouncements(announcements)
+
 	// For each message, see if it is directed, if so, send to the
 	// specific peer, otherwise, broadcast.
 	// significant("ctrlr", "Controller.route() size of ToNetwork channel: %d", len(c.ToNetwork))
@@ -421,48 +466,27 @@ func (c *Controller) route() {
 		TotalMessagesSent++
 		switch parcel.Header.TargetPeer {
 		case BroadcastFlag: // Send to all peers
-
-			// First off, how many nodes are we broadcasting to?  At least 4, if possible.  But 1/4 of the
-			// number of connections if that is more than 4.
-			num := NumberPeersToBroadcast
-			clen := len(c.connections)
-			if clen == 0 {
+			targets := c.broadcastTargets()
+			if 0 == len(targets) {
 				return
-			} else if clen < num {
-				num = clen
 			}
-			quarter := clen / 4
-			if quarter > num {
-				num = quarter
+			// Large messages are announced to peers that understand inventory, and only sent if they ask for them.
+			inventoryParcel := isInventoryParcel(parcel)
+			if inventoryParcel {
+				c.inventory.add(parcel)
+				c.inventory.markSeen(parcel.Header.AppHash)
 			}
-
-			// So at this point num <= clen, and we are going to send num sequentinial connections our message.
-			// Note that if we run over the end of the connections, we wrap back to the start.  We don't assume
-			// an order of connections, but we do assume that if we range over a map twice, we get the keys in
-			// the same order both times.  (We do not modify the map)
-			cnt := 0
-			start := rand.Int() % clen
-			spot := start
-		broadcast:
-			for i := 0; i < 2; i++ {
-				loopcnt := 0
-				for _, connection := range c.connections {
-					if loopcnt == spot {
-						BlockFreeChannelSend(connection.SendChannel, ConnectionParcel{Parcel: parcel})
-						spot++
-						if spot >= clen {
-							spot = 0
-						}
-						cnt++
-					}
-					if cnt >= num {
-						break broadcast
+			for _, connection := range targets {
+				peerHash := connection.peer.Hash
+				switch {
+				case inventoryParcel && c.supportsInventory(peerHash):
+					if c.inventory.announceTo(parcel.Header.AppHash, peerHash) {
+						announcements[peerHash] = append(announcements[peerHash], parcel.Header.AppHash)
 					}
-					loopcnt++
+				default:
+					BlockFreeChannelSend(connection.SendChannel, ConnectionParcel{Parcel: parcel})
 				}
 			}
-			SentToPeers.Set(float64(cnt))
-			StartingPoint.Set(float64(start))
 
 		case RandomPeerFlag: // Find a random peer, send to that peer.
 			debug("ctrlr", "Controller.route() Directed FINDING RANDOM Target: %s Type: %s #Number Connections: %d", parcel.Header.TargetPeer, parcel.Header.AppType, len(c.connections))
@@ -491,6 +515,77 @@ func (c *Controller) route() {
 	}
 }
 
+// broadcastTargets picks the connections a broadcast goes to.
+func (c *Controller) broadcastTargets() []*Connection {
+	targets := []*Connection{}
+	// First off, how many nodes are we broadcasting to?  At least 4, if possible.  But 1/4 of the
+	// number of connections if that is more than 4.
+	num := NumberPeersToBroadcast
+	clen := len(c.connections)
+	if clen == 0 {
+		return targets
+	} else if clen < num {
+		num = clen
+	}
+	quarter := clen / 4
+	if quarter > num {
+		num = quarter
+	}
+
+	// So at this point num <= clen, and we are going to send num sequentinial connections our message.
+	// Note that if we run over the end of the connections, we wrap back to the start.  We don't assume
+	// an order of connections, but we do assume that if we range over a map twice, we get the keys in
+	// the same order both times.  (We do not modify the map)
+	cnt := 0
+	start := rand.Int() % clen
+	spot := start
+broadcast:
+	for i := 0; i < 2; i++ {
+		loopcnt := 0
+		for _, connection := range c.connections {
+			if loopcnt == spot {
+				targets = append(targets, connection)
+				spot++
+				if spot >= clen {
+					spot = 0
+				}
+				cnt++
+			}
+			if cnt >= num {
+				break broadcast
+			}
+			loopcnt++
+		}
+	}
+	SentToPeers.Set(float64(cnt))
+	StartingPoint.Set(float64(start))
+	return targets
+}
+
+// supportsInventory is true if the peer has told us (via its parcels) that it understands inventory announcements
+func (c *Controller) supportsInventory(peerHash string) bool {
+	return ProtocolVersionInventory <= c.protocolVersions[peerHash]
+}
+
+// sendAnnouncements sends each connection the hashes of the messages we have for it
+func (c *Controller) sendAnnouncements(announcements map[string][]string) {
+	for peerHash, appHashes := range announcements {
+		connection, present := c.connections[peerHash]
+		if !present {
+			continue
+		}
+		for start := 0; start < len(appHashes); start += MaxInventoryHashesPerParcel {
+			end := start + MaxInventoryHashesPerParcel
+			if end > len(appHashes) {
+				end = len(appHashes)
+			}
+			parcel := newInventoryParcel(TypeInventory, appHashes[start:end])
+			BlockFreeChannelSend(connection.SendChannel, ConnectionParcel{Parcel: *parcel})
+			p2pInventoryAnnounced.Add(float64(end - start))
+		}
+	}
+}
+
 func (c *Controller) doDirectedSend(parcel Parcel) {
 	connection, present := c.connections[parcel.Header.TargetPeer]
 	if present { // We're still connected to the target
@@ -504,23 +599,57 @@ func (c *Controller) handleParcelReceive(message interface{}, peerHash string, c
 	parameters := message.(ConnectionParcel)
 	parcel := parameters.Parcel
 	parcel.Header.TargetPeer = peerHash // Set the connection ID so the application knows which peer the message is from.
+	c.protocolVersions[peerHash] = parcel.Header.Version
 	switch parcel.Header.Type {
 	case TypeMessage: // Application message, send it on.
 		ApplicationMessagesRecieved++
+		if isInventoryParcel(parcel) {
+			c.inventory.add(parcel) // so we can serve it to peers we announce it to
+			c.inventory.heardFrom(parcel.Header.AppHash, peerHash)
+			c.inventory.markSeen(parcel.Header.AppHash)
+		}
 		BlockFreeChannelSend(c.FromNetwork, parcel)
 	case TypeMessagePart: // A part of the application message, handle by assembler and if we have the full message, send it on.
+		if isInventoryParcel(parcel) {
+			c.inventory.add(parcel)
+			c.inventory.heardFrom(parcel.Header.AppHash, peerHash)
+		}
 		assembled := c.partsAssembler.handlePart(parcel)
 		if assembled != nil {
 			ApplicationMessagesRecieved++
+			c.inventory.markSeen(parcel.Header.AppHash)
 			BlockFreeChannelSend(c.FromNetwork, *assembled)
 		}
+	case TypeInventory: // A peer is announcing messages it has, ask for the ones we haven't seen.
+		want := c.inventory.wanted(inventoryHashes(parcel))
+		if 0 < len(want) {
+			request := newInventoryParcel(TypeInventoryRequest, want)
+			BlockFreeChannelSend(connection.SendChannel, ConnectionParcel{Parcel: *request})
+			p2pInventoryRequested.Add(float64(len(want)))
+		}
+	case TypeInventoryRequest: // A peer wants messages we announced, send the ones we still have.
+		for _, appHash := range inventoryHashes(parcel) {
+			parcels, present := c.inventory.get(appHash)
+			if !present {
+				continue
+			}
+			for _, part := range parcels {
+				BlockFreeChannelSend(connection.SendChannel, ConnectionParcel{Parcel: part})
+			}
+			p2pInventoryServed.Inc()
+		}
 	case TypePeerRequest: // send a response to the connection over its connection.SendChannel
+		c.observeAddress(parcel, connection)
 		// Get selection of peers from discovery
 		response := NewParcel(CurrentNetwork, c.discovery.SharePeers())
 		response.Header.Type = TypePeerResponse
+		if !connection.IsOutGoing() { // They dialed us, so we see the address they connect from
+			response.Header.ObservedAddress = connection.peer.Address
+		}
 		// Send them out to the network - on the connection that requested it!
 		BlockFreeChannelSend(connection.SendChannel, ConnectionParcel{Parcel: *response})
 	case TypePeerResponse:
+		c.observeAddress(parcel, connection)
 		// Add these peers to our known peers
 		c.discovery.LearnPeers(parcel)
 	default:
@@ -529,6 +658,14 @@ func (c *Controller) handleParcelReceive(message interface{}, peerHash string, c
 
 }
 
+// observeAddress passes on what the peer says our address is.  Only a peer we dialed has seen
+// the address we connect from, so that is the only case we listen to.
+func (c *Controller) observeAddress(parcel Parcel, connection Connection) {
+	if 0 < len(parcel.Header.ObservedAddress) && connection.IsOutGoing() {
+		c.discovery.observeAddress(parcel.Header.ObservedAddress, connection.peer.Address)
+	}
+}
+
 func (c *Controller) handleConnectionCommand(command ConnectionCommand, connection Connection) {
 	switch command.Command {
 	case ConnectionUpdateMetrics:
@@ -537,6 +674,7 @@ func (c *Controller) handleConnectionCommand(command ConnectionCommand, connecti
 		delete(c.connectionsByAddress, connection.peer.Address)
 		delete(c.connections, connection.peer.Hash)
 		delete(c.connectionMetrics, connection.peer.Hash)
+		delete(c.protocolVersions, connection.peer.Hash)
 		go connection.goShutdown()
 	case ConnectionUpdatingPeer:
 		c.discovery.updatePeer(command.Peer)
@@ -549,7 +687,11 @@ func (c *Controller) handleCommand(command interface{}) {
 	switch commandType := command.(type) {
 	case CommandDialPeer: // parameter is the peer address
 		parameters := command.(CommandDialPeer)
-		conn := new(Connection).Init(parameters.peer, parameters.persistent)
+		if c.isBanned(parameters.peer.Address) {
+			note("ctrlr", "Controller.handleCommand() not dialing banned address %s", parameters.peer.Address)
+			return
+		}
+		conn := new(Connection).Init(parameters.peer, parameters.persistent).setNode(c.NodeID, c.transport)
 		conn.Start()
 
 		c.connections[conn.peer.Hash] = conn
@@ -558,17 +700,33 @@ func (c *Controller) handleCommand(command interface{}) {
 
 		parameters := command.(CommandAddPeer)
 		conn := parameters.conn // net.Conn
-		addPort := strings.Split(conn.RemoteAddr().String(), ":")
+		address, port, err := SplitAddressPort(conn.RemoteAddr().String())
+		if nil != err {
+			logerror("ctrlr", "Controller.handleCommand() CommandAddPeer got an unusable remote address: %s Error: %+v", conn.RemoteAddr().String(), err)
+			conn.Close()
+			return
+		}
+		if c.isBanned(address) {
+			note("ctrlr", "Controller.handleCommand() refusing connection from banned address %s", address)
+			conn.Close()
+			return
+		}
 		// Port initially stored will be the connection port (not the listen port), but peer will update it on first message.
-		peer := new(Peer).Init(addPort[0], addPort[1], 0, RegularPeer, 0)
+		peer := new(Peer).Init(address, port, 0, RegularPeer, 0)
 		peer.Source["Accept()"] = time.Now()
-		connection := new(Connection).InitWithConn(conn, *peer)
+		connection := new(Connection).InitWithConn(conn, *peer).setNode(c.NodeID, c.transport)
 		connection.Start()
 
 		c.connections[connection.peer.Hash] = connection
 		c.connectionsByAddress[connection.peer.Address] = connection
 	case CommandShutdown:
 		c.shutdown()
+	case CommandNATMapped:
+		parameters := command.(CommandNATMapped)
+		if PublicAddressFromConfiguration != c.discovery.PublicAddressSource() {
+			c.discovery.setPublicAddress(parameters.Address, PublicAddressFromNAT)
+			setNetworkPublicPort(parameters.Port)
+		}
 	case CommandChangeLogging:
 		parameters := command.(CommandChangeLogging)
 		CurrentLoggingLevel = parameters.Level
@@ -587,6 +745,12 @@ func (c *Controller) handleCommand(command interface{}) {
 		if present {
 			BlockFreeChannelSend(connection.SendChannel, ConnectionCommand{Command: ConnectionShutdownNow})
 		}
+	case commandNetworkStatus:
+		parameters := command.(commandNetworkStatus)
+		parameters.reply <- c.networkStatus()
+	case commandPeerControl:
+		parameters := command.(commandPeerControl)
+		parameters.reply <- c.handlePeerControl(parameters)
 	default:
 		logfatal("ctrlr", "Unkown p2p.Controller command recieved: %+v", commandType)
 	}
@@ -619,6 +783,10 @@ func (c *Controller) managePeers() {
 			// Get list of peers ordered by quality from discovery
 			c.fillOutgoingSlots(NumberPeersToConnect - c.numberOutgoingConnections)
 		}
+		if InventoryExpiration < time.Since(c.lastInventoryCleanup) {
+			c.lastInventoryCleanup = time.Now()
+			c.inventory.cleanup()
+		}
 		duration := time.Since(c.discovery.lastPeerSave)
 		// Every so often, tell the discovery service to save peers.
 		if PeerSaveInterval < duration {
@@ -708,6 +876,8 @@ func (c *Controller) updateMetrics() {
 					PeerQuality:      metrics.PeerQuality,
 					ConnectionState:  metrics.ConnectionState,
 					ConnectionNotes:  metrics.ConnectionNotes,
+					PingLatency:      metrics.PingLatency,
+					LastMessage:      metrics.LastMessage,
 				}
 			}
 		}
@@ -723,6 +893,13 @@ func (c *Controller) shutdown() {
 	for _, connection := range c.connections {
 		BlockFreeChannelSend(connection.SendChannel, ConnectionCommand{Command: ConnectionShutdownNow})
 	}
+	if nil != c.natStop {
+		close(c.natStop)
+		c.natStop = nil
+	}
+	if nil != c.listener {
+		c.listener.Close()
+	}
 	c.keepRunning = false
 }
 
@@ -744,6 +921,7 @@ func (c *Controller) networkStatusReport() {
 		silence("ctrlr", "        Total RECV: %d", TotalMessagesRecieved)
 		silence("ctrlr", "  Application RECV: %d", ApplicationMessagesRecieved)
 		silence("ctrlr", "        Total XMIT: %d", TotalMessagesSent)
+		silence("ctrlr", "    Public Address: %s (%s)", c.discovery.PublicAddress(), c.discovery.PublicAddressSource())
 		silence("ctrlr", " ")
 		silence("ctrlr", "\tPeer\t\t\t\tDuration\tStatus\t\tNotes")
 		silence("ctrlr", "-------------------------------------------------------------------------------")
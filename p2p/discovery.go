@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"math/rand"
+	"net"
 	"net/http"
 	"os"
 	"sort"
@@ -25,8 +26,19 @@ type Discovery struct {
 	lastPeerSave  time.Time  // Last time we saved known peers.
 	rng           *rand.Rand // RNG = random number generator
 	seedURL       string     // URL to the source of a list of peers
+
+	publicAddress     string            // our public address, if we know it (see observeAddress())
+	publicSource      string            // where publicAddress came from: configuration, NAT router, or peers
+	observedAddresses map[string]string // the address each peer (by address) says we connected from
 }
 
+// Sources of our public address, in order of precedence
+const (
+	PublicAddressFromConfiguration = "configuration"
+	PublicAddressFromNAT           = "nat"
+	PublicAddressFromPeers         = "peers"
+)
+
 var UpdateKnownPeers sync.Mutex
 
 // Discovery provides the code for sharing and managing peers,
@@ -42,6 +54,7 @@ func (d *Discovery) Init(peersFile string, seed string) *Discovery {
 	d.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
 	d.peersFilePath = peersFile
 	d.seedURL = seed
+	d.observedAddresses = map[string]string{}
 	//d.LoadPeers()
 	d.DiscoverPeersFromSeed()
 	return d
@@ -206,6 +219,7 @@ func (d *Discovery) GetOutgoingPeers() []Peer {
 	UpdateKnownPeers.Lock()
 	for _, peer := range d.knownPeers {
 		switch {
+		case 0 < len(d.publicAddress) && d.publicAddress == peer.Address: // Don't dial ourselves
 		case OnlySpecialPeers && SpecialPeer == peer.Type:
 			firstPassPeers = append(firstPassPeers, peer)
 		case !OnlySpecialPeers:
@@ -246,6 +260,59 @@ func (d *Discovery) GetOutgoingPeers() []Peer {
 	return finalSet
 }
 
+// PublicAddress is the address peers can reach us at, or "" if we don't know it yet
+func (d *Discovery) PublicAddress() string {
+	return d.publicAddress
+}
+
+// PublicAddressSource says how we learned our public address
+func (d *Discovery) PublicAddressSource() string {
+	return d.publicSource
+}
+
+// setPublicAddress sets our public address, unless we already have one from a more trusted source.
+// The configuration beats the NAT router, which beats what peers tell us.
+func (d *Discovery) setPublicAddress(address string, source string) {
+	precedence := map[string]int{"": 0, PublicAddressFromPeers: 1, PublicAddressFromNAT: 2, PublicAddressFromConfiguration: 3}
+	if precedence[source] < precedence[d.publicSource] {
+		return
+	}
+	if d.publicAddress != address {
+		significant("discovery", "Discovery.setPublicAddress() our public address is %s (from %s)", address, source)
+	}
+	d.publicAddress = address
+	d.publicSource = source
+}
+
+// observeAddress records the address a peer says we connected from.  Once enough distinct peers
+// agree on a public address, we take it as ours.
+func (d *Discovery) observeAddress(observed string, source string) {
+	ip := net.ParseIP(NormalizeAddress(observed))
+	if nil == ip || !isPublicIP(ip) {
+		return
+	}
+	_, present := d.observedAddresses[source]
+	if !present && MaxAddressObservations <= len(d.observedAddresses) {
+		for address := range d.observedAddresses { // Make room by forgetting someone
+			delete(d.observedAddresses, address)
+			break
+		}
+	}
+	d.observedAddresses[source] = ip.String()
+
+	tally := map[string]int{}
+	best, bestCount := "", 0
+	for _, address := range d.observedAddresses {
+		tally[address]++
+		if bestCount < tally[address] {
+			best, bestCount = address, tally[address]
+		}
+	}
+	if MinimumAddressObservations <= bestCount {
+		d.setPublicAddress(best, PublicAddressFromPeers)
+	}
+}
+
 // SharePeers gets a set of peers to send to other hosts
 // For now, this gives a random set of  the total known peers.
 // The peers are in a json encoded string as byte slice
@@ -263,7 +330,7 @@ func (d *Discovery) getPeerSelection() []byte {
 	// var currentBestDistance float64
 	selectedPeers := []Peer{}
 	firstPassPeers := []Peer{}
-	specialPeersByLocation := map[uint32]Peer{}
+	specialPeersByAddress := map[string]Peer{}
 	UpdateKnownPeers.Lock()
 	for _, peer := range d.knownPeers {
 		if peer.QualityScore > MinumumSharingQualityScore { // Only share peers that have earned positive reputation
@@ -273,16 +340,16 @@ func (d *Discovery) getPeerSelection() []byte {
 	UpdateKnownPeers.Unlock()
 	peerPool := d.filterPeersFromOtherNetworks(firstPassPeers)
 	sort.Sort(PeerQualitySort(peerPool))
-	// Pull out special peers by location.  Use location because it should more accurately reflect IP address.
-	// we check by location to keep from sharing special peers when they dial into us (in which case we wouldn't realize
-	// they were special by the flag.)
+	// Pull out special peers by address.  Addresses are normalized when the peer is created, so this reflects the IP address
+	// (Location is not unique for IPv6.) We check by address to keep from sharing special peers when they dial into us
+	// (in which case we wouldn't realize they were special by the flag.)
 	for _, peer := range peerPool {
 		if peer.Type == SpecialPeer {
-			specialPeersByLocation[peer.Location] = peer
+			specialPeersByAddress[peer.Address] = peer
 		}
 	}
 	for _, peer := range peerPool {
-		_, present := specialPeersByLocation[peer.Location]
+		_, present := specialPeersByAddress[peer.Address]
 		switch {
 		case SpecialPeer == peer.Type:
 			break
@@ -318,9 +385,9 @@ func (d *Discovery) DiscoverPeersFromSeed() {
 		lines = append(lines, scanner.Text())
 	}
 	for _, line := range lines {
-		ipAndPort := strings.Split(line, ":")
-		if 2 == len(ipAndPort) {
-			peerp := new(Peer).Init(ipAndPort[0], ipAndPort[1], 0, RegularPeer, 0)
+		address, port, err := SplitAddressPort(strings.TrimSpace(line))
+		if nil == err {
+			peerp := new(Peer).Init(address, port, 0, RegularPeer, 0)
 			peer := *peerp
 			peer.LastContact = time.Now()
 			d.updatePeer(d.updatePeerSource(peer, "DNS-Seed"))
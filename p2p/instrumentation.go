@@ -31,6 +31,23 @@ var (
 		Help: "Number of msgs broadcasting",
 	})
 
+	//
+	// Inventory
+	p2pInventoryAnnounced = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "factomd_p2p_inventory_announced_total",
+		Help: "Number of message hashes we have announced to peers",
+	})
+
+	p2pInventoryRequested = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "factomd_p2p_inventory_requested_total",
+		Help: "Number of announced messages we have requested from peers",
+	})
+
+	p2pInventoryServed = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "factomd_p2p_inventory_served_total",
+		Help: "Number of messages we have sent to peers that requested them",
+	})
+
 	//
 	// Connection Routines
 	p2pProcessSendsGuage = prometheus.NewGauge(prometheus.GaugeOpts{
@@ -110,6 +127,11 @@ func RegisterPrometheus() {
 	prometheus.MustRegister(SentToPeers)
 	prometheus.MustRegister(StartingPoint)
 
+	// Inventory
+	prometheus.MustRegister(p2pInventoryAnnounced)
+	prometheus.MustRegister(p2pInventoryRequested)
+	prometheus.MustRegister(p2pInventoryServed)
+
 	// Connection Routines
 	prometheus.MustRegister(p2pProcessSendsGuage)    // processSends
 	prometheus.MustRegister(p2pProcessReceivesGuage) // processReceives
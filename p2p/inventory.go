@@ -0,0 +1,207 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Inventory based gossip.
+//
+// Rather than pushing every large broadcast message to every peer we broadcast to, we announce the
+// AppHash of the message (TypeInventory) and let the peer ask for it (TypeInventoryRequest) if it
+// has not seen it yet.  Small messages (acks, commits, EOMs...) are cheaper to send than to announce,
+// so they are still flooded.  Peers running a protocol version older than ProtocolVersionInventory
+// don't understand the announcements, so they continue to receive full messages.
+//
+// The Inventory is owned by the Controller and is only accessed from the Controller's runloop().
+
+// InventoryItem is a message we can hand out to peers that ask for it.
+type InventoryItem struct {
+	parcels     []Parcel        // all the parcels (one, or every part of a multipart message) making up the message
+	size        int             // bytes of payload held in parcels
+	added       time.Time       // when we first had the message
+	announcedTo map[string]bool // peers (by hash) that we announced the message to, or that gave it to us
+}
+
+// Inventory tracks the messages we can serve, the messages we have seen and the messages we asked for
+type Inventory struct {
+	items     map[string]*InventoryItem // messages we can serve to others, indexed by AppHash
+	seen      map[string]time.Time      // AppHashes of messages we have received
+	requested map[string]time.Time      // AppHashes we have requested and when we asked
+	size      int                       // bytes of payload held by all the items, at most MaxInventorySize
+}
+
+// Initializes the inventory
+func (inv *Inventory) Init() *Inventory {
+	inv.items = make(map[string]*InventoryItem)
+	inv.seen = make(map[string]time.Time)
+	inv.requested = make(map[string]time.Time)
+	return inv
+}
+
+// isInventoryParcel tells us if a parcel should be announced rather than flooded.
+func isInventoryParcel(parcel Parcel) bool {
+	switch {
+	case TypeMessage != parcel.Header.Type && TypeMessagePart != parcel.Header.Type:
+		return false
+	case 0 == len(parcel.Header.AppHash) || "NetworkMessage" == parcel.Header.AppHash:
+		return false
+	case MinimumInventoryPayloadSize > parcel.Header.Length && 1 >= parcel.Header.PartsTotal:
+		return false
+	}
+	return true
+}
+
+// add puts a parcel (or one part of a message) in the inventory.  If that takes the inventory
+// over MaxInventorySize, the oldest messages are dropped.
+func (inv *Inventory) add(parcel Parcel) {
+	appHash := parcel.Header.AppHash
+	item, present := inv.items[appHash]
+	if !present {
+		item = &InventoryItem{added: time.Now(), announcedTo: map[string]bool{}}
+		inv.items[appHash] = item
+	}
+	for _, held := range item.parcels {
+		if held.Header.PartNo == parcel.Header.PartNo {
+			return
+		}
+	}
+	item.parcels = append(item.parcels, parcel)
+	item.size += len(parcel.Payload)
+	inv.size += len(parcel.Payload)
+	for MaxInventorySize < inv.size && 1 < len(inv.items) {
+		inv.dropOldest()
+	}
+}
+
+// dropOldest removes the message we have held the longest
+func (inv *Inventory) dropOldest() {
+	oldest := ""
+	for appHash, item := range inv.items {
+		if "" == oldest || item.added.Before(inv.items[oldest].added) {
+			oldest = appHash
+		}
+	}
+	inv.drop(oldest)
+}
+
+// drop removes a message from the inventory
+func (inv *Inventory) drop(appHash string) {
+	if item, present := inv.items[appHash]; present {
+		inv.size -= item.size
+		delete(inv.items, appHash)
+	}
+}
+
+// announceTo returns true if the peer has not yet been told about (or given us) the message,
+// and notes that it now has.  So each peer hears about a message at most once.  A message is
+// only announced once we hold all of its parts, as we can't serve it before then.
+func (inv *Inventory) announceTo(appHash string, peerHash string) bool {
+	item, present := inv.items[appHash]
+	if !present || !item.isComplete() || item.announcedTo[peerHash] {
+		return false
+	}
+	item.announcedTo[peerHash] = true
+	return true
+}
+
+// heardFrom notes that the peer gave us (some of) the message, so we never announce it back
+func (inv *Inventory) heardFrom(appHash string, peerHash string) {
+	if item, present := inv.items[appHash]; present {
+		item.announcedTo[peerHash] = true
+	}
+}
+
+// markSeen notes a message we received so we don't ask for it again
+func (inv *Inventory) markSeen(appHash string) {
+	inv.seen[appHash] = time.Now()
+	delete(inv.requested, appHash)
+}
+
+// get returns the parcels for the message, if we have all of it
+func (inv *Inventory) get(appHash string) ([]Parcel, bool) {
+	item, present := inv.items[appHash]
+	if !present || !item.isComplete() {
+		return nil, false
+	}
+	return item.parcels, true
+}
+
+// isComplete is true if we hold every part of the message
+func (item *InventoryItem) isComplete() bool {
+	if TypeMessagePart != item.parcels[0].Header.Type {
+		return true
+	}
+	return int(item.parcels[0].Header.PartsTotal) <= len(item.parcels)
+}
+
+// wanted filters announced hashes down to the ones we have not seen, and have not
+// asked for recently.  The returned hashes are marked as requested.
+// Note that an announcement is only as good as the peer making it; we trust the AppHash
+// in the header, as the application validates the message once it arrives.
+func (inv *Inventory) wanted(appHashes []string) []string {
+	want := []string{}
+	for _, appHash := range appHashes {
+		if _, present := inv.seen[appHash]; present {
+			continue
+		}
+		if asked, present := inv.requested[appHash]; present && time.Since(asked) < InventoryRequestTimeout {
+			continue
+		}
+		inv.requested[appHash] = time.Now()
+		want = append(want, appHash)
+		if MaxInventoryHashesPerParcel <= len(want) {
+			break
+		}
+	}
+	return want
+}
+
+// cleanup drops everything older than InventoryExpiration
+func (inv *Inventory) cleanup() {
+	for appHash, item := range inv.items {
+		if InventoryExpiration < time.Since(item.added) {
+			inv.drop(appHash)
+		}
+	}
+	for appHash, when := range inv.seen {
+		if InventoryExpiration < time.Since(when) {
+			delete(inv.seen, appHash)
+		}
+	}
+	for appHash, when := range inv.requested {
+		if InventoryExpiration < time.Since(when) {
+			delete(inv.requested, appHash)
+		}
+	}
+}
+
+// newInventoryParcel builds an announcement (TypeInventory) or a request (TypeInventoryRequest) for a set of hashes
+func newInventoryParcel(parcelType ParcelCommandType, appHashes []string) *Parcel {
+	payload, err := json.Marshal(appHashes)
+	if nil != err {
+		logerror("inventory", "newInventoryParcel() got an error marshalling json. error: %+v hashes: %+v", err, appHashes)
+	}
+	parcel := NewParcel(CurrentNetwork, payload)
+	parcel.Header.Type = parcelType
+	return parcel
+}
+
+// inventoryHashes decodes the hashes carried by a TypeInventory or TypeInventoryRequest parcel
+func inventoryHashes(parcel Parcel) []string {
+	var appHashes []string
+	err := json.Unmarshal(parcel.Payload, &appHashes)
+	if nil != err {
+		logerror("inventory", "inventoryHashes() got an error unmarshalling json. error: %+v json: %+v", err, strconv.Quote(string(parcel.Payload)))
+		return nil
+	}
+	if MaxInventoryHashesPerParcel < len(appHashes) {
+		appHashes = appHashes[:MaxInventoryHashesPerParcel]
+	}
+	return appHashes
+}
@@ -0,0 +1,119 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+)
+
+func inventoryTestParcel(appHash string, size int) Parcel {
+	parcel := NewParcel(CurrentNetwork, make([]byte, size))
+	parcel.Header.AppHash = appHash
+	return *parcel
+}
+
+func TestIsInventoryParcel(t *testing.T) {
+	if isInventoryParcel(inventoryTestParcel("small", 10)) {
+		t.Error("Small messages should be flooded, not announced")
+	}
+	if !isInventoryParcel(inventoryTestParcel("large", int(MinimumInventoryPayloadSize))) {
+		t.Error("Large messages should be announced")
+	}
+	if isInventoryParcel(inventoryTestParcel("NetworkMessage", int(MinimumInventoryPayloadSize))) {
+		t.Error("Messages without an application hash can't be announced")
+	}
+	ping := inventoryTestParcel("large", int(MinimumInventoryPayloadSize))
+	ping.Header.Type = TypePing
+	if isInventoryParcel(ping) {
+		t.Error("Only application messages should be announced")
+	}
+}
+
+func TestInventoryWanted(t *testing.T) {
+	inv := new(Inventory).Init()
+	inv.markSeen("seen")
+
+	want := inv.wanted([]string{"seen", "new"})
+	if 1 != len(want) || "new" != want[0] {
+		t.Errorf("Expected to only want the new message, got %v", want)
+	}
+	// We asked already, so we shouldn't ask again until the request times out
+	want = inv.wanted([]string{"new"})
+	if 0 != len(want) {
+		t.Errorf("Expected to want nothing while the request is outstanding, got %v", want)
+	}
+	inv.markSeen("new")
+	if _, present := inv.requested["new"]; present {
+		t.Error("Receiving a message should clear its request")
+	}
+}
+
+func TestInventoryMultipart(t *testing.T) {
+	inv := new(Inventory).Init()
+	parts := ParcelsForPayload(CurrentNetwork, make([]byte, 10))
+	for i := range parts {
+		parts[i].Header.AppHash = "multi"
+		parts[i].Header.PartsTotal = 2
+		parts[i].Header.PartNo = uint16(i)
+	}
+	second := parts[0]
+	second.Header.PartNo = 1
+
+	inv.add(parts[0])
+	if _, present := inv.get("multi"); present {
+		t.Error("Should not serve a message until all parts are present")
+	}
+	if inv.announceTo("multi", "peer") {
+		t.Error("Should not announce a message until all parts are present")
+	}
+	inv.heardFrom("multi", "sender")
+	inv.add(second)
+	inv.add(second)
+	if !inv.announceTo("multi", "peer") {
+		t.Error("The message should be announced to a new peer")
+	}
+	if inv.announceTo("multi", "peer") {
+		t.Error("The message should only be announced to a peer once")
+	}
+	if inv.announceTo("multi", "sender") {
+		t.Error("The message should not be announced to the peer that gave it to us")
+	}
+	parcels, present := inv.get("multi")
+	if !present || 2 != len(parcels) {
+		t.Errorf("Expected 2 parts to serve, got %d", len(parcels))
+	}
+}
+
+func TestInventorySize(t *testing.T) {
+	inv := new(Inventory).Init()
+	size := MaxInventorySize / 4
+	for _, appHash := range []string{"a", "b", "c", "d", "e"} {
+		inv.add(inventoryTestParcel(appHash, size))
+		time.Sleep(time.Millisecond) // so the messages are added in order
+	}
+	if MaxInventorySize < inv.size || 4 != len(inv.items) {
+		t.Errorf("Expected 4 messages in %d bytes, got %d in %d bytes", MaxInventorySize, len(inv.items), inv.size)
+	}
+	if _, present := inv.get("a"); present {
+		t.Error("The oldest message should have been dropped")
+	}
+	if _, present := inv.get("e"); !present {
+		t.Error("The newest message should be kept")
+	}
+}
+
+func TestInventoryParcelRoundTrip(t *testing.T) {
+	hashes := []string{"a", "b", "c"}
+	parcel := newInventoryParcel(TypeInventory, hashes)
+	if TypeInventory != parcel.Header.Type {
+		t.Errorf("Wrong parcel type %s", parcel.MessageType())
+	}
+	decoded := inventoryHashes(*parcel)
+	if len(decoded) != len(hashes) {
+		t.Fatalf("Expected %d hashes, got %d", len(hashes), len(decoded))
+	}
+	for i := range hashes {
+		if hashes[i] != decoded[i] {
+			t.Errorf("Expected %s, got %s", hashes[i], decoded[i])
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NAT traversal.
+//
+// Nodes behind a NAT router can't accept incoming connections unless the router forwards the
+// listen port to them.  If configured, we ask the router to do that using either UPnP (upnp.go)
+// or NAT-PMP (natpmp.go), and learn our public address from the router at the same time.
+
+// NAT traversal modes, as set in the config file.
+const (
+	NATNone   = "NONE"   // Don't try to map ports (default)
+	NATAny    = "ANY"    // Try UPnP, then NAT-PMP
+	NATUPnP   = "UPNP"   // Only UPnP
+	NATNATPMP = "NATPMP" // Only NAT-PMP
+)
+
+// NATPortMapper is implemented by the protocols we can use to open a port on a NAT router.
+type NATPortMapper interface {
+	// ExternalAddress returns the public address of the router
+	ExternalAddress() (net.IP, error)
+	// AddPortMapping forwards externalPort on the router to internalPort on this host, returning
+	// the external port the router actually mapped.
+	AddPortMapping(protocol string, internalPort int, externalPort int, description string, lifetime time.Duration) (int, error)
+	// DeletePortMapping removes a mapping made by AddPortMapping
+	DeletePortMapping(protocol string, internalPort int, externalPort int) error
+	// String names the protocol, for logging
+	String() string
+}
+
+var (
+	NATMappingLifetime    = time.Hour * 2   // How long we ask the router to keep our mapping
+	NATDiscoveryTimeout   = time.Second * 3 // How long we wait for a router to answer
+	NATMappingDescription = "factomd"       // Shows up in the router's list of mappings
+)
+
+// CommandNATMapped is sent by the NAT goroutine to the Controller when a port has been mapped.
+type CommandNATMapped struct {
+	Address string // our public address as reported by the router
+	Port    string // the external port the router forwards to us
+}
+
+// NewNATPortMapper finds a router for the given mode.  gateway is optional and overrides the
+// default gateway of the host for NAT-PMP.
+func NewNATPortMapper(mode string, gateway string) (NATPortMapper, error) {
+	mode = strings.ToUpper(mode)
+	switch mode {
+	case NATUPnP:
+		return DiscoverUPnP(NATDiscoveryTimeout)
+	case NATNATPMP:
+		return newNATPMPFromGateway(gateway)
+	case NATAny:
+		upnp, err := DiscoverUPnP(NATDiscoveryTimeout)
+		if nil == err {
+			return upnp, nil
+		}
+		note("nat", "NewNATPortMapper() no UPnP router found (%v), trying NAT-PMP", err)
+		return newNATPMPFromGateway(gateway)
+	default:
+		return nil, fmt.Errorf("Unknown NAT traversal mode: %s", mode)
+	}
+}
+
+func newNATPMPFromGateway(gateway string) (NATPortMapper, error) {
+	var ip net.IP
+	if 0 < len(gateway) {
+		ip = net.ParseIP(NormalizeAddress(gateway))
+		if nil == ip {
+			return nil, fmt.Errorf("Invalid NAT gateway address: %s", gateway)
+		}
+	} else {
+		var err error
+		ip, err = defaultGateway()
+		if nil != err {
+			return nil, err
+		}
+	}
+	natpmp := NewNATPMP(ip)
+	// Check there is actually something answering NAT-PMP before we hand it back.
+	if _, err := natpmp.ExternalAddress(); nil != err {
+		return nil, err
+	}
+	return natpmp, nil
+}
+
+// manageNATMapping runs in its own goroutine.  It maps the listen port on the router, tells the controller
+// the result, and renews the mapping until stop is closed, at which point it removes the mapping.
+func (c *Controller) manageNATMapping(mode string, gateway string, stop chan struct{}) {
+	internalPort, err := strconv.Atoi(c.listenPort)
+	if nil != err {
+		logerror("nat", "manageNATMapping() invalid listen port: %s", c.listenPort)
+		return
+	}
+	mapper, err := NewNATPortMapper(mode, gateway)
+	if nil != err {
+		logerror("nat", "manageNATMapping() could not find a NAT router: %v", err)
+		return
+	}
+	significant("nat", "manageNATMapping() using %s", mapper.String())
+
+	externalPort := internalPort
+	for {
+		mapped, err := mapper.AddPortMapping("TCP", internalPort, externalPort, NATMappingDescription, NATMappingLifetime)
+		if nil != err {
+			logerror("nat", "manageNATMapping() %s could not map port %d: %v", mapper.String(), internalPort, err)
+		} else {
+			externalPort = mapped
+			address, err := mapper.ExternalAddress()
+			if nil != err {
+				logerror("nat", "manageNATMapping() %s could not get our external address: %v", mapper.String(), err)
+			} else {
+				significant("nat", "manageNATMapping() %s mapped %s:%d to port %d", mapper.String(), address.String(), externalPort, internalPort)
+				BlockFreeChannelSend(c.commandChannel, CommandNATMapped{Address: address.String(), Port: strconv.Itoa(externalPort)})
+			}
+		}
+
+		select {
+		case <-stop:
+			if err := mapper.DeletePortMapping("TCP", internalPort, externalPort); nil != err {
+				logerror("nat", "manageNATMapping() %s could not remove mapping: %v", mapper.String(), err)
+			}
+			return
+		case <-time.After(NATMappingLifetime / 2): // Renew well before the mapping expires
+		}
+	}
+}
+
+// defaultGateway reads the default route of the host.  Only linux is supported, elsewhere the
+// gateway has to be given in the configuration.
+func defaultGateway() (net.IP, error) {
+	file, err := os.Open("/proc/net/route")
+	if nil != err {
+		return nil, fmt.Errorf("Could not determine the default gateway, please configure one: %v", err)
+	}
+	defer file.Close()
+	return parseRouteTable(bufio.NewScanner(file))
+}
+
+// parseRouteTable finds the default route in the format of /proc/net/route
+func parseRouteTable(scanner *bufio.Scanner) (net.IP, error) {
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// Iface Destination Gateway Flags ...
+		if 3 > len(fields) || "00000000" != fields[1] {
+			continue
+		}
+		gateway, err := strconv.ParseUint(fields[2], 16, 32)
+		if nil != err || 0 == gateway {
+			continue
+		}
+		// The kernel writes the address in host (little endian) byte order
+		return net.IPv4(byte(gateway), byte(gateway>>8), byte(gateway>>16), byte(gateway>>24)), nil
+	}
+	return nil, fmt.Errorf("No default gateway found")
+}
+
+// localAddressFor returns the address of this host used to reach the given host
+func localAddressFor(host string) (net.IP, error) {
+	conn, err := net.Dial("udp", net.JoinHostPort(host, "1"))
+	if nil != err {
+		return nil, err
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).IP, nil
+}
+
+// isPublicIP is false for loopback, link local and private (RFC 1918 / RFC 4193) addresses
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsUnspecified() || ip.IsMulticast() {
+		return false
+	}
+	for _, block := range privateBlocks {
+		if block.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+var privateBlocks = func() []*net.IPNet {
+	blocks := []*net.IPNet{}
+	for _, cidr := range []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "fc00::/7"} {
+		_, block, _ := net.ParseCIDR(cidr)
+		blocks = append(blocks, block)
+	}
+	return blocks
+}()
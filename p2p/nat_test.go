@@ -0,0 +1,193 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeNATPMPGateway answers NAT-PMP requests on a local UDP port, mapping every request to mappedPort
+func fakeNATPMPGateway(t *testing.T, mappedPort uint16) (string, func()) {
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	if nil != err {
+		t.Fatal(err)
+	}
+	go func() {
+		buffer := make([]byte, 64)
+		for {
+			size, from, err := conn.ReadFrom(buffer)
+			if nil != err {
+				return
+			}
+			var response []byte
+			switch {
+			case 2 == size && natpmpOpExternalAddress == buffer[1]:
+				response = make([]byte, 12)
+				copy(response[8:12], net.ParseIP("203.0.113.7").To4())
+			case 12 == size:
+				response = make([]byte, 16)
+				copy(response[8:10], buffer[4:6])
+				binary.BigEndian.PutUint16(response[10:12], mappedPort)
+				copy(response[12:16], buffer[8:12])
+			default:
+				continue
+			}
+			response[1] = buffer[1] + natpmpResponseBit
+			conn.WriteTo(response, from)
+		}
+	}()
+	return conn.LocalAddr().String(), func() { conn.Close() }
+}
+
+func TestNATPMP(t *testing.T) {
+	address, stop := fakeNATPMPGateway(t, 40108)
+	defer stop()
+	natpmp := NewNATPMPWithAddress(address)
+
+	ip, err := natpmp.ExternalAddress()
+	if nil != err || "203.0.113.7" != ip.String() {
+		t.Errorf("ExternalAddress() got %v %v", ip, err)
+	}
+	port, err := natpmp.AddPortMapping("TCP", 8108, 8108, "test", time.Hour)
+	if nil != err || 40108 != port {
+		t.Errorf("AddPortMapping() got %d %v", port, err)
+	}
+	if err = natpmp.DeletePortMapping("TCP", 8108, port); nil != err {
+		t.Errorf("DeletePortMapping() got %v", err)
+	}
+	if _, err = natpmp.AddPortMapping("SCTP", 8108, 8108, "test", time.Hour); nil == err {
+		t.Errorf("AddPortMapping() should not accept SCTP")
+	}
+}
+
+func TestNATPMPNoGateway(t *testing.T) {
+	conn, _ := net.ListenPacket("udp4", "127.0.0.1:0")
+	address := conn.LocalAddr().String()
+	conn.Close() // Nobody answers here
+	if _, err := NewNATPMPWithAddress(address).ExternalAddress(); nil == err {
+		t.Errorf("ExternalAddress() should fail without a gateway")
+	}
+}
+
+const fakeUPnPDescription = `<?xml version="1.0"?>
+<root xmlns="urn:schemas-upnp-org:device-1-0">
+<device>
+  <deviceType>urn:schemas-upnp-org:device:InternetGatewayDevice:1</deviceType>
+  <deviceList><device>
+    <deviceType>urn:schemas-upnp-org:device:WANDevice:1</deviceType>
+    <deviceList><device>
+      <deviceType>urn:schemas-upnp-org:device:WANConnectionDevice:1</deviceType>
+      <serviceList><service>
+        <serviceType>urn:schemas-upnp-org:service:WANIPConnection:1</serviceType>
+        <controlURL>/ctl/IPConn</controlURL>
+      </service></serviceList>
+    </device></deviceList>
+  </device></deviceList>
+</device>
+</root>`
+
+func TestUPnP(t *testing.T) {
+	mappings := map[string]bool{}
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if "/rootDesc.xml" == r.URL.Path {
+			w.Write([]byte(fakeUPnPDescription))
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		action := r.Header.Get("SOAPAction")
+		switch {
+		case strings.HasSuffix(action, `#GetExternalIPAddress"`):
+			w.Write([]byte(`<?xml version="1.0"?><s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body>` +
+				`<u:GetExternalIPAddressResponse xmlns:u="urn:schemas-upnp-org:service:WANIPConnection:1">` +
+				`<NewExternalIPAddress>198.51.100.4</NewExternalIPAddress></u:GetExternalIPAddressResponse></s:Body></s:Envelope>`))
+		case strings.HasSuffix(action, `#AddPortMapping"`):
+			if !strings.Contains(string(body), "<NewInternalPort>8108</NewInternalPort>") {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			mappings["8108"] = true
+		case strings.HasSuffix(action, `#DeletePortMapping"`):
+			delete(mappings, "8108")
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer gateway.Close()
+
+	upnp, err := NewUPnP(gateway.URL + "/rootDesc.xml")
+	if nil != err {
+		t.Fatalf("NewUPnP() got %v", err)
+	}
+	if gateway.URL+"/ctl/IPConn" != upnp.controlURL {
+		t.Errorf("NewUPnP() got control URL %s", upnp.controlURL)
+	}
+	ip, err := upnp.ExternalAddress()
+	if nil != err || "198.51.100.4" != ip.String() {
+		t.Errorf("ExternalAddress() got %v %v", ip, err)
+	}
+	port, err := upnp.AddPortMapping("tcp", 8108, 8108, "test", time.Hour)
+	if nil != err || 8108 != port || !mappings["8108"] {
+		t.Errorf("AddPortMapping() got %d %v", port, err)
+	}
+	if err = upnp.DeletePortMapping("tcp", 8108, 8108); nil != err || mappings["8108"] {
+		t.Errorf("DeletePortMapping() got %v", err)
+	}
+	if _, err = upnp.AddPortMapping("tcp", 9999, 9999, "test", time.Hour); nil == err {
+		t.Errorf("AddPortMapping() should return the gateway's error")
+	}
+}
+
+func TestParseRouteTable(t *testing.T) {
+	table := "Iface\tDestination\tGateway \tFlags\tRefCnt\tUse\tMetric\tMask\n" +
+		"eth0\t0000A8C0\t00000000\t0001\t0\t0\t0\t00FFFFFF\n" +
+		"eth0\t00000000\t0101A8C0\t0003\t0\t0\t0\t00000000\n"
+	ip, err := parseRouteTable(bufio.NewScanner(strings.NewReader(table)))
+	if nil != err || "192.168.1.1" != ip.String() {
+		t.Errorf("parseRouteTable() got %v %v", ip, err)
+	}
+	if _, err = parseRouteTable(bufio.NewScanner(strings.NewReader("Iface\tDestination\tGateway\n"))); nil == err {
+		t.Errorf("parseRouteTable() should fail without a default route")
+	}
+}
+
+func TestObserveAddress(t *testing.T) {
+	d := new(Discovery)
+	d.observedAddresses = map[string]string{}
+
+	d.observeAddress("192.168.1.20", "1.1.1.1") // private addresses are ignored
+	d.observeAddress("203.0.113.7", "1.1.1.1")
+	if "" != d.PublicAddress() {
+		t.Errorf("one peer should not be enough, got %s", d.PublicAddress())
+	}
+	d.observeAddress("203.0.113.7", "1.1.1.1") // same peer again
+	if "" != d.PublicAddress() {
+		t.Errorf("a peer should only count once, got %s", d.PublicAddress())
+	}
+	d.observeAddress("203.0.113.7", "2.2.2.2")
+	if "203.0.113.7" != d.PublicAddress() || PublicAddressFromPeers != d.PublicAddressSource() {
+		t.Errorf("two peers should be enough, got %s from %s", d.PublicAddress(), d.PublicAddressSource())
+	}
+
+	d.setPublicAddress("198.51.100.4", PublicAddressFromNAT)
+	d.observeAddress("203.0.113.9", "3.3.3.3")
+	d.observeAddress("203.0.113.9", "4.4.4.4")
+	d.observeAddress("203.0.113.9", "5.5.5.5")
+	if "198.51.100.4" != d.PublicAddress() {
+		t.Errorf("the NAT router should beat peers, got %s", d.PublicAddress())
+	}
+	d.setPublicAddress("192.0.2.1", PublicAddressFromConfiguration)
+	d.setPublicAddress("198.51.100.4", PublicAddressFromNAT)
+	if "192.0.2.1" != d.PublicAddress() {
+		t.Errorf("the configuration should beat the NAT router, got %s", d.PublicAddress())
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// NAT-PMP client (RFC 6886).  Requests are small UDP datagrams to port 5351 of the gateway.
+
+const (
+	natpmpPort               = "5351"
+	natpmpVersion            = 0
+	natpmpOpExternalAddress  = 0
+	natpmpOpMapUDP           = 1
+	natpmpOpMapTCP           = 2
+	natpmpResponseBit        = 128
+	natpmpMaxAttempts        = 4
+	natpmpInitialWaitTimeout = 250 * time.Millisecond
+)
+
+var natpmpResultCodes = map[uint16]string{
+	1: "Unsupported Version",
+	2: "Not Authorized/Refused",
+	3: "Network Failure",
+	4: "Out of resources",
+	5: "Unsupported opcode",
+}
+
+// NATPMP talks NAT-PMP to a gateway
+type NATPMP struct {
+	gatewayAddress string // host:port of the gateway
+}
+
+var _ NATPortMapper = (*NATPMP)(nil)
+
+// NewNATPMP creates a client for the gateway on the standard NAT-PMP port
+func NewNATPMP(gateway net.IP) *NATPMP {
+	return &NATPMP{gatewayAddress: net.JoinHostPort(gateway.String(), natpmpPort)}
+}
+
+// NewNATPMPWithAddress creates a client for a gateway listening on a given host:port (used in testing)
+func NewNATPMPWithAddress(gatewayAddress string) *NATPMP {
+	return &NATPMP{gatewayAddress: gatewayAddress}
+}
+
+func (n *NATPMP) String() string {
+	return "NAT-PMP(" + n.gatewayAddress + ")"
+}
+
+func (n *NATPMP) ExternalAddress() (net.IP, error) {
+	response, err := n.call([]byte{natpmpVersion, natpmpOpExternalAddress}, 12)
+	if nil != err {
+		return nil, err
+	}
+	return net.IPv4(response[8], response[9], response[10], response[11]), nil
+}
+
+func (n *NATPMP) AddPortMapping(protocol string, internalPort int, externalPort int, description string, lifetime time.Duration) (int, error) {
+	opcode, err := natpmpMapOpcode(protocol)
+	if nil != err {
+		return 0, err
+	}
+	request := make([]byte, 12)
+	request[0] = natpmpVersion
+	request[1] = opcode
+	binary.BigEndian.PutUint16(request[4:6], uint16(internalPort))
+	binary.BigEndian.PutUint16(request[6:8], uint16(externalPort))
+	binary.BigEndian.PutUint32(request[8:12], uint32(lifetime/time.Second))
+	response, err := n.call(request, 16)
+	if nil != err {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint16(response[10:12])), nil
+}
+
+// DeletePortMapping asks for a mapping with a lifetime of zero, which removes it
+func (n *NATPMP) DeletePortMapping(protocol string, internalPort int, externalPort int) error {
+	opcode, err := natpmpMapOpcode(protocol)
+	if nil != err {
+		return err
+	}
+	request := make([]byte, 12)
+	request[0] = natpmpVersion
+	request[1] = opcode
+	binary.BigEndian.PutUint16(request[4:6], uint16(internalPort))
+	_, err = n.call(request, 16)
+	return err
+}
+
+func natpmpMapOpcode(protocol string) (byte, error) {
+	switch strings.ToUpper(protocol) {
+	case "TCP":
+		return natpmpOpMapTCP, nil
+	case "UDP":
+		return natpmpOpMapUDP, nil
+	default:
+		return 0, fmt.Errorf("NAT-PMP can't map protocol %s", protocol)
+	}
+}
+
+// call sends the request and waits for the response, retrying with a doubling timeout as the RFC suggests.
+func (n *NATPMP) call(request []byte, responseSize int) ([]byte, error) {
+	conn, err := net.Dial("udp", n.gatewayAddress)
+	if nil != err {
+		return nil, err
+	}
+	defer conn.Close()
+
+	response := make([]byte, 16)
+	timeout := natpmpInitialWaitTimeout
+	for attempt := 0; attempt < natpmpMaxAttempts; attempt++ {
+		if _, err = conn.Write(request); nil != err {
+			return nil, err
+		}
+		conn.SetReadDeadline(time.Now().Add(timeout))
+		timeout *= 2
+		size, err := conn.Read(response)
+		if nil != err {
+			if nerr, ok := err.(net.Error); ok && nerr.Timeout() {
+				continue
+			}
+			return nil, err
+		}
+		if size < responseSize || natpmpVersion != response[0] || request[1]+natpmpResponseBit != response[1] {
+			continue // not the answer to our request
+		}
+		if result := binary.BigEndian.Uint16(response[2:4]); 0 != result {
+			return nil, fmt.Errorf("NAT-PMP gateway refused request: %s (%d)", natpmpResultCodes[result], result)
+		}
+		return response[:size], nil
+	}
+	return nil, fmt.Errorf("No NAT-PMP response from %s", n.gatewayAddress)
+}
@@ -36,32 +36,39 @@ type ParcelHeader struct {
 	PeerPort    string // port of the peer , or we are listening on
 	AppHash     string // Application specific message hash, for tracing
 	AppType     string // Application specific message type, for tracing
+	// On peer requests and responses, the address the sender sees the receiver connecting from.
+	// Lets nodes behind a NAT learn their public address.  Older peers ignore it.
+	ObservedAddress string
 }
 
 type ParcelCommandType uint16
 
 // Parcel commands -- all new commands should be added to the *end* of the list!
 const ( // iota is reset to 0
-	TypeHeartbeat    ParcelCommandType = iota // "Note, I'm still alive"
-	TypePing                                  // "Are you there?"
-	TypePong                                  // "yes, I'm here"
-	TypePeerRequest                           // "Please share some peers"
-	TypePeerResponse                          // "Here's some peers I know about."
-	TypeAlert                                 // network wide alerts (used in bitcoin to indicate criticalities)
-	TypeMessage                               // Application level message
-	TypeMessagePart                           // Application level message that was split into multiple parts
+	TypeHeartbeat        ParcelCommandType = iota // "Note, I'm still alive"
+	TypePing                                      // "Are you there?"
+	TypePong                                      // "yes, I'm here"
+	TypePeerRequest                               // "Please share some peers"
+	TypePeerResponse                              // "Here's some peers I know about."
+	TypeAlert                                     // network wide alerts (used in bitcoin to indicate criticalities)
+	TypeMessage                                   // Application level message
+	TypeMessagePart                               // Application level message that was split into multiple parts
+	TypeInventory                                 // "Here are the hashes of messages I have" (see inventory.go)
+	TypeInventoryRequest                          // "Please send me the messages with these hashes"
 )
 
 // CommandStrings is a Map of command ids to strings for easy printing of network comands
 var CommandStrings = map[ParcelCommandType]string{
-	TypeHeartbeat:    "Heartbeat",     // "Note, I'm still alive"
-	TypePing:         "Ping",          // "Are you there?"
-	TypePong:         "Pong",          // "yes, I'm here"
-	TypePeerRequest:  "Peer-Request",  // "Please share some peers"
-	TypePeerResponse: "Peer-Response", // "Here's some peers I know about."
-	TypeAlert:        "Alert",         // network wide alerts (used in bitcoin to indicate criticalities)
-	TypeMessage:      "Message",       // Application level message
-	TypeMessagePart:  "MessagePart",   // Application level message that was split into multiple parts
+	TypeHeartbeat:        "Heartbeat",         // "Note, I'm still alive"
+	TypePing:             "Ping",              // "Are you there?"
+	TypePong:             "Pong",              // "yes, I'm here"
+	TypePeerRequest:      "Peer-Request",      // "Please share some peers"
+	TypePeerResponse:     "Peer-Response",     // "Here's some peers I know about."
+	TypeAlert:            "Alert",             // network wide alerts (used in bitcoin to indicate criticalities)
+	TypeMessage:          "Message",           // Application level message
+	TypeMessagePart:      "MessagePart",       // Application level message that was split into multiple parts
+	TypeInventory:        "Inventory",         // "Here are the hashes of messages I have"
+	TypeInventoryRequest: "Inventory-Request", // "Please send me the messages with these hashes"
 }
 
 // MaxPayloadSize is the maximum bytes a message can be at the networking level.
@@ -128,6 +135,9 @@ func (p *ParcelHeader) Init(network NetworkID) *ParcelHeader {
 	p.Type = TypeMessage
 	p.TargetPeer = ""              // initially no target
 	p.PeerPort = NetworkListenPort // store our listening port
+	if port := NetworkPublicPort(); 0 < len(port) {
+		p.PeerPort = port // unless peers have to reach us on another port (eg: NAT mapping)
+	}
 	return p
 }
 
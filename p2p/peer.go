@@ -5,9 +5,10 @@
 package p2p
 
 import (
+	"encoding/binary"
 	"fmt"
 	"math/rand"
-	"strconv"
+	"net"
 	"strings"
 	"time"
 )
@@ -16,11 +17,11 @@ import (
 
 type Peer struct {
 	QualityScore int32     // 0 is neutral quality, negative is a bad peer.
-	Address      string    // Must be in form of x.x.x.x
+	Address      string    // Must be in form of x.x.x.x (IPv4) or x:x::x (IPv6, without brackets)
 	Port         string    // Must be in form of xxxx
 	NodeID       uint64    // a nonce to distinguish multiple nodes behind one IP address
 	Hash         string    // This is more of a connection ID than hash right now.
-	Location     uint32    // IP address as an int.
+	Location     uint32    // IP address as an int. (For IPv6 the leading 32 bits of the address.)
 	Network      NetworkID // The network this peer reference lives on.
 	Type         uint8
 	Connections  int                  // Number of successful connections.
@@ -34,7 +35,7 @@ const ( // iota is reset to 0
 )
 
 func (p *Peer) Init(address string, port string, quality int32, peerType uint8, connections int) *Peer {
-	p.Address = address
+	p.Address = NormalizeAddress(address)
 	p.Port = port
 	p.QualityScore = quality
 	p.generatePeerHash()
@@ -45,52 +46,75 @@ func (p *Peer) Init(address string, port string, quality int32, peerType uint8,
 	return p
 }
 
+// NormalizeAddress strips any brackets from an IPv6 literal and returns the canonical
+// form of an IP address, so the same peer is always stored under the same key.
+// Anything that does not parse as an IP (eg: a DNS name) is returned unchanged.
+func NormalizeAddress(address string) string {
+	address = strings.TrimSuffix(strings.TrimPrefix(address, "["), "]")
+	ip := net.ParseIP(address)
+	if nil == ip {
+		return address
+	}
+	return ip.String()
+}
+
+// SplitAddressPort splits "x.x.x.x:port" or "[x:x::x]:port" into a normalized address and a port
+func SplitAddressPort(addressPort string) (address string, port string, err error) {
+	address, port, err = net.SplitHostPort(addressPort)
+	if nil != err {
+		return "", "", err
+	}
+	if 0 == len(address) || 0 == len(port) {
+		return "", "", fmt.Errorf("%s is missing an address or port", addressPort)
+	}
+	return NormalizeAddress(address), port, nil
+}
+
+// IsIPv6 returns true if the peer address is an IPv6 literal
+func (p *Peer) IsIPv6() bool {
+	ip := net.ParseIP(p.Address)
+	return nil != ip && nil == ip.To4()
+}
+
 func (p *Peer) generatePeerHash() {
-	p.Hash = fmt.Sprintf("%s:%s %x", p.Address, p.Port, rand.Int63())
+	p.Hash = fmt.Sprintf("%s %x", p.AddressPort(), rand.Int63())
 }
 
+// AddressPort returns the address in a form that can be dialed, IPv6 addresses are bracketed.
 func (p *Peer) AddressPort() string {
-	return p.Address + ":" + p.Port
+	return net.JoinHostPort(p.Address, p.Port)
 }
 
 func (p *Peer) PeerIdent() string {
-	return p.Hash[0:12] + "-" + p.Address + ":" + p.Port
+	return p.Hash[0:12] + "-" + p.AddressPort()
 }
 
 func (p *Peer) PeerFixedIdent() string {
-	address := fmt.Sprintf("%16s", p.Address)
+	address := p.Address
+	if p.IsIPv6() {
+		address = "[" + address + "]"
+	}
+	address = fmt.Sprintf("%16s", address)
 	return p.Hash[0:12] + "-" + address + ":" + p.Port
 }
 
-// TODO Hadn't considered IPV6 address support.
-// TODO Need to audit all the net code to check IPv6 addresses
-// Here's an IPv6 conversion:
-// Ref: http://stackoverflow.com/questions/23297141/golang-net-ip-to-ipv6-from-mysql-as-decimal39-0-conversion
-// func ipv6ToInt(IPv6Addr net.IP) *big.Int {
-//     IPv6Int := big.NewInt(0)
-//     IPv6Int.SetBytes(IPv6Addr)
-//     return IPv6Int
-// }
-// Problem is we're working wiht string addresses, may never have made a connection.
 // TODO - we might have a DNS address, not iP address and need to resolve it!
 // locationFromAddress converts the peers address into a uint32 "location" numeric
+// For IPv4 this is the address itself. For IPv6 we use the leading 32 bits, which
+// is the routing prefix typically allocated to a network, so peers on different
+// networks still sort apart for distance purposes.
 func (p *Peer) LocationFromAddress() (location uint32) {
 	location = 0
-	// Split the IPv4 octets
-	octets := strings.Split(p.Address, ".")
-	if 4 == len(octets) {
-		// Turn into uint32
-		b0, _ := strconv.Atoi(octets[0])
-		b1, _ := strconv.Atoi(octets[1])
-		b2, _ := strconv.Atoi(octets[2])
-		b3, _ := strconv.Atoi(octets[3])
-		location += uint32(b0) << 24
-		location += uint32(b1) << 16
-		location += uint32(b2) << 8
-		location += uint32(b3)
-		verbose("peer", "Peer: %s with octets: %+v has Location: %d", p.Hash, octets, location)
-	} else {
-		silence("peer", "len(octets) != 4 \n Invalid Peer Address: %v", octets)
+	ip := net.ParseIP(p.Address)
+	switch {
+	case nil == ip:
+		silence("peer", "Invalid Peer Address: %v", p.Address)
+	case nil != ip.To4():
+		location = binary.BigEndian.Uint32(ip.To4())
+		verbose("peer", "Peer: %s with IPv4 address: %s has Location: %d", p.Hash, p.Address, location)
+	default:
+		location = binary.BigEndian.Uint32(ip.To16()[:4])
+		verbose("peer", "Peer: %s with IPv6 address: %s has Location: %d", p.Hash, p.Address, location)
 	}
 	return location
 }
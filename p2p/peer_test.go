@@ -0,0 +1,78 @@
+package p2p_test
+
+import (
+	"testing"
+
+	. "github.com/FactomProject/factomd/p2p"
+)
+
+func TestPeerLocationFromAddress(t *testing.T) {
+	peer := new(Peer).Init("1.2.3.4", "8108", 0, RegularPeer, 0)
+	if peer.Location != 0x01020304 {
+		t.Errorf("Exp location %d, got %d", 0x01020304, peer.Location)
+	}
+
+	peer = new(Peer).Init("2001:db8::1", "8108", 0, RegularPeer, 0)
+	if peer.Location != 0x20010db8 {
+		t.Errorf("Exp location %d, got %d", 0x20010db8, peer.Location)
+	}
+
+	peer = new(Peer).Init("::ffff:1.2.3.4", "8108", 0, RegularPeer, 0)
+	if peer.Location != 0x01020304 {
+		t.Errorf("IPv4 mapped address: exp location %d, got %d", 0x01020304, peer.Location)
+	}
+
+	peer = new(Peer).Init("not-an-ip", "8108", 0, RegularPeer, 0)
+	if peer.Location != 0 {
+		t.Errorf("Exp location 0 for invalid address, got %d", peer.Location)
+	}
+}
+
+func TestPeerIPv6Address(t *testing.T) {
+	peer := new(Peer).Init("[2001:DB8:0:0::1]", "8108", 0, RegularPeer, 0)
+	if peer.Address != "2001:db8::1" {
+		t.Errorf("Exp normalized address %s, got %s", "2001:db8::1", peer.Address)
+	}
+	if !peer.IsIPv6() {
+		t.Error("Expected peer to be IPv6")
+	}
+	if peer.AddressPort() != "[2001:db8::1]:8108" {
+		t.Errorf("Exp %s, got %s", "[2001:db8::1]:8108", peer.AddressPort())
+	}
+
+	peer = new(Peer).Init("1.2.3.4", "8108", 0, RegularPeer, 0)
+	if peer.IsIPv6() {
+		t.Error("Expected peer to be IPv4")
+	}
+	if peer.AddressPort() != "1.2.3.4:8108" {
+		t.Errorf("Exp %s, got %s", "1.2.3.4:8108", peer.AddressPort())
+	}
+}
+
+func TestSplitAddressPort(t *testing.T) {
+	tests := []struct {
+		in      string
+		address string
+		port    string
+		valid   bool
+	}{
+		{"127.0.0.1:8108", "127.0.0.1", "8108", true},
+		{"[::1]:8108", "::1", "8108", true},
+		{"[fe80::1:2]:1", "fe80::1:2", "1", true},
+		{"localhost:8108", "localhost", "8108", true},
+		{"::1:8108", "", "", false},
+		{"127.0.0.1", "", "", false},
+		{"127.0.0.1:", "", "", false},
+	}
+
+	for _, test := range tests {
+		address, port, err := SplitAddressPort(test.in)
+		if test.valid != (nil == err) {
+			t.Errorf("%s: expected valid %t, got error %v", test.in, test.valid, err)
+			continue
+		}
+		if address != test.address || port != test.port {
+			t.Errorf("%s: exp %s %s, got %s %s", test.in, test.address, test.port, address, port)
+		}
+	}
+}
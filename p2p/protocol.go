@@ -10,6 +10,7 @@ import (
 	"math/rand"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/FactomProject/factomd/common/primitives"
@@ -65,8 +66,16 @@ var (
 	PingInterval                         = time.Second * 15
 	TimeBetweenRedials                   = time.Second * 20
 	PeerSaveInterval                     = time.Second * 30
+	NetworkControlTimeout                = time.Second * 5 // how long the debug API waits on the Controller (see status.go)
 	PeerRequestInterval                  = time.Second * 180
 	PeerDiscoveryInterval                = time.Hour * 4
+	MinimumInventoryPayloadSize   uint32 = 1024             // broadcast messages at least this big are announced rather than flooded
+	MaxInventoryHashesPerParcel          = 1000             // most hashes we announce or request in a single parcel
+	InventoryRequestTimeout              = time.Second * 10 // if an announced message hasn't arrived by now we ask the next peer that announces it
+	InventoryExpiration                  = time.Minute * 10 // how long we keep messages to serve, and remember what we've seen
+	MaxInventorySize                     = 64 * 1024 * 1024 // most bytes of messages we keep to serve, the oldest are dropped first
+	MinimumAddressObservations           = 2                // distinct peers that must agree on our address before we believe them
+	MaxAddressObservations               = 100              // most peer observations of our address we keep
 
 	// Testing metrics
 	TotalMessagesRecieved       uint64
@@ -74,15 +83,37 @@ var (
 	ApplicationMessagesRecieved uint64
 
 	CRCKoopmanTable = crc32.MakeTable(crc32.Koopman)
-	RandomGenerator *rand.Rand // seeded pseudo-random number generator
+	RandomGenerator = rand.New(rand.NewSource(time.Now().UnixNano())) // seeded pseudo-random number generator
 
 )
 
+// The port we tell peers to dial us on, if not NetworkListenPort (eg: NAT mapping).  The Controller
+// sets it, and parcels are built with it on every goroutine.
+var (
+	networkPublicPort      = ""
+	networkPublicPortMutex sync.RWMutex
+)
+
+// NetworkPublicPort returns the port we tell peers to dial us on, or "" if it is NetworkListenPort
+func NetworkPublicPort() string {
+	networkPublicPortMutex.RLock()
+	defer networkPublicPortMutex.RUnlock()
+	return networkPublicPort
+}
+
+func setNetworkPublicPort(port string) {
+	networkPublicPortMutex.Lock()
+	defer networkPublicPortMutex.Unlock()
+	networkPublicPort = port
+}
+
 const (
 	// ProtocolVersion is the latest version this package supports
-	ProtocolVersion uint16 = 8
+	ProtocolVersion uint16 = 9
 	// ProtocolVersionMinimum is the earliest version this package supports
 	ProtocolVersionMinimum uint16 = 8
+	// ProtocolVersionInventory is the earliest version that understands inventory announcements
+	ProtocolVersionInventory uint16 = 9
 )
 
 // NetworkIdentifier represents the P2P network we are participating in (eg: test, nmain, etc.)
@@ -0,0 +1,451 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// SimNetwork is an in-process network for testing the p2p package without real sockets.
+//
+// Each node gets its own host address on the network from Host(), which it passes to its
+// Controller in ControllerInit.Transport.  Connections behave like TCP streams (ordered, reliable)
+// with these faults available:
+//
+//	-- latency (plus random jitter) on every write
+//	-- loss: the probability a write is lost.  As on a real network, a stream that loses data
+//	   is of no use, so the connection is reset at both ends.
+//	-- partitions: hosts in different partitions can't dial each other, and connections
+//	   between them are reset.
+//
+// All the randomness comes from the seed given to NewSimNetwork, so a test run can be repeated
+// (to the extent goroutine scheduling allows.)
+//
+// Note the p2p package keeps some settings in globals (eg: NetworkListenPort, CurrentNetwork),
+// so every node in a simulation should use the same port and network.
+type SimNetwork struct {
+	mutex      sync.Mutex
+	rng        *rand.Rand
+	latency    time.Duration
+	jitter     time.Duration
+	loss       float64
+	listeners  map[string]*simListener // listeners, indexed by address:port
+	partitions map[string]int          // partition of each host, hosts not listed are in partition 0
+	conns      map[*simConn]bool       // open connections (both ends)
+	nextPort   int                     // next ephemeral port to hand out
+}
+
+var (
+	errSimRefused     = errors.New("simulated network: connection refused")
+	errSimUnreachable = errors.New("simulated network: host unreachable")
+	errSimReset       = errors.New("simulated network: connection reset")
+	errSimClosed      = errors.New("simulated network: use of closed connection")
+	errSimAddressUsed = errors.New("simulated network: address already in use")
+)
+
+// simListenBacklog is how many dialed connections can wait to be accepted
+const simListenBacklog = 128
+
+// simTimeout is returned by reads that pass their deadline
+type simTimeout struct{}
+
+func (simTimeout) Error() string   { return "simulated network: i/o timeout" }
+func (simTimeout) Timeout() bool   { return true }
+func (simTimeout) Temporary() bool { return true }
+
+// NewSimNetwork creates a network with no latency, loss or partitions
+func NewSimNetwork(seed int64) *SimNetwork {
+	n := new(SimNetwork)
+	n.rng = rand.New(rand.NewSource(seed))
+	n.listeners = map[string]*simListener{}
+	n.partitions = map[string]int{}
+	n.conns = map[*simConn]bool{}
+	n.nextPort = 30000
+	return n
+}
+
+// Host returns the Transport for a node with the given address (eg: "10.0.0.1")
+func (n *SimNetwork) Host(address string) Transport {
+	return &simTransport{network: n, host: NormalizeAddress(address)}
+}
+
+// SetLatency sets how long writes take to arrive, between latency and latency+jitter
+func (n *SimNetwork) SetLatency(latency time.Duration, jitter time.Duration) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.latency = latency
+	n.jitter = jitter
+}
+
+// SetLoss sets the probability (0 to 1) that a write is lost, resetting its connection
+func (n *SimNetwork) SetLoss(probability float64) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.loss = probability
+}
+
+// Partition splits the network.  Each group of hosts can only reach hosts in the same group,
+// hosts not in any group can only reach each other.  Connections across partitions are reset.
+func (n *SimNetwork) Partition(groups ...[]string) {
+	n.mutex.Lock()
+	n.partitions = map[string]int{}
+	for i, group := range groups {
+		for _, host := range group {
+			n.partitions[NormalizeAddress(host)] = i + 1
+		}
+	}
+	broken := []*simConn{}
+	for conn := range n.conns {
+		if !n.reachable(conn.local.host, conn.remote.host) {
+			broken = append(broken, conn)
+		}
+	}
+	n.mutex.Unlock()
+	for _, conn := range broken {
+		conn.reset()
+	}
+}
+
+// Heal removes all partitions
+func (n *SimNetwork) Heal() {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.partitions = map[string]int{}
+}
+
+// Close shuts every listener and connection on the network
+func (n *SimNetwork) Close() {
+	n.mutex.Lock()
+	listeners := []*simListener{}
+	for _, listener := range n.listeners {
+		listeners = append(listeners, listener)
+	}
+	conns := []*simConn{}
+	for conn := range n.conns {
+		conns = append(conns, conn)
+	}
+	n.mutex.Unlock()
+	for _, listener := range listeners {
+		listener.Close()
+	}
+	for _, conn := range conns {
+		conn.reset()
+	}
+}
+
+// reachable must be called with the mutex held
+func (n *SimNetwork) reachable(from string, to string) bool {
+	return n.partitions[from] == n.partitions[to]
+}
+
+// delay picks the latency of a write
+func (n *SimNetwork) delay() time.Duration {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	delay := n.latency
+	if 0 < n.jitter {
+		delay += time.Duration(n.rng.Int63n(int64(n.jitter)))
+	}
+	return delay
+}
+
+// lose decides if a write is lost
+func (n *SimNetwork) lose() bool {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	return 0 < n.loss && n.rng.Float64() < n.loss
+}
+
+func (n *SimNetwork) dial(from string, address string) (net.Conn, error) {
+	host, port, err := SplitAddressPort(address)
+	if nil != err {
+		return nil, err
+	}
+	n.mutex.Lock()
+	if !n.reachable(from, host) {
+		n.mutex.Unlock()
+		return nil, errSimUnreachable
+	}
+	listener, present := n.listeners[net.JoinHostPort(host, port)]
+	if !present {
+		n.mutex.Unlock()
+		return nil, errSimRefused
+	}
+	local := simAddr{host: from, port: strconv.Itoa(n.nextPort)}
+	n.nextPort++
+	client, server := newSimConnPair(n, local, simAddr{host: host, port: port})
+	n.conns[client] = true
+	n.conns[server] = true
+	n.mutex.Unlock()
+
+	time.Sleep(n.delay()) // the handshake
+	select {
+	case <-listener.done:
+	case listener.accept <- server:
+		return client, nil
+	default: // backlog full
+	}
+	client.reset()
+	return nil, errSimRefused
+}
+
+func (n *SimNetwork) forget(conn *simConn) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	delete(n.conns, conn)
+}
+
+//////////////////////////////////////////////////////////////////////
+// Transport
+//////////////////////////////////////////////////////////////////////
+
+type simTransport struct {
+	network *SimNetwork
+	host    string
+}
+
+// Listen listens on the transport's host, whatever host is in the address
+func (t *simTransport) Listen(address string) (net.Listener, error) {
+	_, port, err := net.SplitHostPort(address)
+	if nil != err {
+		return nil, err
+	}
+	t.network.mutex.Lock()
+	defer t.network.mutex.Unlock()
+	addr := simAddr{host: t.host, port: port}
+	if _, present := t.network.listeners[addr.String()]; present {
+		return nil, errSimAddressUsed
+	}
+	listener := &simListener{network: t.network, address: addr, accept: make(chan net.Conn, simListenBacklog), done: make(chan struct{})}
+	t.network.listeners[addr.String()] = listener
+	return listener, nil
+}
+
+func (t *simTransport) Dial(address string, timeout time.Duration) (net.Conn, error) {
+	return t.network.dial(t.host, address)
+}
+
+type simAddr struct {
+	host string
+	port string
+}
+
+func (a simAddr) Network() string { return "sim" }
+func (a simAddr) String() string  { return net.JoinHostPort(a.host, a.port) }
+
+//////////////////////////////////////////////////////////////////////
+// Listener
+//////////////////////////////////////////////////////////////////////
+
+type simListener struct {
+	network *SimNetwork
+	address simAddr
+	accept  chan net.Conn
+	done    chan struct{}
+	once    sync.Once
+}
+
+func (l *simListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.accept:
+		return conn, nil
+	case <-l.done:
+		return nil, &net.OpError{Op: "accept", Net: "sim", Addr: l.address, Err: errSimClosed}
+	}
+}
+
+func (l *simListener) Close() error {
+	l.once.Do(func() {
+		close(l.done)
+		l.network.mutex.Lock()
+		delete(l.network.listeners, l.address.String())
+		l.network.mutex.Unlock()
+	})
+	return nil
+}
+
+func (l *simListener) Addr() net.Addr {
+	return l.address
+}
+
+//////////////////////////////////////////////////////////////////////
+// Connection
+//////////////////////////////////////////////////////////////////////
+
+// simChunk is a write on its way to the other end
+type simChunk struct {
+	data      []byte
+	deliverAt time.Time
+}
+
+// simConn is one end of a simulated connection.  Each end has a goroutine (deliver()) that hands
+// its writes to the other end once their latency has passed.
+type simConn struct {
+	network *SimNetwork
+	local   simAddr
+	remote  simAddr
+	peer    *simConn
+
+	incoming chan []byte   // data delivered to us
+	outgoing chan simChunk // our writes, on their way to the peer
+	eof      chan struct{} // closed once the peer closed and all its writes were delivered
+	done     chan struct{} // closed when we are closed or reset
+	doneOnce sync.Once
+
+	mutex        sync.Mutex
+	closed       bool      // no more writes, outgoing is closed
+	lastDelivery time.Time // writes are delivered in order, even with jitter
+	readDeadline time.Time
+
+	pending []byte // delivered data not yet read
+}
+
+func newSimConnPair(network *SimNetwork, clientAddr simAddr, serverAddr simAddr) (*simConn, *simConn) {
+	client := newSimConn(network, clientAddr, serverAddr)
+	server := newSimConn(network, serverAddr, clientAddr)
+	client.peer = server
+	server.peer = client
+	go client.deliver()
+	go server.deliver()
+	return client, server
+}
+
+func newSimConn(network *SimNetwork, local simAddr, remote simAddr) *simConn {
+	c := new(simConn)
+	c.network = network
+	c.local = local
+	c.remote = remote
+	c.incoming = make(chan []byte, 1024)
+	c.outgoing = make(chan simChunk, 1024)
+	c.eof = make(chan struct{})
+	c.done = make(chan struct{})
+	return c
+}
+
+// deliver moves our writes to the peer, then tells the peer there is nothing more.
+func (c *simConn) deliver() {
+	defer close(c.peer.eof)
+	for chunk := range c.outgoing {
+		wait := time.NewTimer(time.Until(chunk.deliverAt))
+		select {
+		case <-wait.C:
+		case <-c.peer.done:
+			wait.Stop()
+			continue // Drain, the peer won't read any more
+		}
+		select {
+		case c.peer.incoming <- chunk.data:
+		case <-c.peer.done:
+		}
+	}
+}
+
+func (c *simConn) Read(b []byte) (int, error) {
+	if 0 == len(c.pending) {
+		var timeout <-chan time.Time
+		c.mutex.Lock()
+		deadline := c.readDeadline
+		c.mutex.Unlock()
+		if !deadline.IsZero() {
+			timer := time.NewTimer(time.Until(deadline))
+			defer timer.Stop()
+			timeout = timer.C
+		}
+		select {
+		case c.pending = <-c.incoming:
+		case <-c.done:
+			return 0, errSimClosed
+		case <-c.eof:
+			select { // The peer closed, but there may be data left
+			case c.pending = <-c.incoming:
+			default:
+				return 0, io.EOF
+			}
+		case <-timeout:
+			return 0, simTimeout{}
+		}
+	}
+	size := copy(b, c.pending)
+	c.pending = c.pending[size:]
+	return size, nil
+}
+
+func (c *simConn) Write(b []byte) (int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.closed {
+		return 0, errSimClosed
+	}
+	c.network.mutex.Lock()
+	reachable := c.network.reachable(c.local.host, c.remote.host)
+	c.network.mutex.Unlock()
+	if !reachable || c.network.lose() {
+		go c.reset() // reset needs our mutex
+		return 0, errSimReset
+	}
+	deliverAt := time.Now().Add(c.network.delay())
+	if deliverAt.Before(c.lastDelivery) {
+		deliverAt = c.lastDelivery
+	}
+	c.lastDelivery = deliverAt
+	data := make([]byte, len(b))
+	copy(data, b)
+	select {
+	case c.outgoing <- simChunk{data: data, deliverAt: deliverAt}:
+		return len(b), nil
+	case <-c.done:
+		return 0, errSimClosed
+	}
+}
+
+// Close stops our reads and writes.  Writes already made are still delivered before the peer sees EOF.
+func (c *simConn) Close() error {
+	c.doneOnce.Do(func() { close(c.done) })
+	c.mutex.Lock()
+	if !c.closed {
+		c.closed = true
+		close(c.outgoing)
+	}
+	c.mutex.Unlock()
+	c.network.forget(c)
+	return nil
+}
+
+// reset kills both ends of the connection immediately
+func (c *simConn) reset() {
+	c.peer.doneOnce.Do(func() { close(c.peer.done) })
+	c.Close()
+	c.peer.Close()
+}
+
+func (c *simConn) LocalAddr() net.Addr  { return c.local }
+func (c *simConn) RemoteAddr() net.Addr { return c.remote }
+
+func (c *simConn) SetDeadline(t time.Time) error {
+	return c.SetReadDeadline(t)
+}
+
+func (c *simConn) SetReadDeadline(t time.Time) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.readDeadline = t
+	return nil
+}
+
+// SetWriteDeadline is accepted but ignored, simulated writes only block if the peer stops reading
+func (c *simConn) SetWriteDeadline(t time.Time) error {
+	return nil
+}
+
+func (c *simConn) String() string {
+	return fmt.Sprintf("sim(%s->%s)", c.local, c.remote)
+}
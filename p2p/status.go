@@ -0,0 +1,234 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Peer status and control for the debug API.
+//
+// These calls go to the Controller's runloop over the command channel like everything else, but wait
+// for an answer so the caller learns the outcome.  They time out after NetworkControlTimeout if the
+// runloop is busy (or not running.)
+
+// PeerStatus describes one connection
+type PeerStatus struct {
+	Hash             string
+	Address          string
+	Port             string
+	IsOutgoing       bool
+	IsPersistent     bool
+	IsSpecial        bool
+	State            string
+	QualityScore     int32
+	BytesSent        uint32
+	BytesReceived    uint32
+	MessagesSent     uint32
+	MessagesReceived uint32
+	PingLatency      time.Duration // round trip time of our last ping, 0 if we haven't pinged yet
+	ProtocolVersion  uint16        // 0 until the peer sends us something
+	ConnectedSince   time.Time
+	LastMessage      time.Time
+	Notes            string
+}
+
+// NetworkStatus is what the Controller reports about its connections
+type NetworkStatus struct {
+	Connections   []PeerStatus
+	Banned        []string // banned addresses
+	PublicAddress string
+}
+
+const (
+	peerControlDial = iota
+	peerControlDisconnect
+	peerControlBan
+	peerControlUnban
+)
+
+// commandPeerControl asks the runloop to act on a peer, the result goes back on reply
+type commandPeerControl struct {
+	action     int
+	peer       string // address, address:port or peer hash depending on the action
+	persistent bool
+	reply      chan error
+}
+
+// commandNetworkStatus asks the runloop for a NetworkStatus
+type commandNetworkStatus struct {
+	reply chan *NetworkStatus
+}
+
+// GetNetworkStatus returns a *NetworkStatus describing every connection
+func (c *Controller) GetNetworkStatus() (interface{}, error) {
+	reply := make(chan *NetworkStatus, 1)
+	BlockFreeChannelSend(c.commandChannel, commandNetworkStatus{reply: reply})
+	select {
+	case status := <-reply:
+		return status, nil
+	case <-time.After(NetworkControlTimeout):
+		return nil, fmt.Errorf("The network controller did not respond")
+	}
+}
+
+// DialPeerAddress connects to address:port.  Persistent connections are redialed if they drop.
+func (c *Controller) DialPeerAddress(address string, persistent bool) error {
+	return c.peerControl(commandPeerControl{action: peerControlDial, peer: address, persistent: persistent})
+}
+
+// DisconnectPeer closes the connection to a peer, given by hash or address
+func (c *Controller) DisconnectPeer(peer string) error {
+	return c.peerControl(commandPeerControl{action: peerControlDisconnect, peer: peer})
+}
+
+// BanPeer disconnects a peer (by hash or address) and refuses connections to and from its address until it is unbanned
+func (c *Controller) BanPeer(peer string) error {
+	return c.peerControl(commandPeerControl{action: peerControlBan, peer: peer})
+}
+
+// UnbanPeer lifts the ban on an address
+func (c *Controller) UnbanPeer(address string) error {
+	return c.peerControl(commandPeerControl{action: peerControlUnban, peer: address})
+}
+
+func (c *Controller) peerControl(command commandPeerControl) error {
+	command.reply = make(chan error, 1)
+	BlockFreeChannelSend(c.commandChannel, command)
+	select {
+	case err := <-command.reply:
+		return err
+	case <-time.After(NetworkControlTimeout):
+		return fmt.Errorf("The network controller did not respond")
+	}
+}
+
+//////////////////////////////////////////////////////////////////////
+// Runloop side
+//////////////////////////////////////////////////////////////////////
+
+func (c *Controller) networkStatus() *NetworkStatus {
+	status := &NetworkStatus{Connections: []PeerStatus{}, Banned: []string{}, PublicAddress: c.discovery.PublicAddress()}
+	for hash, connection := range c.connections {
+		metrics := c.connectionMetrics[hash]
+		status.Connections = append(status.Connections, PeerStatus{
+			Hash:             hash,
+			Address:          connection.peer.Address,
+			Port:             connection.peer.Port,
+			IsOutgoing:       connection.IsOutGoing(),
+			IsPersistent:     connection.isPersistent,
+			IsSpecial:        SpecialPeer == connection.peer.Type,
+			State:            metrics.ConnectionState,
+			QualityScore:     metrics.PeerQuality,
+			BytesSent:        metrics.BytesSent,
+			BytesReceived:    metrics.BytesReceived,
+			MessagesSent:     metrics.MessagesSent,
+			MessagesReceived: metrics.MessagesReceived,
+			PingLatency:      metrics.PingLatency,
+			ProtocolVersion:  c.protocolVersions[hash],
+			ConnectedSince:   metrics.MomentConnected,
+			LastMessage:      metrics.LastMessage,
+			Notes:            metrics.ConnectionNotes,
+		})
+	}
+	sort.Slice(status.Connections, func(i, j int) bool { return status.Connections[i].Hash < status.Connections[j].Hash })
+	for address := range c.bannedAddresses {
+		status.Banned = append(status.Banned, address)
+	}
+	sort.Strings(status.Banned)
+	return status
+}
+
+func (c *Controller) handlePeerControl(command commandPeerControl) error {
+	switch command.action {
+	case peerControlDial:
+		address, port, err := SplitAddressPort(command.peer)
+		if nil != err {
+			return err
+		}
+		if c.isBanned(address) {
+			return fmt.Errorf("%s is banned", address)
+		}
+		for _, connection := range c.connections {
+			if address == connection.peer.Address && port == connection.peer.Port {
+				return fmt.Errorf("Already connected to %s", command.peer)
+			}
+		}
+		peer := new(Peer).Init(address, port, 0, RegularPeer, 0)
+		peer.Source["Debug-API"] = time.Now()
+		c.handleCommand(CommandDialPeer{peer: *peer, persistent: command.persistent})
+	case peerControlDisconnect:
+		connections := c.findConnections(command.peer)
+		if 0 == len(connections) {
+			return fmt.Errorf("Not connected to %s", command.peer)
+		}
+		for _, connection := range connections {
+			BlockFreeChannelSend(connection.SendChannel, ConnectionCommand{Command: ConnectionShutdownNow})
+		}
+	case peerControlBan:
+		address := command.peer
+		if connections := c.findConnections(command.peer); 0 < len(connections) {
+			address = connections[0].peer.Address
+		} else if host, _, err := SplitAddressPort(command.peer); nil == err {
+			address = host
+		}
+		c.ban(NormalizeAddress(address))
+	case peerControlUnban:
+		address := command.peer
+		if host, _, err := SplitAddressPort(command.peer); nil == err {
+			address = host
+		}
+		address = NormalizeAddress(address)
+		if !c.isBanned(address) {
+			return fmt.Errorf("%s is not banned", address)
+		}
+		delete(c.bannedAddresses, address)
+		if c.discovery.isPeerPresent(Peer{Address: address}) {
+			peer := c.discovery.getPeer(address)
+			peer.QualityScore = 0 // A fresh start
+			c.discovery.updatePeer(peer)
+		}
+		significant("ctrlr", "Controller.handlePeerControl() unbanned %s", address)
+	default:
+		return fmt.Errorf("Unknown peer control action: %d", command.action)
+	}
+	return nil
+}
+
+// findConnections returns the connection with the peer hash, or all the connections to an address (or address:port)
+func (c *Controller) findConnections(peer string) []*Connection {
+	if connection, present := c.connections[peer]; present {
+		return []*Connection{connection}
+	}
+	address, port, err := SplitAddressPort(peer)
+	if nil != err {
+		address, port = NormalizeAddress(peer), ""
+	}
+	found := []*Connection{}
+	for _, connection := range c.connections {
+		if address == connection.peer.Address && ("" == port || port == connection.peer.Port) {
+			found = append(found, connection)
+		}
+	}
+	return found
+}
+
+// ban refuses the address from now on, and drops any connections to it
+func (c *Controller) ban(address string) {
+	significant("ctrlr", "Controller.ban() banning %s", address)
+	c.bannedAddresses[address] = time.Now()
+	for hash, connection := range c.connections {
+		if address == connection.peer.Address {
+			c.applicationPeerUpdate(BannedQualityScore, hash)
+		}
+	}
+}
+
+func (c *Controller) isBanned(address string) bool {
+	_, banned := c.bannedAddresses[address]
+	return banned
+}
@@ -0,0 +1,117 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package p2p_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/FactomProject/factomd/p2p"
+)
+
+// peerStatus returns what the controller reports about its connection to address, if it has one
+func peerStatus(t *testing.T, node *simNode, address string) (PeerStatus, bool) {
+	status, err := node.controller.GetNetworkStatus()
+	if nil != err {
+		t.Fatalf("GetNetworkStatus() got %v", err)
+	}
+	for _, peer := range status.(*NetworkStatus).Connections {
+		if address == peer.Address {
+			return peer, true
+		}
+	}
+	return PeerStatus{}, false
+}
+
+func TestNetworkStatus(t *testing.T) {
+	dir, restore := simulationSettings(t)
+	defer restore()
+	network := NewSimNetwork(11)
+	defer network.Close()
+
+	b := startSimNode(network, dir, "10.0.3.2", "")
+	a := startSimNode(network, dir, "10.0.3.1", "10.0.3.2:8108")
+	defer a.controller.NetworkStop()
+	defer b.controller.NetworkStop()
+	waitFor(t, "a to connect to b", 10*time.Second, func() bool { return a.isConnectedTo("10.0.3.2") })
+
+	waitFor(t, "a to measure b", 10*time.Second, func() bool {
+		peer, present := peerStatus(t, a, "10.0.3.2")
+		return present && "Online" == peer.State && 0 < peer.PingLatency && 0 < peer.ProtocolVersion && !peer.LastMessage.IsZero()
+	})
+	peer, _ := peerStatus(t, a, "10.0.3.2")
+	if !peer.IsOutgoing || !peer.IsPersistent || !peer.IsSpecial || "8108" != peer.Port {
+		t.Errorf("GetNetworkStatus() got %+v", peer)
+	}
+}
+
+func TestPeerControl(t *testing.T) {
+	dir, restore := simulationSettings(t)
+	defer restore()
+	network := NewSimNetwork(12)
+	defer network.Close()
+
+	a := startSimNode(network, dir, "10.0.4.1", "")
+	b := startSimNode(network, dir, "10.0.4.2", "")
+	defer a.controller.NetworkStop()
+	defer b.controller.NetworkStop()
+	OnlySpecialPeers = true // So only the calls below make connections
+
+	if err := a.controller.DialPeerAddress("10.0.4.2:8108", false); nil != err {
+		t.Fatalf("DialPeerAddress() got %v", err)
+	}
+	waitFor(t, "a to connect to b", 10*time.Second, func() bool { return a.isConnectedTo("10.0.4.2") })
+	if err := a.controller.DialPeerAddress("10.0.4.2:8108", false); nil == err {
+		t.Errorf("DialPeerAddress() should refuse a second connection")
+	}
+	if err := a.controller.DialPeerAddress("10.0.4.2", false); nil == err {
+		t.Errorf("DialPeerAddress() should require a port")
+	}
+
+	if err := a.controller.DisconnectPeer("10.0.4.2"); nil != err {
+		t.Fatalf("DisconnectPeer() got %v", err)
+	}
+	waitFor(t, "a to drop b", 10*time.Second, func() bool { _, present := peerStatus(t, a, "10.0.4.2"); return !present })
+	waitFor(t, "b to notice", 10*time.Second, func() bool { _, present := peerStatus(t, b, "10.0.4.1"); return !present })
+	if err := a.controller.DisconnectPeer("10.0.4.2"); nil == err {
+		t.Errorf("DisconnectPeer() should fail without a connection")
+	}
+
+	if err := a.controller.BanPeer("10.0.4.2"); nil != err {
+		t.Fatalf("BanPeer() got %v", err)
+	}
+	status, _ := a.controller.GetNetworkStatus()
+	if banned := status.(*NetworkStatus).Banned; 1 != len(banned) || "10.0.4.2" != banned[0] {
+		t.Errorf("GetNetworkStatus() got banned %v", banned)
+	}
+	if err := a.controller.DialPeerAddress("10.0.4.2:8108", false); nil == err {
+		t.Errorf("DialPeerAddress() should refuse a banned address")
+	}
+	if err := b.controller.DialPeerAddress("10.0.4.1:8108", false); nil != err {
+		t.Fatalf("DialPeerAddress() got %v", err)
+	}
+	// b redials each time a drops the connection, so after a few rounds a has refused b several times
+	waitFor(t, "a to refuse b", 10*time.Second, func() bool { peer, _ := peerStatus(t, b, "10.0.4.1"); return 10 <= peer.MessagesSent })
+	if peer, _ := peerStatus(t, b, "10.0.4.1"); 0 < peer.MessagesReceived {
+		t.Errorf("b got messages from the address that banned it")
+	}
+	if _, present := peerStatus(t, a, "10.0.4.2"); present {
+		t.Errorf("a accepted a connection from the address it banned")
+	}
+
+	b.controller.DisconnectPeer("10.0.4.1") // So it doesn't get in ahead of a's dial below
+	waitFor(t, "b to stop dialing a", 10*time.Second, func() bool { _, present := peerStatus(t, b, "10.0.4.1"); return !present })
+
+	if err := a.controller.UnbanPeer("10.0.4.2"); nil != err {
+		t.Fatalf("UnbanPeer() got %v", err)
+	}
+	if err := a.controller.UnbanPeer("10.0.4.2"); nil == err {
+		t.Errorf("UnbanPeer() should fail for an address that is not banned")
+	}
+	if err := a.controller.DialPeerAddress("10.0.4.2:8108", false); nil != err {
+		t.Fatalf("DialPeerAddress() after unbanning got %v", err)
+	}
+	waitFor(t, "a to connect to b again", 10*time.Second, func() bool { return a.isConnectedTo("10.0.4.2") })
+}
@@ -0,0 +1,28 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"net"
+	"time"
+)
+
+// Transport is how the Controller and its Connections reach the network.  Normally this is TCP,
+// tests can plug in a simulated network instead (see SimNetwork in simulator.go).
+type Transport interface {
+	Listen(address string) (net.Listener, error)
+	Dial(address string, timeout time.Duration) (net.Conn, error)
+}
+
+// TCPTransport is the real network
+type TCPTransport struct{}
+
+func (TCPTransport) Listen(address string) (net.Listener, error) {
+	return net.Listen("tcp", address)
+}
+
+func (TCPTransport) Dial(address string, timeout time.Duration) (net.Conn, error) {
+	return net.DialTimeout("tcp", address, timeout)
+}
@@ -0,0 +1,240 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UPnP Internet Gateway Device client.  The router is found with an SSDP multicast search,
+// its description tells us where to send SOAP requests for the WAN connection service.
+
+const (
+	ssdpAddress        = "239.255.255.250:1900"
+	upnpDeviceType     = "urn:schemas-upnp-org:device:InternetGatewayDevice:1"
+	upnpWANIPService   = "urn:schemas-upnp-org:service:WANIPConnection:1"
+	upnpWANPPPService  = "urn:schemas-upnp-org:service:WANPPPConnection:1"
+	upnpRequestTimeout = 5 * time.Second
+)
+
+// UPnP talks to the WAN connection service of an Internet Gateway Device
+type UPnP struct {
+	controlURL  string // where SOAP requests go
+	serviceType string // WANIPConnection or WANPPPConnection
+	localIP     net.IP // our address on the router's network
+	client      http.Client
+}
+
+var _ NATPortMapper = (*UPnP)(nil)
+
+// upnpDevice is the part of the device description we care about
+type upnpDevice struct {
+	DeviceType string        `xml:"deviceType"`
+	Services   []upnpService `xml:"serviceList>service"`
+	Devices    []upnpDevice  `xml:"deviceList>device"`
+}
+
+type upnpService struct {
+	ServiceType string `xml:"serviceType"`
+	ControlURL  string `xml:"controlURL"`
+}
+
+type upnpRoot struct {
+	URLBase string     `xml:"URLBase"`
+	Device  upnpDevice `xml:"device"`
+}
+
+// DiscoverUPnP searches the local network for an Internet Gateway Device
+func DiscoverUPnP(timeout time.Duration) (*UPnP, error) {
+	conn, err := net.ListenPacket("udp4", ":0")
+	if nil != err {
+		return nil, err
+	}
+	defer conn.Close()
+	ssdp, err := net.ResolveUDPAddr("udp4", ssdpAddress)
+	if nil != err {
+		return nil, err
+	}
+	search := "M-SEARCH * HTTP/1.1\r\n" +
+		"HOST: " + ssdpAddress + "\r\n" +
+		"ST: " + upnpDeviceType + "\r\n" +
+		"MAN: \"ssdp:discover\"\r\n" +
+		"MX: 2\r\n\r\n"
+	if _, err = conn.WriteTo([]byte(search), ssdp); nil != err {
+		return nil, err
+	}
+
+	conn.SetReadDeadline(time.Now().Add(timeout))
+	buffer := make([]byte, 2048)
+	for {
+		size, _, err := conn.ReadFrom(buffer)
+		if nil != err {
+			return nil, fmt.Errorf("No UPnP gateway found: %v", err)
+		}
+		response, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(buffer[:size])), nil)
+		if nil != err {
+			continue
+		}
+		location := response.Header.Get("Location")
+		if 0 == len(location) || !strings.Contains(response.Header.Get("St"), "InternetGatewayDevice") {
+			continue
+		}
+		upnp, err := NewUPnP(location)
+		if nil != err {
+			note("nat", "DiscoverUPnP() skipping %s: %v", location, err)
+			continue
+		}
+		return upnp, nil
+	}
+}
+
+// NewUPnP creates a client from the device description found at location
+func NewUPnP(location string) (*UPnP, error) {
+	upnp := &UPnP{client: http.Client{Timeout: upnpRequestTimeout}}
+	response, err := upnp.client.Get(location)
+	if nil != err {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if http.StatusOK != response.StatusCode {
+		return nil, fmt.Errorf("Device description returned %s", response.Status)
+	}
+	var root upnpRoot
+	if err = xml.NewDecoder(response.Body).Decode(&root); nil != err {
+		return nil, err
+	}
+	service := findWANService(root.Device)
+	if nil == service {
+		return nil, fmt.Errorf("Device has no WAN connection service")
+	}
+
+	base := location
+	if 0 < len(root.URLBase) {
+		base = root.URLBase
+	}
+	baseURL, err := url.Parse(base)
+	if nil != err {
+		return nil, err
+	}
+	controlURL, err := baseURL.Parse(service.ControlURL)
+	if nil != err {
+		return nil, err
+	}
+	upnp.controlURL = controlURL.String()
+	upnp.serviceType = service.ServiceType
+
+	upnp.localIP, err = localAddressFor(controlURL.Hostname())
+	if nil != err {
+		return nil, err
+	}
+	return upnp, nil
+}
+
+// findWANService walks the device tree looking for the service that can map ports
+func findWANService(device upnpDevice) *upnpService {
+	for i, service := range device.Services {
+		if upnpWANIPService == service.ServiceType || upnpWANPPPService == service.ServiceType {
+			return &device.Services[i]
+		}
+	}
+	for _, child := range device.Devices {
+		if service := findWANService(child); nil != service {
+			return service
+		}
+	}
+	return nil
+}
+
+func (u *UPnP) String() string {
+	return "UPnP(" + u.controlURL + ")"
+}
+
+func (u *UPnP) ExternalAddress() (net.IP, error) {
+	var response struct {
+		Address string `xml:"Body>GetExternalIPAddressResponse>NewExternalIPAddress"`
+	}
+	if err := u.soap("GetExternalIPAddress", "", &response); nil != err {
+		return nil, err
+	}
+	ip := net.ParseIP(strings.TrimSpace(response.Address))
+	if nil == ip {
+		return nil, fmt.Errorf("Invalid external address from gateway: %q", response.Address)
+	}
+	return ip, nil
+}
+
+// AddPortMapping asks for the requested external port.  UPnP gateways either grant it or fail,
+// so the returned port is always the one asked for.
+func (u *UPnP) AddPortMapping(protocol string, internalPort int, externalPort int, description string, lifetime time.Duration) (int, error) {
+	arguments := "<NewRemoteHost></NewRemoteHost>" +
+		"<NewExternalPort>" + strconv.Itoa(externalPort) + "</NewExternalPort>" +
+		"<NewProtocol>" + strings.ToUpper(protocol) + "</NewProtocol>" +
+		"<NewInternalPort>" + strconv.Itoa(internalPort) + "</NewInternalPort>" +
+		"<NewInternalClient>" + u.localIP.String() + "</NewInternalClient>" +
+		"<NewEnabled>1</NewEnabled>" +
+		"<NewPortMappingDescription>" + xmlEscape(description) + "</NewPortMappingDescription>" +
+		"<NewLeaseDuration>" + strconv.Itoa(int(lifetime/time.Second)) + "</NewLeaseDuration>"
+	if err := u.soap("AddPortMapping", arguments, nil); nil != err {
+		return 0, err
+	}
+	return externalPort, nil
+}
+
+func (u *UPnP) DeletePortMapping(protocol string, internalPort int, externalPort int) error {
+	arguments := "<NewRemoteHost></NewRemoteHost>" +
+		"<NewExternalPort>" + strconv.Itoa(externalPort) + "</NewExternalPort>" +
+		"<NewProtocol>" + strings.ToUpper(protocol) + "</NewProtocol>"
+	return u.soap("DeletePortMapping", arguments, nil)
+}
+
+// soap sends an action to the gateway, decoding the response envelope into result if it is not nil
+func (u *UPnP) soap(action string, arguments string, result interface{}) error {
+	envelope := `<?xml version="1.0"?>` +
+		`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">` +
+		`<s:Body><u:` + action + ` xmlns:u="` + u.serviceType + `">` + arguments + `</u:` + action + `></s:Body></s:Envelope>`
+	request, err := http.NewRequest("POST", u.controlURL, strings.NewReader(envelope))
+	if nil != err {
+		return err
+	}
+	request.Header.Set("Content-Type", `text/xml; charset="utf-8"`)
+	request.Header.Set("SOAPAction", `"`+u.serviceType+"#"+action+`"`)
+	response, err := u.client.Do(request)
+	if nil != err {
+		return err
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	if nil != err {
+		return err
+	}
+	if http.StatusOK != response.StatusCode {
+		var fault struct {
+			Code        string `xml:"Body>Fault>detail>UPnPError>errorCode"`
+			Description string `xml:"Body>Fault>detail>UPnPError>errorDescription"`
+		}
+		xml.Unmarshal(body, &fault)
+		return fmt.Errorf("UPnP %s failed: %s %s %s", action, response.Status, fault.Code, fault.Description)
+	}
+	if nil != result {
+		return xml.Unmarshal(body, result)
+	}
+	return nil
+}
+
+func xmlEscape(s string) string {
+	var buffer bytes.Buffer
+	xml.EscapeText(&buffer, []byte(s))
+	return buffer.String()
+}
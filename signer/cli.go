@@ -0,0 +1,169 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/FactomProject/factomd/common/primitives"
+	"github.com/FactomProject/factomd/util"
+)
+
+const keystoreUsage = `usage: factomd keystore <command> [-keystore path] [-name name]
+
+commands:
+  create   generate a new key
+  import   import a key, typed in or from the LocalServerPrivKey of -config
+  export   print a key in hex
+  rotate   generate a new key, keeping the old one as <name>.previous
+  passwd   change the password of a key
+  list     list the keys and their public keys
+
+The password is read from $FACTOMD_KEYSTORE_PASSWORD, or asked for on the terminal.
+`
+
+// DefaultKeystore returns where the keystore is kept unless told otherwise
+func DefaultKeystore() string {
+	return util.GetHomeDir() + "/.factom/m2/identity.keystore"
+}
+
+// KeystoreCommand runs the factomd keystore subcommand with the arguments after
+// "keystore", writing its results to out.
+func KeystoreCommand(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%s", keystoreUsage)
+	}
+	command := args[0]
+
+	flags := flag.NewFlagSet("keystore "+command, flag.ContinueOnError)
+	path := flags.String("keystore", DefaultKeystore(), "The keystore file")
+	name := flags.String("name", IdentityKey, "The name of the key")
+	config := flags.String("config", "", "import: the factomd.conf to import LocalServerPrivKey from")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	// Only create and import may make a new keystore
+	ks, err := ReadKeystore(*path)
+	if os.IsNotExist(err) && (command == "create" || command == "import") {
+		ks, err = NewKeystore(), nil
+	}
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case "create":
+		key := new(primitives.PrivateKey)
+		if err := key.GenerateKey(); err != nil {
+			return err
+		}
+		return putNewKey(out, ks, *path, *name, key)
+
+	case "import":
+		var hexKey string
+		if *config != "" {
+			hexKey = util.ReadConfig(*config).App.LocalServerPrivKey
+		} else {
+			secret, err := ReadSecret("Private key (hex): ")
+			if err != nil {
+				return err
+			}
+			hexKey = strings.TrimSpace(string(secret))
+		}
+		key, err := primitives.NewPrivateKeyFromHex(hexKey)
+		if err != nil {
+			return err
+		}
+		return putNewKey(out, ks, *path, *name, key)
+
+	case "export":
+		key, _, err := openKey(ks, *path, *name)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, key.PrivateKeyString())
+		return nil
+
+	case "rotate":
+		old, password, err := openKey(ks, *path, *name)
+		if err != nil {
+			return err
+		}
+		key := new(primitives.PrivateKey)
+		if err := key.GenerateKey(); err != nil {
+			return err
+		}
+		if err := ks.PutKey(*name+".previous", old, password); err != nil {
+			return err
+		}
+		if err := ks.PutKey(*name, key, password); err != nil {
+			return err
+		}
+		if err := ks.Write(*path); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "%s %s\n", *name, key.PublicKeyString())
+		fmt.Fprintf(out, "The old key is kept as %s.previous.  Register the new key with the identity before restarting.\n", *name)
+		return nil
+
+	case "passwd":
+		key, _, err := openKey(ks, *path, *name)
+		if err != nil {
+			return err
+		}
+		password, err := Password(*path, true)
+		if err != nil {
+			return err
+		}
+		if err := ks.PutKey(*name, key, password); err != nil {
+			return err
+		}
+		return ks.Write(*path)
+
+	case "list":
+		for _, n := range ks.Names() {
+			fmt.Fprintf(out, "%-20s %s\n", n, ks.Keys[n].PublicKey)
+		}
+		return nil
+	}
+	return fmt.Errorf("Unknown keystore command %q\n%s", command, keystoreUsage)
+}
+
+// openKey asks for the password and decrypts the named key
+func openKey(ks *Keystore, path, name string) (*primitives.PrivateKey, []byte, error) {
+	if ks.Keys[name] == nil {
+		return nil, nil, fmt.Errorf("No key %q in keystore %s", name, path)
+	}
+	password, err := Password(path, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := ks.Key(name, password)
+	return key, password, err
+}
+
+// putNewKey adds the key under a name that isn't taken, and writes the keystore
+func putNewKey(out io.Writer, ks *Keystore, path, name string, key *primitives.PrivateKey) error {
+	if ks.Keys[name] != nil {
+		return fmt.Errorf("Keystore %s already has a key %q; use rotate to replace it", path, name)
+	}
+	password, err := Password(path, true)
+	if err != nil {
+		return err
+	}
+	if err := ks.PutKey(name, key, password); err != nil {
+		return err
+	}
+	if err := ks.Write(path); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%s %s\n", name, key.PublicKeyString())
+	return nil
+}
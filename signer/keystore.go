@@ -0,0 +1,208 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/FactomProject/factomd/common/primitives"
+	"golang.org/x/crypto/scrypt"
+)
+
+// The name of the server identity key in a keystore
+const IdentityKey = "identity"
+
+// The scrypt parameters of new keys
+var (
+	ScryptN = 1 << 15
+	ScryptR = 8
+	ScryptP = 1
+)
+
+const keystoreVersion = 1
+
+// Keystore is a file of named ed25519 keys, each encrypted with AES-256-GCM under a
+// key derived from its password with scrypt.  The public keys are in the clear, and
+// are authenticated with the private keys.
+type Keystore struct {
+	Version int                  `json:"version"`
+	Keys    map[string]*KeyEntry `json:"keys"`
+}
+
+type KeyEntry struct {
+	PublicKey  string    `json:"publickey"`
+	KDF        KDFParams `json:"kdf"`
+	Nonce      string    `json:"nonce"`
+	CipherText string    `json:"ciphertext"`
+}
+
+type KDFParams struct {
+	Name string `json:"name"`
+	Salt string `json:"salt"`
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+}
+
+func NewKeystore() *Keystore {
+	ks := new(Keystore)
+	ks.Version = keystoreVersion
+	ks.Keys = make(map[string]*KeyEntry)
+	return ks
+}
+
+// ReadKeystore reads the keystore file at path
+func ReadKeystore(path string) (*Keystore, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ks := new(Keystore)
+	if err := json.Unmarshal(data, ks); err != nil {
+		return nil, fmt.Errorf("Invalid keystore %s: %v", path, err)
+	}
+	if ks.Version != keystoreVersion {
+		return nil, fmt.Errorf("Keystore %s has unknown version %d", path, ks.Version)
+	}
+	if ks.Keys == nil {
+		ks.Keys = make(map[string]*KeyEntry)
+	}
+	return ks, nil
+}
+
+// Write writes the keystore to path, readable only by its owner.  The file is
+// replaced whole, so a failed write leaves the old keystore.
+func (ks *Keystore) Write(path string) error {
+	data, err := json.MarshalIndent(ks, "", "\t")
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Names returns the names of the keys, sorted
+func (ks *Keystore) Names() []string {
+	var names []string
+	for name := range ks.Keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PutKey encrypts the key with the password and stores it under the name,
+// replacing any key of that name.
+func (ks *Keystore) PutKey(name string, key *primitives.PrivateKey, password []byte) error {
+	if len(password) == 0 {
+		return fmt.Errorf("The password is empty")
+	}
+	entry := new(KeyEntry)
+	entry.PublicKey = hex.EncodeToString(key.Public())
+
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	entry.KDF = KDFParams{Name: "scrypt", Salt: hex.EncodeToString(salt), N: ScryptN, R: ScryptR, P: ScryptP}
+	aead, err := entry.cipher(password)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	entry.Nonce = hex.EncodeToString(nonce)
+	entry.CipherText = hex.EncodeToString(aead.Seal(nil, nonce, key.Key[:32], key.Public()))
+
+	ks.Keys[name] = entry
+	return nil
+}
+
+// Key decrypts the named key with the password
+func (ks *Keystore) Key(name string, password []byte) (*primitives.PrivateKey, error) {
+	entry := ks.Keys[name]
+	if entry == nil {
+		return nil, fmt.Errorf("No key %q in the keystore", name)
+	}
+	pub, err := hex.DecodeString(entry.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hex.DecodeString(entry.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := hex.DecodeString(entry.CipherText)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := entry.cipher(password)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("Key %q has a bad nonce", name)
+	}
+	seed, err := aead.Open(nil, nonce, sealed, pub)
+	if err != nil {
+		return nil, fmt.Errorf("Wrong password for key %q, or the keystore is corrupt", name)
+	}
+	return primitives.NewPrivateKeyFromHex(hex.EncodeToString(seed))
+}
+
+// PublicKey returns the public key of the named key, without its password
+func (ks *Keystore) PublicKey(name string) ([]byte, error) {
+	entry := ks.Keys[name]
+	if entry == nil {
+		return nil, fmt.Errorf("No key %q in the keystore", name)
+	}
+	return hex.DecodeString(entry.PublicKey)
+}
+
+// cipher returns the AEAD that encrypts the key, using the key derived from the password
+func (entry *KeyEntry) cipher(password []byte) (cipher.AEAD, error) {
+	if entry.KDF.Name != "scrypt" {
+		return nil, fmt.Errorf("Unknown key derivation %q", entry.KDF.Name)
+	}
+	salt, err := hex.DecodeString(entry.KDF.Salt)
+	if err != nil {
+		return nil, err
+	}
+	key, err := scrypt.Key(password, salt, entry.KDF.N, entry.KDF.R, entry.KDF.P, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
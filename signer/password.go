@@ -0,0 +1,77 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+var stdin = bufio.NewReader(os.Stdin)
+
+// isTerminal is true if standard input is a terminal
+func isTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// ReadSecret prompts for a line on standard input, without echoing it to a terminal
+func ReadSecret(prompt string) ([]byte, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	if isTerminal() {
+		echo := func(on bool) {
+			arg := "-echo"
+			if on {
+				arg = "echo"
+			}
+			cmd := exec.Command("stty", arg)
+			cmd.Stdin = os.Stdin
+			cmd.Run()
+		}
+		echo(false)
+		defer fmt.Fprintln(os.Stderr)
+		defer echo(true)
+	}
+	line, err := stdin.ReadString('\n')
+	if err != nil && line == "" {
+		return nil, err
+	}
+	return []byte(strings.TrimRight(line, "\r\n")), nil
+}
+
+// Password returns the password of the keystore at path, from $FACTOMD_KEYSTORE_PASSWORD
+// if it is set, and otherwise by asking on the terminal.  A new password is asked for twice.
+func Password(path string, isNew bool) ([]byte, error) {
+	if password := os.Getenv(PasswordEnv); password != "" {
+		return []byte(password), nil
+	}
+	if !isTerminal() {
+		return nil, fmt.Errorf("No password for keystore %s in $%s", path, PasswordEnv)
+	}
+	prompt := "Password for keystore " + path + ": "
+	if isNew {
+		prompt = "New password for keystore " + path + ": "
+	}
+	password, err := ReadSecret(prompt)
+	if err != nil {
+		return nil, err
+	}
+	if len(password) == 0 {
+		return nil, fmt.Errorf("The password is empty")
+	}
+	if isNew {
+		again, err := ReadSecret("Repeat the password: ")
+		if err != nil {
+			return nil, err
+		}
+		if string(again) != string(password) {
+			return nil, fmt.Errorf("The passwords don't match")
+		}
+	}
+	return password, nil
+}
@@ -0,0 +1,218 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/FactomProject/ed25519"
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/common/primitives"
+)
+
+/**************************************
+ * Remote signer protocol
+ *
+ * A remote signer holds the key in another process, listening on a local
+ * socket.  Each request and response is a line of JSON:
+ *
+ *   {"method":"public"}              -> {"result":"<public key hex>"}
+ *   {"method":"sign","data":"<hex>"} -> {"result":"<signature hex>"}
+ *
+ * and on failure the response is {"error":"<message>"}.
+ **************************************/
+
+// How long a remote signer has to answer a request
+var RemoteTimeout = 5 * time.Second
+
+type remoteRequest struct {
+	Method string `json:"method"`
+	Data   string `json:"data,omitempty"`
+}
+
+type remoteResponse struct {
+	Result string `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// RemoteSigner signs with a key held by a remote signer.  It reconnects if the
+// connection is lost.
+type RemoteSigner struct {
+	mutex   sync.Mutex
+	network string
+	address string
+	conn    net.Conn
+	reader  *bufio.Reader
+	pub     [ed25519.PublicKeySize]byte
+	err     error // Of the last signature
+}
+
+var _ interfaces.KeySigner = (*RemoteSigner)(nil)
+
+// DialRemote connects to the remote signer listening on the unix socket, and gets its public key
+func DialRemote(socket string) (*RemoteSigner, error) {
+	r := new(RemoteSigner)
+	r.network = "unix"
+	r.address = socket
+	pub, err := r.call(remoteRequest{Method: "public"})
+	if err != nil {
+		return nil, err
+	}
+	if len(pub) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("Remote signer gave a public key of %d bytes", len(pub))
+	}
+	copy(r.pub[:], pub)
+	return r, nil
+}
+
+func (r *RemoteSigner) Public() []byte {
+	return r.pub[:]
+}
+
+// Sign has the remote signer sign the message.  Sign can't fail, so if the signer
+// doesn't answer with a valid signature, it returns a signature that doesn't
+// verify, and Err returns why.
+func (r *RemoteSigner) Sign(msg []byte) interfaces.IFullSignature {
+	sig := new(primitives.Signature)
+	sig.SetPub(r.pub[:])
+	s, err := r.call(remoteRequest{Method: "sign", Data: hex.EncodeToString(msg)})
+	if err == nil && len(s) != ed25519.SignatureSize {
+		err = fmt.Errorf("Remote signer gave a signature of %d bytes", len(s))
+	}
+	if err == nil {
+		sig.SetSignature(s)
+		if !sig.Verify(msg) {
+			err = fmt.Errorf("Remote signer gave an invalid signature")
+		}
+	}
+	if err != nil {
+		sig.SetSignature(make([]byte, ed25519.SignatureSize))
+	}
+
+	r.mutex.Lock()
+	r.err = err
+	r.mutex.Unlock()
+	return sig
+}
+
+// Err returns the error of the last signature, if it failed
+func (r *RemoteSigner) Err() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.err
+}
+
+func (r *RemoteSigner) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.conn == nil {
+		return nil
+	}
+	err := r.conn.Close()
+	r.conn = nil
+	return err
+}
+
+// call sends the request and returns the decoded result, trying a new connection
+// if the old one fails
+func (r *RemoteSigner) call(req remoteRequest) ([]byte, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var err error
+	for try := 0; try < 2; try++ {
+		if r.conn == nil {
+			if r.conn, err = net.DialTimeout(r.network, r.address, RemoteTimeout); err != nil {
+				r.conn = nil
+				return nil, err
+			}
+			r.reader = bufio.NewReader(r.conn)
+		}
+		var resp *remoteResponse
+		resp, err = r.roundTrip(req)
+		if err != nil {
+			r.conn.Close()
+			r.conn = nil
+			continue
+		}
+		if resp.Error != "" {
+			return nil, fmt.Errorf("Remote signer: %s", resp.Error)
+		}
+		return hex.DecodeString(resp.Result)
+	}
+	return nil, err
+}
+
+func (r *RemoteSigner) roundTrip(req remoteRequest) (*remoteResponse, error) {
+	r.conn.SetDeadline(time.Now().Add(RemoteTimeout))
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.conn.Write(append(data, '\n')); err != nil {
+		return nil, err
+	}
+	line, err := r.reader.ReadBytes('\n')
+	if err != nil {
+		return nil, err
+	}
+	resp := new(remoteResponse)
+	if err := json.Unmarshal(line, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ServeRemote answers remote signer requests on the listener with the signer's key,
+// until the listener is closed.
+func ServeRemote(l net.Listener, signer interfaces.KeySigner) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go serveRemoteConn(conn, signer)
+	}
+}
+
+func serveRemoteConn(conn net.Conn, signer interfaces.KeySigner) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			return
+		}
+		resp := new(remoteResponse)
+		req := new(remoteRequest)
+		if err := json.Unmarshal(line, req); err != nil {
+			resp.Error = "invalid request"
+		} else {
+			switch req.Method {
+			case "public":
+				resp.Result = hex.EncodeToString(signer.Public())
+			case "sign":
+				msg, err := hex.DecodeString(req.Data)
+				if err != nil {
+					resp.Error = "invalid data"
+					break
+				}
+				resp.Result = hex.EncodeToString(signer.Sign(msg).GetSignature()[:])
+			default:
+				resp.Error = fmt.Sprintf("unknown method %q", req.Method)
+			}
+		}
+		data, _ := json.Marshal(resp)
+		if _, err := conn.Write(append(data, '\n')); err != nil {
+			return
+		}
+	}
+}
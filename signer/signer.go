@@ -0,0 +1,53 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package signer provides the keys a node signs with, so that an authority node
+// need not keep its identity key in factomd.conf.  A key can come from an encrypted
+// keystore file, or stay in a remote signer that factomd asks over a local socket.
+package signer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/FactomProject/factomd/common/interfaces"
+)
+
+// The environment variable that holds the password of a keystore
+const PasswordEnv = "FACTOMD_KEYSTORE_PASSWORD"
+
+// Open returns the signer a spec names:
+//
+//	keystore:<path>[#<name>]  the named key (default identity) of a keystore file,
+//	                          whose password is in $FACTOMD_KEYSTORE_PASSWORD or
+//	                          is asked for on the terminal
+//	remote:<socket>           a remote signer listening on the unix socket
+func Open(spec string) (interfaces.KeySigner, error) {
+	i := strings.Index(spec, ":")
+	if i < 0 {
+		return nil, fmt.Errorf("Invalid signer %q, expected keystore:<path> or remote:<socket>", spec)
+	}
+	kind, location := spec[:i], spec[i+1:]
+
+	switch kind {
+	case "keystore":
+		name := IdentityKey
+		if j := strings.LastIndex(location, "#"); j >= 0 {
+			location, name = location[:j], location[j+1:]
+		}
+		ks, err := ReadKeystore(location)
+		if err != nil {
+			return nil, err
+		}
+		password, err := Password(location, false)
+		if err != nil {
+			return nil, err
+		}
+		return ks.Key(name, password)
+
+	case "remote":
+		return DialRemote(location)
+	}
+	return nil, fmt.Errorf("Unknown signer %q, expected keystore:<path> or remote:<socket>", kind)
+}
@@ -0,0 +1,210 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package signer_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/FactomProject/factomd/common/primitives"
+	. "github.com/FactomProject/factomd/signer"
+)
+
+func init() {
+	ScryptN = 1 << 10 // Keep the tests fast
+}
+
+func newKey(t *testing.T) *primitives.PrivateKey {
+	key := new(primitives.PrivateKey)
+	if err := key.GenerateKey(); err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "signer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestKeystore(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "test.keystore")
+
+	key := newKey(t)
+	ks := NewKeystore()
+	if err := ks.PutKey(IdentityKey, key, []byte("secret")); err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.Write(path); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Keystore file mode %v: %v", info.Mode(), err)
+	}
+
+	ks, err := ReadKeystore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pub, err := ks.PublicKey(IdentityKey); err != nil || !bytes.Equal(pub, key.Public()) {
+		t.Errorf("PublicKey() got %x: %v", pub, err)
+	}
+	got, err := ks.Key(IdentityKey, []byte("secret"))
+	if err != nil || !bytes.Equal(got.Key[:], key.Key[:]) {
+		t.Errorf("Key() got a different key: %v", err)
+	}
+	if _, err := ks.Key(IdentityKey, []byte("wrong")); err == nil {
+		t.Error("Decrypted a key with the wrong password")
+	}
+	if _, err := ks.Key("anchor", []byte("secret")); err == nil {
+		t.Error("Decrypted a missing key")
+	}
+
+	// Swapping in another public key must not pass
+	ks.Keys[IdentityKey].PublicKey = primitives.NewZeroHash().String()
+	if _, err := ks.Key(IdentityKey, []byte("secret")); err == nil {
+		t.Error("Decrypted a key with a forged public key")
+	}
+}
+
+func TestRemoteSigner(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "signer.sock")
+
+	key := newKey(t)
+	l, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go ServeRemote(l, key)
+
+	s, err := Open("remote:" + socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	remote := s.(*RemoteSigner)
+	if !bytes.Equal(remote.Public(), key.Public()) {
+		t.Errorf("Remote public key %x, wanted %x", remote.Public(), key.Public())
+	}
+	msg := []byte("a message")
+	if sig := remote.Sign(msg); !sig.Verify(msg) || remote.Err() != nil {
+		t.Errorf("Remote signature doesn't verify: %v", remote.Err())
+	}
+
+	// A lost connection is made again
+	remote.Close()
+	if sig := remote.Sign(msg); !sig.Verify(msg) {
+		t.Errorf("Remote signature doesn't verify after reconnecting: %v", remote.Err())
+	}
+
+	// With the signer gone, the signature fails but doesn't panic
+	l.Close()
+	remote.Close()
+	if sig := remote.Sign(msg); sig.Verify(msg) || remote.Err() == nil {
+		t.Error("Signed without a remote signer")
+	}
+}
+
+func TestOpenKeystore(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "test.keystore")
+
+	key, anchor := newKey(t), newKey(t)
+	ks := NewKeystore()
+	ks.PutKey(IdentityKey, key, []byte("secret"))
+	ks.PutKey("anchor", anchor, []byte("secret"))
+	if err := ks.Write(path); err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv(PasswordEnv, "secret")
+	defer os.Unsetenv(PasswordEnv)
+	if s, err := Open("keystore:" + path); err != nil || !bytes.Equal(s.Public(), key.Public()) {
+		t.Errorf("Opened the wrong key: %v", err)
+	}
+	if s, err := Open("keystore:" + path + "#anchor"); err != nil || !bytes.Equal(s.Public(), anchor.Public()) {
+		t.Errorf("Opened the wrong key: %v", err)
+	}
+	for _, bad := range []string{path, "keystore:" + path + "#missing", "hsm:" + path} {
+		if _, err := Open(bad); err == nil {
+			t.Errorf("Opened %q", bad)
+		}
+	}
+}
+
+func TestKeystoreCommand(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "test.keystore")
+
+	os.Setenv(PasswordEnv, "secret")
+	defer os.Unsetenv(PasswordEnv)
+	run := func(args ...string) (string, error) {
+		out := new(bytes.Buffer)
+		err := KeystoreCommand(append(args, "-keystore", path), out)
+		return out.String(), err
+	}
+
+	if _, err := run("export"); err == nil {
+		t.Error("Exported from a missing keystore")
+	}
+	if _, err := run("create"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run("create"); err == nil {
+		t.Error("Created a key over an existing one")
+	}
+	out, err := run("export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := primitives.NewPrivateKeyFromHex(strings.TrimSpace(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := run("rotate"); err != nil {
+		t.Fatal(err)
+	}
+	ks, err := ReadKeystore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if old, err := ks.Key(IdentityKey+".previous", []byte("secret")); err != nil || !bytes.Equal(old.Public(), key.Public()) {
+		t.Errorf("Rotate didn't keep the old key: %v", err)
+	}
+	if pub, _ := ks.PublicKey(IdentityKey); bytes.Equal(pub, key.Public()) {
+		t.Error("Rotate didn't replace the key")
+	}
+
+	os.Setenv(PasswordEnv, "changed")
+	if _, err := run("passwd"); err == nil {
+		t.Error("Changed the password without the old one")
+	}
+	os.Setenv(PasswordEnv, "secret")
+	if _, err := run("passwd"); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err = run("list")
+	if err != nil || !strings.Contains(out, IdentityKey+".previous") {
+		t.Errorf("List got %q: %v", out, err)
+	}
+	if _, err := run("frobnicate"); err == nil {
+		t.Error("Ran an unknown command")
+	}
+}
@@ -92,11 +92,47 @@ type Authority struct {
 	Status            uint8
 	AnchorKeys        []AnchorSigningKey
 
-	KeyHistory []HistoricKey
+	KeyHistory        []HistoricKey
+	CoinbaseAddresses []CoinbaseAddress // Oldest first
 }
 
 var _ interfaces.BinaryMarshallable = (*Authority)(nil)
 
+// CoinbaseAddress is a factoid address a server's coinbase payouts go to, from the admin
+// block at DBHeight
+type CoinbaseAddress struct {
+	Address  interfaces.IHash
+	DBHeight uint32
+}
+
+// AddCoinbaseAddress adds an address set by the admin block at dbheight, unless we already
+// have it
+func (auth *Authority) AddCoinbaseAddress(address interfaces.IHash, dbheight uint32) {
+	i := len(auth.CoinbaseAddresses)
+	for i > 0 && auth.CoinbaseAddresses[i-1].DBHeight > dbheight {
+		i--
+	}
+	for _, c := range auth.CoinbaseAddresses {
+		if c.DBHeight == dbheight && c.Address.IsSameAs(address) {
+			return // The admin block is applied again
+		}
+	}
+	auth.CoinbaseAddresses = append(auth.CoinbaseAddresses, CoinbaseAddress{})
+	copy(auth.CoinbaseAddresses[i+1:], auth.CoinbaseAddresses[i:])
+	auth.CoinbaseAddresses[i] = CoinbaseAddress{Address: address, DBHeight: dbheight}
+}
+
+// CoinbaseAddressAt returns the address a payout at dbheight goes to, the last one set
+// at least declaration blocks before, or nil if there is none
+func (auth *Authority) CoinbaseAddressAt(dbheight uint32, declaration uint32) interfaces.IHash {
+	for i := len(auth.CoinbaseAddresses) - 1; i >= 0; i-- {
+		if uint64(auth.CoinbaseAddresses[i].DBHeight)+uint64(declaration) <= uint64(dbheight) {
+			return auth.CoinbaseAddresses[i].Address
+		}
+	}
+	return nil
+}
+
 func RandomAuthority() *Authority {
 	a := new(Authority)
 
@@ -117,6 +153,11 @@ func RandomAuthority() *Authority {
 		a.KeyHistory = append(a.KeyHistory, *RandomHistoricKey())
 	}
 
+	l = random.RandIntBetween(0, 10)
+	for i := 0; i < l; i++ {
+		a.AddCoinbaseAddress(primitives.RandomHash(), random.RandUInt32())
+	}
+
 	return a
 }
 
@@ -153,6 +194,15 @@ func (e *Authority) IsSameAs(b *Authority) bool {
 			return false
 		}
 	}
+	if len(e.CoinbaseAddresses) != len(b.CoinbaseAddresses) {
+		return false
+	}
+	for i := range e.CoinbaseAddresses {
+		if e.CoinbaseAddresses[i].DBHeight != b.CoinbaseAddresses[i].DBHeight ||
+			e.CoinbaseAddresses[i].Address.IsSameAs(b.CoinbaseAddresses[i].Address) == false {
+			return false
+		}
+	}
 
 	return true
 }
@@ -218,6 +268,22 @@ func (e *Authority) MarshalBinary() ([]byte, error) {
 		}
 	}
 
+	l = len(e.CoinbaseAddresses)
+	err = buf.PushVarInt(uint64(l))
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range e.CoinbaseAddresses {
+		err = buf.PushBinaryMarshallable(v.Address)
+		if err != nil {
+			return nil, err
+		}
+		err = buf.PushUInt32(v.DBHeight)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return buf.DeepCopyBytes(), nil
 }
 
@@ -274,6 +340,24 @@ func (e *Authority) UnmarshalBinaryData(p []byte) (newData []byte, err error) {
 		e.KeyHistory = append(e.KeyHistory, hk)
 	}
 
+	l, err = buf.PopVarInt()
+	if err != nil {
+		return
+	}
+	for i := 0; i < int(l); i++ {
+		var c CoinbaseAddress
+		c.Address = primitives.NewZeroHash()
+		err = buf.PopBinaryMarshallable(c.Address)
+		if err != nil {
+			return
+		}
+		c.DBHeight, err = buf.PopUInt32()
+		if err != nil {
+			return
+		}
+		e.CoinbaseAddresses = append(e.CoinbaseAddresses, c)
+	}
+
 	newData = buf.DeepCopyBytes()
 	return
 }
@@ -422,6 +506,58 @@ func (st *State) FastVerifyAuthoritySignature(msg []byte, sig interfaces.IFullSi
 	return -1, fmt.Errorf("%s", "Signature Key Invalid or not Federated Server Key")
 }
 
+// FastVerifyAuthoritySignatures checks many signatures of a message at once, and
+// returns for each what FastVerifyAuthoritySignature would: 1 if signed by a
+// federated server, 0 by an audit server, and -1 otherwise.
+func (st *State) FastVerifyAuthoritySignatures(msg []byte, sigs []interfaces.IFullSignature, dbheight uint32) []int {
+	checks := make([]int, len(sigs))
+	for i := range checks {
+		checks[i] = -1
+	}
+	feds := st.GetFedServers(dbheight)
+	if feds == nil {
+		return checks
+	}
+
+	// The type of the authority behind each signing key, old keys included and
+	// feds taking precedence
+	keys := make(map[string]int)
+	addKeys := func(servers []interfaces.IServer, typ int) {
+		for _, server := range servers {
+			auth, _ := st.GetAuthority(server.GetChainID())
+			if auth == nil {
+				continue
+			}
+			if key, err := auth.SigningKey.MarshalBinary(); err == nil {
+				keys[string(key)] = typ
+			}
+			for _, histKey := range auth.KeyHistory {
+				if key, err := histKey.SigningKey.MarshalBinary(); err == nil {
+					keys[string(key)] = typ
+				}
+			}
+		}
+	}
+	addKeys(st.GetAuditServers(dbheight), 0)
+	addKeys(feds, 1)
+
+	batch := primitives.NewBatchVerifier()
+	index := make([]int, len(sigs))
+	for i, sig := range sigs {
+		index[i] = -1
+		if _, ok := keys[string(sig.GetKey())]; ok {
+			index[i] = batch.Add(sig.GetKey(), msg, sig.GetSignature()[:])
+		}
+	}
+	valid := batch.Verify()
+	for i, sig := range sigs {
+		if index[i] >= 0 && valid[index[i]] {
+			checks[i] = keys[string(sig.GetKey())]
+		}
+	}
+	return checks
+}
+
 func pkEq(a, b []byte) bool {
 	if a == nil && b == nil {
 		return true
@@ -706,7 +842,7 @@ func addServerSigningKey(chainID interfaces.IHash, key interfaces.IHash, height
 				break
 			}
 			if bytes.Compare(pubData, key.Bytes()) == 0 {
-				st.serverPrivKey = st.serverPendingPrivKeys[i]
+				st.serverSigner = st.serverPendingPrivKeys[i]
 				st.serverPubKey = st.serverPendingPubKeys[i]
 				if len(st.serverPendingPrivKeys) > i+1 {
 					st.serverPendingPrivKeys = append(st.serverPendingPrivKeys[:i], st.serverPendingPrivKeys[i+1:]...)
@@ -0,0 +1,52 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/FactomProject/factomd/common/adminBlock"
+	"github.com/FactomProject/factomd/common/primitives"
+)
+
+// GetAuthoritiesAtHeight returns the federated and audit servers, and their signing keys, at a
+// directory block height, as an *adminBlock.AuthoritySet.  The history is built from the admin
+// blocks saved in the database, as far up as anyone has asked, so it doesn't depend on how this
+// node got its state (eg: fast boot).
+func (s *State) GetAuthoritiesAtHeight(dbheight uint32) (interface{}, error) {
+	s.authorityHistoryMutex.Lock()
+	defer s.authorityHistoryMutex.Unlock()
+
+	if s.authorityHistory == nil {
+		history := adminBlock.NewAuthorityHistory()
+		key := new(primitives.PublicKey)
+		if err := key.UnmarshalBinary(s.GetNetworkBootStrapKey().Bytes()); err != nil {
+			return nil, err
+		}
+		history.AddBootstrapServer(s.GetNetworkBootStrapIdentity(), key)
+		s.authorityHistory = history
+	}
+
+	head, err := s.DB.FetchDBlockHead()
+	if err != nil {
+		return nil, err
+	}
+	if head == nil || dbheight > head.GetDatabaseHeight() {
+		return nil, fmt.Errorf("Directory block %d has not been saved yet", dbheight)
+	}
+	for s.authorityHistory.Height() <= dbheight {
+		ablock, err := s.DB.FetchABlockByHeight(s.authorityHistory.Height())
+		if err != nil {
+			return nil, err
+		}
+		if ablock == nil {
+			return nil, fmt.Errorf("Admin block %d is missing", s.authorityHistory.Height())
+		}
+		if err = s.authorityHistory.AddAdminBlock(ablock); err != nil {
+			return nil, err
+		}
+	}
+	return s.authorityHistory.AuthoritySetAt(dbheight), nil
+}
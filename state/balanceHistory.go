@@ -0,0 +1,204 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/FactomProject/factomd/common/primitives"
+)
+
+// The balance history answers what the balance of an address was after some block.  For
+// every block processed we save the permanent balances it changed, and every
+// BalanceCheckpointInterval blocks a checkpoint of all the balances.  The balance at a
+// height is the last change at or below it, back to the checkpoint before it.  A block
+// recorded after a gap (eg: we booted from a savestate past the history) gets a checkpoint
+// too, so blocks in the gap are simply not recorded.
+
+// How many blocks apart the checkpoints of all balances are saved.  Closer checkpoints
+// make queries faster, at the cost of a copy of every balance each time.
+var BalanceCheckpointInterval uint32 = 1000
+
+// The decoded checkpoint last used, as queries tend to fall in the same range
+type balanceCheckpoint struct {
+	height  uint32
+	factoid map[[32]byte]int64
+	ec      map[[32]byte]int64
+}
+
+// loadBalanceHistory reads how far the history is saved the first time it is needed
+func (s *State) loadBalanceHistory() error {
+	if s.balanceHistoryLoaded {
+		return nil
+	}
+	checkpoints, height, err := s.DB.FetchBalanceHistoryHeight()
+	if err != nil {
+		return err
+	}
+	s.balanceCheckpoints = checkpoints
+	s.balanceHistoryHeight = height
+	s.balanceHistoryLoaded = true
+	return nil
+}
+
+// noteBalanceChange remembers a change of a permanent balance for the balance history.
+// The caller holds the mutex of the balances changed.
+func (s *State) noteBalanceChange(ec bool, adr [32]byte, v int64) {
+	changes := &s.factoidBalanceChanges
+	if ec {
+		changes = &s.ecBalanceChanges
+	}
+	if *changes == nil {
+		*changes = map[[32]byte]int64{}
+	}
+	(*changes)[adr] = v
+}
+
+// recordBalances records the balances changed by the block at dbheight, which has just
+// been added to the permanent balances
+func (s *State) recordBalances(dbheight uint32) {
+	s.balanceHistoryMutex.Lock()
+	defer s.balanceHistoryMutex.Unlock()
+
+	s.FactoidBalancesPMutex.Lock()
+	factoidChanges := s.factoidBalanceChanges
+	s.factoidBalanceChanges = nil
+	s.FactoidBalancesPMutex.Unlock()
+	s.ECBalancesPMutex.Lock()
+	ecChanges := s.ecBalanceChanges
+	s.ecBalanceChanges = nil
+	s.ECBalancesPMutex.Unlock()
+
+	if err := s.loadBalanceHistory(); err != nil {
+		s.Logf("error", "Failed to load the balance history: %v", err)
+		return
+	}
+	if dbheight < s.balanceHistoryHeight {
+		return // Already recorded, we are loading the database
+	}
+
+	changes, err := encodeBalances(factoidChanges, ecChanges)
+	if err != nil {
+		s.Logf("error", "Failed to save the balance history: %v", err)
+		return
+	}
+	var checkpoint []byte
+	if dbheight%BalanceCheckpointInterval == 0 || dbheight != s.balanceHistoryHeight || len(s.balanceCheckpoints) == 0 {
+		s.FactoidBalancesPMutex.Lock()
+		s.ECBalancesPMutex.Lock()
+		checkpoint, err = encodeBalances(s.FactoidBalancesP, s.ECBalancesP)
+		s.ECBalancesPMutex.Unlock()
+		s.FactoidBalancesPMutex.Unlock()
+		if err != nil {
+			s.Logf("error", "Failed to save the balance history: %v", err)
+			return
+		}
+	}
+
+	if err := s.DB.SaveBalanceHistory(dbheight, changes, checkpoint); err != nil {
+		s.Logf("error", "Failed to save the balance history: %v", err)
+		return
+	}
+	if checkpoint != nil {
+		s.balanceCheckpoints = append(s.balanceCheckpoints, dbheight)
+	}
+	s.balanceHistoryHeight = dbheight + 1
+}
+
+// GetFactoidBalanceAt returns the balance of the factoid address after the block at height
+func (s *State) GetFactoidBalanceAt(adr [32]byte, height uint32) (int64, error) {
+	return s.getBalanceAt(false, adr, height)
+}
+
+// GetECBalanceAt returns the balance of the entry credit address after the block at height
+func (s *State) GetECBalanceAt(adr [32]byte, height uint32) (int64, error) {
+	return s.getBalanceAt(true, adr, height)
+}
+
+func (s *State) getBalanceAt(ec bool, adr [32]byte, height uint32) (int64, error) {
+	s.balanceHistoryMutex.Lock()
+	defer s.balanceHistoryMutex.Unlock()
+
+	if err := s.loadBalanceHistory(); err != nil {
+		return 0, err
+	}
+	if height >= s.balanceHistoryHeight {
+		return 0, fmt.Errorf("The balance history is not recorded up to block %d", height)
+	}
+	from := -1
+	for i := len(s.balanceCheckpoints) - 1; i >= 0; i-- {
+		if s.balanceCheckpoints[i] <= height {
+			from = int(s.balanceCheckpoints[i])
+			break
+		}
+	}
+	if from < 0 {
+		return 0, fmt.Errorf("The balance history is not recorded at block %d", height)
+	}
+
+	// The last change after the checkpoint wins
+	for h := int(height); h > from; h-- {
+		data, err := s.DB.FetchBalanceChanges(uint32(h))
+		if err != nil {
+			return 0, err
+		}
+		if data == nil {
+			return 0, fmt.Errorf("The balance history is not recorded at block %d", h)
+		}
+		factoidChanges, ecChanges, err := decodeBalances(data)
+		if err != nil {
+			return 0, err
+		}
+		changes := factoidChanges
+		if ec {
+			changes = ecChanges
+		}
+		if v, ok := changes[adr]; ok {
+			return v, nil
+		}
+	}
+
+	if s.balanceCheckpointCache == nil || s.balanceCheckpointCache.height != uint32(from) {
+		data, err := s.DB.FetchBalanceCheckpoint(uint32(from))
+		if err != nil {
+			return 0, err
+		}
+		if data == nil {
+			return 0, fmt.Errorf("The balance checkpoint at block %d is missing", from)
+		}
+		cp := new(balanceCheckpoint)
+		cp.height = uint32(from)
+		if cp.factoid, cp.ec, err = decodeBalances(data); err != nil {
+			return 0, err
+		}
+		s.balanceCheckpointCache = cp
+	}
+	if ec {
+		return s.balanceCheckpointCache.ec[adr], nil
+	}
+	return s.balanceCheckpointCache.factoid[adr], nil
+}
+
+func encodeBalances(factoid, ec map[[32]byte]int64) ([]byte, error) {
+	buf := primitives.NewBuffer(nil)
+	if err := PushBalanceMap(buf, factoid); err != nil {
+		return nil, err
+	}
+	if err := PushBalanceMap(buf, ec); err != nil {
+		return nil, err
+	}
+	return buf.DeepCopyBytes(), nil
+}
+
+func decodeBalances(data []byte) (factoid, ec map[[32]byte]int64, err error) {
+	buf := primitives.NewBuffer(data)
+	if factoid, err = PopBalanceMap(buf); err != nil {
+		return nil, nil, err
+	}
+	if ec, err = PopBalanceMap(buf); err != nil {
+		return nil, nil, err
+	}
+	return factoid, ec, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package state_test
+
+import (
+	"testing"
+
+	. "github.com/FactomProject/factomd/state"
+	"github.com/FactomProject/factomd/testHelper"
+)
+
+func TestBalanceHistory(t *testing.T) {
+	defer func(interval uint32) { BalanceCheckpointInterval = interval }(BalanceCheckpointInterval)
+	BalanceCheckpointInterval = 4
+
+	s := testHelper.CreatePopulateAndExecuteTestState()
+	top := s.GetHighestSavedBlk()
+	checkpoints, next, err := s.DB.FetchBalanceHistoryHeight()
+	if err != nil || next != top+1 || len(checkpoints) != int(top/4)+1 {
+		t.Fatalf("FetchBalanceHistoryHeight() got checkpoints %v up to %d, %v", checkpoints, next, err)
+	}
+
+	// Replay the factoid blocks, and check the balances after each one.  A new State
+	// reads the history back from the database.
+	reloaded := new(State)
+	reloaded.DB = s.DB
+	balances := map[[32]byte]int64{}
+	for h := uint32(0); h <= top; h++ {
+		fblock, err := s.DB.FetchFBlockByHeight(h)
+		if err != nil || fblock == nil {
+			t.Fatalf("No factoid block %d: %v", h, err)
+		}
+		for _, tx := range fblock.GetTransactions() {
+			for _, in := range tx.GetInputs() {
+				balances[in.GetAddress().Fixed()] -= int64(in.GetAmount())
+			}
+			for _, out := range tx.GetOutputs() {
+				balances[out.GetAddress().Fixed()] += int64(out.GetAmount())
+			}
+		}
+		for adr, want := range balances {
+			for _, st := range []*State{s, reloaded} {
+				if got, err := st.GetFactoidBalanceAt(adr, h); err != nil || got != want {
+					t.Errorf("GetFactoidBalanceAt(%x, %d) got %d, expected %d: %v", adr[:4], h, got, want, err)
+				}
+			}
+		}
+	}
+	if len(balances) == 0 {
+		t.Error("No factoid transactions to check")
+	}
+
+	// The latest are the current balances
+	s.FactoidBalancesPMutex.Lock()
+	defer s.FactoidBalancesPMutex.Unlock()
+	s.ECBalancesPMutex.Lock()
+	defer s.ECBalancesPMutex.Unlock()
+	for adr, want := range s.FactoidBalancesP {
+		if got, err := s.GetFactoidBalanceAt(adr, top); err != nil || got != want {
+			t.Errorf("GetFactoidBalanceAt(%x, %d) got %d, expected %d: %v", adr[:4], top, got, want, err)
+		}
+	}
+	for adr, want := range s.ECBalancesP {
+		if got, err := s.GetECBalanceAt(adr, top); err != nil || got != want {
+			t.Errorf("GetECBalanceAt(%x, %d) got %d, expected %d: %v", adr[:4], top, got, want, err)
+		}
+	}
+
+	var adr [32]byte
+	if _, err := s.GetFactoidBalanceAt(adr, top+1); err == nil {
+		t.Error("GetFactoidBalanceAt() returned a block that is not recorded")
+	}
+	if v, err := s.GetECBalanceAt(adr, 0); err != nil || v != 0 {
+		t.Errorf("GetECBalanceAt() of an unused address got %d, %v", v, err)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package state
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/FactomProject/factomd/common/constants"
+	"github.com/FactomProject/factomd/common/primitives"
+)
+
+// ParseCheckPoints reads a list of trusted directory block KeyMRs, given as
+// "height:keymr,height:keymr"
+func ParseCheckPoints(list string) (map[uint32]string, error) {
+	checkPoints := make(map[uint32]string)
+	for _, field := range strings.Split(list, ",") {
+		field = strings.TrimSpace(field)
+		if len(field) == 0 {
+			continue
+		}
+		parts := strings.Split(field, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("Checkpoint %q is not height:keymr", field)
+		}
+		height, err := strconv.ParseUint(strings.TrimSpace(parts[0]), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("Checkpoint %q has a bad height: %s", field, err.Error())
+		}
+		keymr, err := primitives.HexToHash(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("Checkpoint %q has a bad KeyMR: %s", field, err.Error())
+		}
+		checkPoints[uint32(height)] = keymr.String()
+	}
+	return checkPoints, nil
+}
+
+// initCheckPoints builds the checkpoints for our network: the ones built into the binary,
+// plus any the operator configured.
+func (s *State) initCheckPoints() {
+	s.checkPoints = make(map[uint32]string)
+	if s.Network == "MAIN" || s.Network == "main" {
+		for height, keymr := range constants.CheckPoints {
+			s.checkPoints[height] = keymr
+		}
+	}
+
+	configured, err := ParseCheckPoints(s.CheckPointList)
+	if err != nil {
+		panic("Bad value for CheckPoints in factomd.conf: " + err.Error())
+	}
+	for height, keymr := range configured {
+		s.checkPoints[height] = keymr
+	}
+
+	s.vouched = make(map[uint32]string)
+	s.highestCheckPoint = 0
+	for height := range s.checkPoints {
+		if height > s.highestCheckPoint {
+			s.highestCheckPoint = height
+		}
+	}
+	if s.FastSync && len(s.checkPoints) > 0 {
+		s.Println("\nFast syncing to the checkpoint at height ", s.highestCheckPoint)
+	}
+}
+
+// GetCheckPoint returns the trusted directory block KeyMR at this height, or ""
+// if there is no checkpoint there.
+func (s *State) GetCheckPoint(dbheight uint32) string {
+	return s.checkPoints[dbheight]
+}
+
+// IsCheckPointed is true if we are fast syncing and a checkpoint at or above this
+// height vouches for the block.  Such blocks must be what the checkpoint vouches for (see
+// VouchedKeyMR), but their signatures and transactions are not validated.
+func (s *State) IsCheckPointed(dbheight uint32) bool {
+	return s.FastSync && len(s.checkPoints) > 0 && dbheight <= s.highestCheckPoint
+}
+
+// VouchedKeyMR returns the KeyMR of the block at dbheight that the next checkpoint vouches for.
+// The checkpoint gives the KeyMR of its own block, and each block back from it gives the KeyMR of
+// the one before, so this is only known once we have received every block from dbheight to the
+// checkpoint.  Until then it returns false, and blocks wait in DBStatesReceived.  A received block
+// that isn't the one vouched for is dropped, so that it is asked for again.
+func (s *State) VouchedKeyMR(dbheight uint32) (string, bool) {
+	delete(s.vouched, dbheight-1) // Already saved
+	if keymr, ok := s.vouched[dbheight]; ok {
+		return keymr, true
+	}
+
+	// Work back from the next checkpoint, or from the lowest block vouched for below it
+	var top uint32
+	var keymr string
+	for height, key := range s.checkPoints {
+		if height >= dbheight && (keymr == "" || height < top) {
+			top, keymr = height, key
+		}
+	}
+	if keymr == "" {
+		return "", false
+	}
+	for height := dbheight + 1; height < top; height++ {
+		if key, ok := s.vouched[height]; ok {
+			top, keymr = height, key
+			break
+		}
+	}
+	s.vouched[top] = keymr
+
+	for height := top; height > dbheight; height-- {
+		ix := int(height) - s.DBStatesReceivedBase
+		if ix < 0 || ix >= len(s.DBStatesReceived) || s.DBStatesReceived[ix] == nil {
+			return "", false
+		}
+		dblk := s.DBStatesReceived[ix].DirectoryBlock
+		if dblk.GetKeyMR().String() != keymr {
+			s.DBStatesReceived[ix] = nil
+			return "", false
+		}
+		keymr = dblk.GetHeader().GetPrevKeyMR().String()
+		s.vouched[height-1] = keymr
+	}
+	return keymr, true
+}
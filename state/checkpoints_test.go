@@ -0,0 +1,137 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package state_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/FactomProject/factomd/common/messages"
+	"github.com/FactomProject/factomd/common/primitives"
+	. "github.com/FactomProject/factomd/state"
+	"github.com/FactomProject/factomd/testHelper"
+)
+
+func TestParseCheckPoints(t *testing.T) {
+	keymr := "3bc9a3a8c8ba27cd3f2eab3c2a3a7c4f5e5d2c8b8e3fd0d5c9ae0ab2b3a4d1f7"
+	checkPoints, err := ParseCheckPoints(" 10:" + keymr + ",,20 : " + keymr)
+	if err != nil || len(checkPoints) != 2 || checkPoints[10] != keymr || checkPoints[20] != keymr {
+		t.Errorf("ParseCheckPoints() got %v, %v", checkPoints, err)
+	}
+	for _, bad := range []string{"10", "x:" + keymr, "10:abc", "10:" + keymr + ":1"} {
+		if _, err := ParseCheckPoints(bad); err == nil {
+			t.Errorf("ParseCheckPoints(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestFastSync(t *testing.T) {
+	ref := new(State)
+	ref.DB = testHelper.CreateAndPopulateTestDatabaseOverlay()
+	msgs := testHelper.GetAllDBStateMsgsFromDatabase(ref)
+	last := msgs[len(msgs)-1].(*messages.DBStateMsg).DirectoryBlock
+	checkPoint := fmt.Sprintf("%d:%s", last.GetDatabaseHeight(), last.GetKeyMR().String())
+
+	// The test blocks are not signed, so only a checkpoint lets us sync them
+	var entries []*JournalEntry
+	for _, msg := range msgs[1:] {
+		entries = append(entries, &JournalEntry{Timestamp: msg.GetTimestamp(), Local: true, Msg: msg})
+	}
+
+	s := newReplayState(msgs[0].(*messages.DBStateMsg))
+	s.ReplayJournal(entries, ref.DB)
+	if s.GetHighestSavedBlk() != 0 {
+		t.Fatalf("Synced unsigned blocks to %d without a checkpoint", s.GetHighestSavedBlk())
+	}
+
+	s = newFastSyncState(msgs[0].(*messages.DBStateMsg), checkPoint)
+	if !s.IsCheckPointed(last.GetDatabaseHeight()) || s.IsCheckPointed(last.GetDatabaseHeight()+1) {
+		t.Errorf("IsCheckPointed() wrong around the checkpoint %s", checkPoint)
+	}
+	report := s.ReplayJournal(entries, ref.DB)
+	if report.Divergence != nil || s.GetHighestSavedBlk() != last.GetDatabaseHeight() {
+		t.Errorf("Fast synced to %d, wanted %d: %s", s.GetHighestSavedBlk(), last.GetDatabaseHeight(), report.String())
+	}
+
+	// A checkpoint the chain doesn't match stops the sync there
+	prev := msgs[1].(*messages.DBStateMsg).DirectoryBlock
+	s = newFastSyncState(msgs[0].(*messages.DBStateMsg), fmt.Sprintf("%s,1:%s", checkPoint, last.GetKeyMR().String()))
+	if s.GetCheckPoint(1) == prev.GetKeyMR().String() {
+		t.Fatal("Test checkpoint matches the chain")
+	}
+	s.ReplayJournal(entries, ref.DB)
+	if s.GetHighestSavedBlk() != 0 {
+		t.Errorf("Synced to %d past a bad checkpoint", s.GetHighestSavedBlk())
+	}
+}
+
+func TestFastSyncForgedBlock(t *testing.T) {
+	ref := new(State)
+	ref.DB = testHelper.CreateAndPopulateTestDatabaseOverlay()
+	msgs := testHelper.GetAllDBStateMsgsFromDatabase(ref)
+	checkPoint := func(i int) string {
+		dblk := msgs[i].(*messages.DBStateMsg).DirectoryBlock
+		return fmt.Sprintf("%d:%s", dblk.GetDatabaseHeight(), dblk.GetKeyMR().String())
+	}
+	low, forged, high := 2, 5, len(msgs)-1
+
+	// A block between the checkpoints that chains to the block before, but isn't the one the
+	// checkpoints vouch for
+	data, err := msgs[forged].MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake := new(messages.DBStateMsg)
+	if err := fake.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	fake.DirectoryBlock.GetHeader().SetTimestamp(primitives.NewTimestampFromMinutes(1))
+	if fake.DirectoryBlock.GetKeyMR().IsSameAs(msgs[forged].(*messages.DBStateMsg).DirectoryBlock.GetKeyMR()) {
+		t.Fatal("Forged block has the real KeyMR")
+	}
+
+	var entries []*JournalEntry
+	for i, msg := range msgs[1:] {
+		if i+1 == forged {
+			msg = fake
+		}
+		entries = append(entries, &JournalEntry{Timestamp: msg.GetTimestamp(), Local: true, Msg: msg})
+	}
+
+	s := newFastSyncState(msgs[0].(*messages.DBStateMsg), checkPoint(low)+","+checkPoint(high))
+	s.ReplayJournal(entries, ref.DB)
+	// Nothing past the low checkpoint is vouched for without the real block
+	if s.GetHighestSavedBlk() != uint32(low) {
+		t.Errorf("Fast synced to %d with a forged block at %d", s.GetHighestSavedBlk(), forged)
+	}
+	if dblk, err := s.DB.FetchDBlockByHeight(uint32(forged)); err != nil || dblk != nil {
+		t.Errorf("Saved the forged block: %v, %v", dblk, err)
+	}
+
+	// The real block, when it comes, takes us to the checkpoint
+	s.ReplayJournal([]*JournalEntry{{Timestamp: msgs[forged].GetTimestamp(), Local: true, Msg: msgs[forged]}}, ref.DB)
+	if s.GetHighestSavedBlk() != uint32(high) {
+		t.Errorf("Fast synced to %d, wanted %d", s.GetHighestSavedBlk(), high)
+	}
+}
+
+func newFastSyncState(first *messages.DBStateMsg, checkPoints string) *State {
+	db := testHelper.CreateEmptyTestDatabaseOverlay()
+	db.ProcessABlockBatch(first.AdminBlock)
+	db.ProcessFBlockBatch(first.FactoidBlock)
+	db.ProcessECBlockBatch(first.EntryCreditBlock, false)
+	db.ProcessDBlockBatch(first.DirectoryBlock)
+
+	s := new(State)
+	s.DB = db
+	s.LoadConfig("", "")
+	s.Network = "LOCAL"
+	s.FastSync = true
+	s.CheckPointList = checkPoints
+	s.Init()
+	s.Network = "LOCAL"
+	s.SetFactoshisPerEC(1)
+	return s
+}
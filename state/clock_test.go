@@ -0,0 +1,46 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FactomProject/factomd/common/constants"
+	"github.com/FactomProject/factomd/common/primitives"
+	"github.com/FactomProject/factomd/testHelper"
+)
+
+func TestSimClockState(t *testing.T) {
+	s := testHelper.CreateEmptyTestState()
+	start := time.Unix(1500000000, 0)
+	clock := primitives.NewSimClock(start)
+	s.SetClock(clock)
+
+	if s.GetTimestamp().GetTimeMilli() != start.UnixNano()/1e6 || s.GetCurrentTime() != start.UnixNano() {
+		t.Errorf("GetTimestamp() got %v, expected %v", s.GetTimestamp(), start)
+	}
+
+	// Messages are checked against the simulated time, not the wall clock
+	ts := primitives.NewTimestampFromTime(start.Add(-time.Minute))
+	if !s.Replay.IsTSValid(constants.INTERNAL_REPLAY, primitives.Sha([]byte("msg")), ts) {
+		t.Errorf("IsTSValid() rejected a message from a minute ago")
+	}
+	clock.Advance(3 * time.Hour)
+	if s.Replay.IsTSValid(constants.INTERNAL_REPLAY, primitives.Sha([]byte("old msg")), ts) {
+		t.Errorf("IsTSValid() accepted a message from 3 hours ago")
+	}
+
+	// A minute that has gone on for too long stalls us, in simulated time
+	s.DirectoryBlockInSeconds = 600
+	s.CurrentMinuteStartTime = s.GetCurrentTime()
+	if s.IsStalled() {
+		t.Errorf("IsStalled() at the start of the minute")
+	}
+	clock.Advance(91 * time.Second)
+	if !s.IsStalled() {
+		t.Errorf("IsStalled() after a minute and a half")
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package state
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/FactomProject/factomd/common/constants"
+	"github.com/FactomProject/factomd/common/factoid"
+	"github.com/FactomProject/factomd/common/interfaces"
+)
+
+// The coinbase of a payout block pays the federated and audit servers as they stand once
+// the block before it has been processed, each to the coinbase address an admin block set
+// long enough before (see RegisterCoinbaseAddress).  Both come from the admin blocks, so
+// every node agrees on them.  The leaders build it at the end of the previous block, and a
+// node checks the coinbase of a block from the network against the same payouts before
+// applying it.
+
+// coinbasePayouts returns what the coinbase of the block at dbheight must pay, in order of
+// the identity chain IDs
+func (s *State) coinbasePayouts(dbheight uint32) []factoid.CoinbasePayout {
+	params := factoid.GetCoinbaseParams(s.GetNetworkID())
+	if !params.PaysAt(dbheight) {
+		return nil
+	}
+
+	authorities := make([]*Authority, 0, len(s.Authorities))
+	for _, a := range s.Authorities {
+		if a.Status == constants.IDENTITY_FEDERATED_SERVER || a.Status == constants.IDENTITY_AUDIT_SERVER {
+			authorities = append(authorities, a)
+		}
+	}
+	sort.Slice(authorities, func(i, j int) bool {
+		return bytes.Compare(authorities[i].AuthorityChainID.Bytes(), authorities[j].AuthorityChainID.Bytes()) < 0
+	})
+
+	var payouts []factoid.CoinbasePayout
+	for _, a := range authorities {
+		address := a.CoinbaseAddressAt(dbheight, params.Declaration)
+		if address == nil {
+			continue
+		}
+		amount := params.FederatedAmount
+		if a.Status == constants.IDENTITY_AUDIT_SERVER {
+			amount = params.AuditAmount
+		}
+		if amount == 0 {
+			continue
+		}
+		payouts = append(payouts, factoid.CoinbasePayout{Address: factoid.NewAddress(address.Bytes()), Amount: amount})
+	}
+	return payouts
+}
+
+// AddCoinbaseAddress sets the address the coinbase payouts of the server go to, from the
+// admin block at dbheight
+func (s *State) AddCoinbaseAddress(dbheight uint32, chainID interfaces.IHash, address interfaces.IHash) {
+	i := s.AddAuthorityFromChainID(chainID)
+	s.Authorities[i].AddCoinbaseAddress(address, dbheight)
+}
+
+// GetCoinbase returns the coinbase transaction of the block at dbheight
+func (s *State) GetCoinbase(dbheight uint32, ftime interfaces.Timestamp) interfaces.ITransaction {
+	return factoid.GetCoinbase(ftime, s.coinbasePayouts(dbheight))
+}
+
+// ValidateCoinbase returns an error if the coinbase of the factoid block does not make the
+// payouts the network expects.  The payouts depend on the authority servers, so a block
+// that pays them can only be checked once the block before it has been processed.
+func (s *State) ValidateCoinbase(fblock interfaces.IFBlock) error {
+	dbheight := fblock.GetDatabaseHeight()
+	if !factoid.GetCoinbaseParams(s.GetNetworkID()).IsActive(dbheight) {
+		return nil
+	}
+	var coinbase interfaces.ITransaction
+	if transactions := fblock.GetTransactions(); len(transactions) > 0 {
+		coinbase = transactions[0]
+	}
+	return factoid.CheckCoinbase(coinbase, s.coinbasePayouts(dbheight))
+}
@@ -0,0 +1,173 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package state_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/FactomProject/factomd/common/adminBlock"
+	"github.com/FactomProject/factomd/common/constants"
+	"github.com/FactomProject/factomd/common/entryBlock"
+	"github.com/FactomProject/factomd/common/factoid"
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/common/primitives"
+	. "github.com/FactomProject/factomd/state"
+	"github.com/FactomProject/factomd/testHelper"
+)
+
+// coinbaseAddressEntry returns the identity entry declaring address at seconds, signed by key
+func coinbaseAddressEntry(chainID interfaces.IHash, address interfaces.IHash, seconds int64, key *primitives.PrivateKey) interfaces.IEBEntry {
+	ts := make([]byte, 8)
+	binary.BigEndian.PutUint64(ts, uint64(seconds))
+
+	e := entryBlock.NewEntry()
+	e.ChainID = chainID
+	extIDs := [][]byte{{0x00}, []byte("Coinbase Address"), chainID.Bytes(), address.Bytes(), ts}
+	var msg []byte
+	for _, x := range extIDs {
+		msg = append(msg, x...)
+	}
+	sig := key.Sign(msg)
+	extIDs = append(extIDs, append([]byte{0x01}, key.Public()...), sig.GetSignature()[:])
+	for _, x := range extIDs {
+		e.ExtIDs = append(e.ExtIDs, primitives.ByteSlice{Bytes: x})
+	}
+	return e
+}
+
+func TestCoinbasePayouts(t *testing.T) {
+	s := testHelper.CreateEmptyTestState()
+	s.NetworkNumber = constants.NETWORK_LOCAL
+	params := factoid.GetCoinbaseParams(constants.LOCAL_NETWORK_ID)
+	n := params.Frequency
+	ts := primitives.NewTimestampFromSeconds(1000)
+
+	// A federated, an audit and a pending server, in order of chain ID
+	var keys []*primitives.PrivateKey
+	var chainIDs []interfaces.IHash
+	statuses := []uint8{constants.IDENTITY_FEDERATED_SERVER, constants.IDENTITY_AUDIT_SERVER, constants.IDENTITY_PENDING_FEDERATED_SERVER}
+	s.Authorities = nil
+	for i, status := range statuses {
+		key := new(primitives.PrivateKey)
+		if err := key.GenerateKey(); err != nil {
+			t.Fatal(err)
+		}
+		b := make([]byte, 32)
+		b[0], b[1] = 0x88, byte(i)
+		chainID := primitives.NewHash(b)
+
+		id := RandomIdentity()
+		id.IdentityChainID = chainID
+		id.AnchorKeys = []AnchorSigningKey{*RandomAnchorSigningKey()}
+		id.Key1 = primitives.Shad(append([]byte{0x01}, key.Public()...))
+		s.Identities = append(s.Identities, id)
+		auth := new(Authority)
+		auth.AuthorityChainID = chainID
+		auth.ManagementChainID = id.ManagementChainID
+		auth.Status = status
+		s.Authorities = append(s.Authorities, auth)
+
+		keys = append(keys, key)
+		chainIDs = append(chainIDs, chainID)
+	}
+
+	addresses := make([]interfaces.IHash, 4)
+	for i := range addresses {
+		addresses[i] = primitives.Sha([]byte{byte(i)})
+	}
+	// Addresses are set from the height of the admin block they are in
+	declare := func(i int, address interfaces.IHash, height uint32) {
+		ablock := adminBlock.NewAdminBlock(nil)
+		ablock.GetHeader().SetDBHeight(height)
+		if err := ablock.AddFactoidAddress(chainIDs[i], address); err != nil {
+			t.Fatal(err)
+		}
+		if err := ablock.UpdateState(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	declare(0, addresses[0], 10*n)
+	declare(1, addresses[1], 10*n)
+	declare(2, addresses[2], 10*n)
+
+	check := func(dbheight uint32, want []factoid.CoinbasePayout) {
+		coinbase := s.GetCoinbase(dbheight, ts)
+		if err := factoid.CheckCoinbase(coinbase, want); err != nil {
+			t.Errorf("Coinbase at %d: %v", dbheight, err)
+		}
+
+		fblock := factoid.NewFBlock(nil)
+		fblock.SetDBHeight(dbheight)
+		if err := fblock.AddCoinbase(coinbase); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.ValidateCoinbase(fblock); err != nil {
+			t.Errorf("ValidateCoinbase() at %d: %v", dbheight, err)
+		}
+		other := factoid.NewFBlock(nil)
+		other.SetDBHeight(dbheight)
+		other.AddCoinbase(factoid.GetCoinbase(ts, []factoid.CoinbasePayout{{Address: factoid.NewAddress(addresses[3].Bytes()), Amount: 1}}))
+		if err := s.ValidateCoinbase(other); err == nil {
+			t.Errorf("ValidateCoinbase() at %d passed a coinbase with the wrong payouts", dbheight)
+		}
+	}
+	fed := func(i int) factoid.CoinbasePayout {
+		return factoid.CoinbasePayout{Address: factoid.NewAddress(addresses[i].Bytes()), Amount: params.FederatedAmount}
+	}
+	audit := func(i int) factoid.CoinbasePayout {
+		return factoid.CoinbasePayout{Address: factoid.NewAddress(addresses[i].Bytes()), Amount: params.AuditAmount}
+	}
+
+	// Not declared long enough before, and not a payout height
+	check(10*n, nil)
+	check(11*n-1, nil)
+	check(11*n, []factoid.CoinbasePayout{fed(0), audit(1)})
+
+	// A new address takes over once it has been declared long enough
+	declare(0, addresses[3], 12*n-1)
+	check(12*n, []factoid.CoinbasePayout{fed(0), audit(1)})
+	check(13*n, []factoid.CoinbasePayout{fed(3), audit(1)})
+
+	// Applying the admin block again changes nothing
+	declare(0, addresses[3], 12*n-1)
+	if l := len(s.Authorities[0].CoinbaseAddresses); l != 2 {
+		t.Errorf("Authority has %d coinbase addresses, expected 2", l)
+	}
+
+	// The identity entry alone doesn't change the payouts; only the admin block does
+	s.LLeaderHeight = 13 * n
+	s.SetLeaderTimestamp(primitives.NewTimestampFromSeconds(100000))
+	entry := coinbaseAddressEntry(chainIDs[1], addresses[2], 100000, keys[1])
+	if err := RegisterCoinbaseAddress(entry, false, 13*n, s); err != nil {
+		t.Error(err)
+	}
+	check(14*n, []factoid.CoinbasePayout{fed(3), audit(1)})
+
+	// A server that leaves is no longer paid
+	s.Authorities[1].Status = constants.IDENTITY_PENDING_AUDIT_SERVER
+	check(14*n, []factoid.CoinbasePayout{fed(3)})
+
+	// An entry signed by the wrong key, or timed by the local clock rather than the block, is refused
+	if err := RegisterCoinbaseAddress(coinbaseAddressEntry(chainIDs[1], addresses[3], 100000, keys[0]), false, 13*n, s); err == nil {
+		t.Error("RegisterCoinbaseAddress() took an entry signed by the wrong key")
+	}
+	now := s.GetTimestamp().GetTimeSeconds()
+	if err := RegisterCoinbaseAddress(coinbaseAddressEntry(chainIDs[1], addresses[3], now, keys[1]), false, 13*n, s); err == nil {
+		t.Error("RegisterCoinbaseAddress() took an entry 12 hours from the block")
+	}
+	if err := RegisterCoinbaseAddress(coinbaseAddressEntry(chainIDs[1], addresses[3], 100000, keys[1]), false, 13*n+1, s); err == nil {
+		t.Error("RegisterCoinbaseAddress() took an entry in a block we don't have")
+	}
+
+	// Other networks don't check the coinbase
+	s.NetworkNumber = constants.NETWORK_MAIN
+	fblock := factoid.NewFBlock(nil)
+	fblock.SetDBHeight(14 * n)
+	fblock.AddCoinbase(factoid.GetCoinbase(ts, []factoid.CoinbasePayout{fed(0)}))
+	if err := s.ValidateCoinbase(fblock); err != nil {
+		t.Errorf("ValidateCoinbase() on MAIN %v", err)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/FactomProject/factomd/common/adminBlock"
@@ -677,9 +678,44 @@ func (d *DBState) ValidNext(state *State, next *messages.DBStateMsg) int {
 		return 0
 	}
 
-	valid := next.ValidateSignatures(state)
-	if !next.IsInDB && !next.IgnoreSigs && valid != 1 {
-		return valid
+	if !next.IsInDB && state.IsCheckPointed(dbheight) {
+		// A checkpoint vouches for this block, so we skip the signatures.  We wait for the blocks
+		// up to the checkpoint, so nothing is saved that the checkpoint doesn't vouch for.
+		keymr, ok := state.VouchedKeyMR(dbheight)
+		if !ok {
+			return 0
+		}
+		if keymr != dirblk.GetKeyMR().String() {
+			return -1
+		}
+		if valid := next.ValidateData(state); valid != 1 {
+			return valid
+		}
+	} else {
+		valid := next.ValidateSignatures(state)
+		if !next.IsInDB && !next.IgnoreSigs && valid != 1 {
+			return valid
+		}
+
+		// ProcessBlocks can't reject the factoid block anymore, so a bad one is rejected here
+		if !next.IsInDB {
+			if err := next.FactoidBlock.Validate(state.GetNetworkID()); err != nil {
+				state.Logf("warning", "DBState.ValidNext: rtn -1 bad factoid block at dbht: %d: %v", dbheight, err)
+				return -1
+			}
+		}
+
+		// The payouts of the coinbase are only known once the block before is processed
+		if !next.IsInDB && !next.IgnoreSigs {
+			params := factoid.GetCoinbaseParams(state.GetNetworkID())
+			if params.PaysAt(dbheight) && dbheight != state.GetHighestSavedBlk()+1 {
+				return 0
+			}
+			if err := state.ValidateCoinbase(next.FactoidBlock); err != nil {
+				state.Logf("warning", "DBState.ValidNext: rtn -1 bad coinbase at dbht: %d: %v", dbheight, err)
+				return -1
+			}
+		}
 	}
 
 	// Get the keymr of the Previous DBState
@@ -895,6 +931,7 @@ func (list *DBStateList) FixupLinks(p *DBState, d *DBState) (progress bool) {
 			addEntry := adminBlock.NewAddFederatedServer(cf.GetChainID(), currentDBHeight+1)
 			//list.State.AddStatus(fmt.Sprintf("FIXUPLINKS: Adding delta to the Admin Block: %s", addEntry.String()))
 			d.AdminBlock.AddFirstABEntry(addEntry)
+			list.State.auditAdminBlock(currentDBHeight+1, cf.GetChainID(), strings.TrimSpace(addEntry.String()))
 			/*} else {
 				list.State.AddStatus(fmt.Sprintf("FIXUPLINKS: Not Adding delta to the Admin Block: Idx: %d Status: %d", index, list.State.Identities[index].Status))
 			}*/
@@ -912,6 +949,7 @@ func (list *DBStateList) FixupLinks(p *DBState, d *DBState) (progress bool) {
 			if containsServer(currentAuds, pf) {
 				demoteEntry := adminBlock.NewAddAuditServer(pf.GetChainID(), currentDBHeight+1)
 				d.AdminBlock.AddFirstABEntry(demoteEntry)
+				list.State.auditAdminBlock(currentDBHeight+1, pf.GetChainID(), strings.TrimSpace(demoteEntry.String()))
 			}
 			_ = currentAuds
 		}
@@ -1085,8 +1123,14 @@ func (list *DBStateList) ProcessBlocks(d *DBState) (progress bool) {
 	if err != nil {
 		panic(err)
 	}
+	list.State.recordBalances(d.DirectoryBlock.GetHeader().GetDBHeight())
+	list.State.updateSupply(d.DirectoryBlock.GetHeader().GetDBHeight())
 
-	list.State.Balancehash = fs.GetBalanceHash(false)
+	// Blocks vouched for by a later checkpoint don't need a balance hash; we only need one
+	// from the checkpoint on.
+	if !list.State.IsCheckPointed(d.DirectoryBlock.GetHeader().GetDBHeight() + 1) {
+		list.State.Balancehash = fs.GetBalanceHash(false)
+	}
 
 	// Make the current exchange rate whatever we had in the previous block.
 	// UNLESS there was a FER entry processed during this block  changeheight will be left at 1 on a change block
@@ -1100,6 +1144,7 @@ func (list *DBStateList) ProcessBlocks(d *DBState) (progress bool) {
 		}
 		list.State.FactoshisPerEC = d.FactoidBlock.GetExchRate()
 	}
+	list.State.recordFER(d.DirectoryBlock.GetHeader().GetDBHeight(), d.FactoidBlock.GetExchRate())
 
 	fs.ProcessEndOfBlock(list.State)
 
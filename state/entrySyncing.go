@@ -128,16 +128,21 @@ func (s *State) MakeMissingEntryRequests() {
 
 				asked := MissingEntryMap[entry.GetHash().Fixed()] != nil
 
-				if asked {
-					s.DB.StartMultiBatch()
-					err := s.DB.InsertEntryMultiBatch(entry)
-					if err != nil {
-						panic(err)
-					}
-					err = s.DB.ExecuteMultiBatch()
-					if err != nil {
-						panic(err)
+				// Not once the database is closed, or about to be
+				if !s.writeUnlessShuttingDown(func() {
+					if asked {
+						s.DB.StartMultiBatch()
+						err := s.DB.InsertEntryMultiBatch(entry)
+						if err != nil {
+							panic(err)
+						}
+						err = s.DB.ExecuteMultiBatch()
+						if err != nil {
+							panic(err)
+						}
 					}
+				}) {
+					break InsertLoop
 				}
 
 			default:
@@ -13,7 +13,6 @@ import (
 	"fmt"
 	"runtime/debug"
 	"sort"
-	"time"
 
 	"github.com/FactomProject/factomd/common/constants"
 	"github.com/FactomProject/factomd/common/entryCreditBlock"
@@ -129,7 +128,7 @@ func (fs *FactoidState) Reset(dbstate *DBState) {
 
 		fs.CurrentBlock = fBlock
 
-		t := factoid.GetCoinbase(dbstate.NextTimestamp)
+		t := fs.State.GetCoinbase(fs.CurrentBlock.GetDatabaseHeight(), dbstate.NextTimestamp)
 
 		fs.State.FactoshisPerEC = dbstate.FinalExchangeRate
 		fs.State.LeaderTimestamp = dbstate.NextTimestamp
@@ -164,7 +163,7 @@ func (fs *FactoidState) GetCurrentBlock() interfaces.IFBlock {
 		fs.CurrentBlock = factoid.NewFBlock(nil)
 		fs.CurrentBlock.SetExchRate(fs.State.GetFactoshisPerEC())
 		fs.CurrentBlock.SetDBHeight(fs.DBHeight)
-		t := factoid.GetCoinbase(fs.State.GetLeaderTimestamp())
+		t := fs.State.GetCoinbase(fs.DBHeight, fs.State.GetLeaderTimestamp())
 		err := fs.CurrentBlock.AddCoinbase(t)
 		if err != nil {
 			panic(err.Error())
@@ -177,8 +176,12 @@ func (fs *FactoidState) GetCurrentBlock() interfaces.IFBlock {
 // When we are playing catchup, adding the transaction block is a pretty
 // useful feature.
 func (fs *FactoidState) AddTransactionBlock(blk interfaces.IFBlock) error {
-	if err := blk.Validate(); err != nil {
-		return err
+	// Transactions in blocks vouched for by a checkpoint are applied without validation; the
+	// block is only processed once the checkpoint's KeyMR leads back to it (see VouchedKeyMR)
+	if !fs.State.IsCheckPointed(blk.GetDatabaseHeight()) {
+		if err := blk.Validate(fs.State.GetNetworkID()); err != nil {
+			return err
+		}
 	}
 
 	transactions := blk.GetTransactions()
@@ -239,7 +242,7 @@ func (fs *FactoidState) AddTransaction(index int, trans interfaces.ITransaction)
 	if err := fs.UpdateTransaction(true, trans); err != nil {
 		return err
 	}
-	if err := fs.CurrentBlock.AddTransaction(trans); err != nil {
+	if err := fs.CurrentBlock.AddTransaction(fs.State.GetNetworkID(), trans); err != nil {
 		if err != nil {
 			return err
 		}
@@ -365,7 +368,7 @@ func (fs *FactoidState) ProcessEndOfBlock(state interfaces.IState) {
 
 	leaderTS := fs.State.GetLeaderTimestamp()
 
-	t := factoid.GetCoinbase(leaderTS)
+	t := fs.State.GetCoinbase(fs.CurrentBlock.GetDatabaseHeight(), leaderTS)
 
 	dbstate := fs.State.DBStates.Get(int(fs.DBHeight))
 	if dbstate != nil {
@@ -380,12 +383,22 @@ func (fs *FactoidState) ProcessEndOfBlock(state interfaces.IState) {
 	fs.UpdateTransaction(true, t)
 
 	fs.DBHeight++
-	fs.State.CurrentBlockStartTime = time.Now().UnixNano()
+	fs.State.CurrentBlockStartTime = fs.State.GetClock().Now().UnixNano()
 }
 
 // Returns an error message about what is wrong with the transaction if it is
 // invalid, otherwise you are good to go.
 func (fs *FactoidState) Validate(index int, trans interfaces.ITransaction) error {
+	if err := factoid.CheckRCDActivation(trans, fs.State.GetNetworkID(), fs.DBHeight); err != nil {
+		return err
+	}
+	if err := factoid.CheckVersionActivation(trans, fs.State.GetNetworkID(), fs.DBHeight); err != nil {
+		return err
+	}
+	if err := factoid.CheckLockHeights(trans, fs.DBHeight); err != nil {
+		return err
+	}
+
 	var sums = make(map[[32]byte]uint64, 10)  // Look at the sum of an address's inputs
 	for _, input := range trans.GetInputs() { //    to a transaction.
 		bal, err := factoid.ValidateAmounts(sums[input.GetAddress().Fixed()], input.GetAmount())
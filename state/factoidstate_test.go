@@ -291,6 +291,34 @@ func TestUpdateTransactionWithNegativeBalance(t *testing.T) {
 	}
 }
 
+func TestValidateLockHeights(t *testing.T) {
+	s := testHelper.CreateAndPopulateTestState()
+	fs := s.FactoidState.(*FactoidState)
+
+	add1, err := primitives.HexToHash("0000000000000000000000000000000000000000000000000000000000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.PutF(true, add1.Fixed(), 10)
+
+	ft := new(factoid.Transaction)
+	ft.AddInput(add1, 10)
+	ft.LockHeight = fs.DBHeight + 1
+	ft.ExpiryHeight = fs.DBHeight + 2
+
+	if err := fs.Validate(1, ft); err == nil {
+		t.Errorf("Validated a transaction locked until %d at %d", ft.LockHeight, fs.DBHeight)
+	}
+	fs.DBHeight++
+	if err := fs.Validate(1, ft); err != nil {
+		t.Errorf("%v", err)
+	}
+	fs.DBHeight += 2
+	if err := fs.Validate(1, ft); err == nil {
+		t.Errorf("Validated a transaction that expired at %d at %d", ft.ExpiryHeight, fs.DBHeight)
+	}
+}
+
 /*
 func TestUpdateECTransaction(t *testing.T) {
 	fs.SetFactoshisPerEC(1)
@@ -8,7 +8,6 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math/rand"
-	"time"
 
 	"github.com/FactomProject/factomd/common/interfaces"
 	"github.com/FactomProject/factomd/common/primitives"
@@ -81,7 +80,7 @@ func markFault(pl *ProcessList, vmIndex int, faultReason int) {
 		return
 	}
 
-	now := time.Now().Unix()
+	now := pl.State.GetClock().Now().Unix()
 	vm := pl.VMs[vmIndex]
 
 	if vm.WhenFaulted == 0 {
@@ -90,6 +89,7 @@ func markFault(pl *ProcessList, vmIndex int, faultReason int) {
 		// and keep track of the ProcessList height it has faulted at
 		vm.WhenFaulted = now
 		vm.FaultFlag = faultReason
+		pl.State.auditVMFault(pl, FaultAuditMarked, vmIndex, faultReason)
 	}
 
 	c := pl.State.CurrentMinute
@@ -105,6 +105,9 @@ func markFault(pl *ProcessList, vmIndex int, faultReason int) {
 func markNoFault(pl *ProcessList, vmIndex int) {
 	vm := pl.VMs[vmIndex]
 
+	if vm.WhenFaulted != 0 {
+		pl.State.auditVMFault(pl, FaultAuditRecovered, vmIndex, 0)
+	}
 	vm.WhenFaulted = 0
 	vm.FaultFlag = -1
 
@@ -127,7 +130,7 @@ func markNoFault(pl *ProcessList, vmIndex int) {
 		if cf.AmINegotiator {
 			ff := CraftFullFault(pl, vmIndex, vm.Height)
 			if ff != nil {
-				ff.Sign(pl.State.serverPrivKey)
+				ff.Sign(pl.State.serverSigner)
 				ff.SendOut(pl.State, ff)
 				ff.FollowerExecute(pl.State)
 			}
@@ -150,7 +153,7 @@ func NegotiationCheck(pl *ProcessList) {
 		return
 	}
 
-	now := time.Now().Unix()
+	now := pl.State.GetClock().Now().Unix()
 	if now-prevVM.WhenFaulted < int64(pl.State.FaultTimeout) {
 		//It hasn't been long enough; wait a little longer
 		//before starting negotiation
@@ -161,7 +164,7 @@ func NegotiationCheck(pl *ProcessList) {
 		//THROTTLE
 		ff := CraftFullFault(pl, prevIdx, prevVM.Height)
 		if ff != nil {
-			ff.Sign(pl.State.serverPrivKey)
+			ff.Sign(pl.State.serverSigner)
 			ff.SendOut(pl.State, ff)
 			ff.FollowerExecute(pl.State)
 		}
@@ -175,7 +178,7 @@ func NegotiationCheck(pl *ProcessList) {
 func FaultCheck(pl *ProcessList) {
 	NegotiationCheck(pl)
 
-	now := time.Now().Unix()
+	now := pl.State.GetClock().Now().Unix()
 
 	currentFault := pl.CurrentFault()
 	if currentFault.IsNil() {
@@ -299,7 +302,7 @@ func CraftFault(pl *ProcessList, vmIndex int, height int) *messages.ServerFault
 		// Create and send ServerFault (vote) message
 		sf := messages.NewServerFault(faultedFedID, replacementServer.GetChainID(), vmIndex, pl.DBHeight, uint32(height), pl.System.Height, pl.State.GetTimestamp())
 		if sf != nil {
-			sf.Sign(pl.State.serverPrivKey)
+			sf.Sign(pl.State.serverSigner)
 			return sf
 		}
 	} else {
@@ -325,7 +328,7 @@ func CraftFullFault(pl *ProcessList, vmIndex int, height int) *messages.FullServ
 		prevFF = pl.System.List[pl.System.Height-1].(*messages.FullServerFault)
 	}
 
-	now := time.Now().Unix()
+	now := pl.State.GetClock().Now().Unix()
 
 	if faultState.IsNil() || (now-faultState.GetTimestamp().GetTimeSeconds() > int64(pl.State.FaultTimeout)) && !(faultState.HasEnoughSigs(pl.State) && faultState.GetPledgeDone()) {
 		sf = CraftFault(pl, vmIndex, height)
@@ -410,6 +413,9 @@ func (s *State) FollowerExecuteSFault(m interfaces.IMsg) {
 	sfSigned, err := s.FastVerifyAuthoritySignature(lbytes, sf.Signature, sf.DBHeight)
 
 	if err == nil && (sfSigned > 0 || (sfSigned == 0 && isPledge)) {
+		if _, counted := currentFault.LocalVoteMap[issuerID]; !counted {
+			s.auditVote(sf, isPledge)
+		}
 		currentFault.AddFaultVote(issuerID, sf.GetSignature())
 	}
 }
@@ -446,7 +452,7 @@ func isMyNegotiation(sf FaultCore, pl *ProcessList) bool {
 // message, it will copy it, sign it, and send it out to the network
 func (s *State) matchFault(sf *messages.ServerFault) {
 	if sf != nil {
-		sf.Sign(s.serverPrivKey)
+		sf.Sign(s.serverSigner)
 		sf.SendOut(s, sf)
 		s.InMsgQueue().Enqueue(sf)
 	}
@@ -477,6 +483,7 @@ func (s *State) FollowerExecuteFullFault(m interfaces.IMsg) {
 	//	s.LLeaderHeight,
 	//	fullFault.String()))
 
+	s.auditFullFault(FaultAuditNegotiation, fullFault, "", "")
 	pl.AddToSystemList(fullFault)
 }
 
@@ -0,0 +1,269 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package state
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/common/messages"
+)
+
+// The fault audit trail records every step of a fault negotiation, so operators can
+// reconstruct why a federated server was replaced.  Records are appended to a file as
+// one JSON object per line (like the journal) and the most recent ones are kept in memory
+// for the API and the control panel.
+
+const (
+	FaultAuditMarked      = "marked"      // we consider a VM faulted
+	FaultAuditRecovered   = "recovered"   // the VM came back before it was replaced
+	FaultAuditVote        = "vote"        // a ServerFault vote (or the audit server's pledge) was counted
+	FaultAuditNegotiation = "negotiation" // a FullServerFault was added to the system list
+	FaultAuditCleared     = "cleared"     // a FullServerFault with ClearFault was processed
+	FaultAuditReplaced    = "replaced"    // the faulted server was replaced by the audit server
+	FaultAuditRejected    = "rejected"    // a complete FullServerFault could not be applied
+	FaultAuditAdminBlock  = "admin-block" // the server lists changed in the admin block because of a fault
+)
+
+// FaultAuditMemory is how many records we keep in memory
+var FaultAuditMemory = 1000
+
+type FaultAuditRecord struct {
+	Time             time.Time
+	Event            string
+	Node             string // the node that recorded this
+	ServerID         string // the faulted federated server
+	AuditServerID    string // the audit server nominated to replace it
+	VMIndex          int
+	DBHeight         uint32
+	Height           uint32 // VM height of the fault
+	SystemHeight     uint32
+	CoreHash         string   `json:",omitempty"` // identifies the negotiation, see FaultCore
+	FaultReason      int      `json:",omitempty"`
+	Signer           string   `json:",omitempty"` // public key of the vote
+	Pledge           bool     `json:",omitempty"` // the vote (or negotiation) includes the audit server's pledge
+	Signatures       []string `json:",omitempty"` // public keys of the signatures collected
+	Outcome          string   `json:",omitempty"`
+	AdminBlockChange string   `json:",omitempty"`
+}
+
+func (r *FaultAuditRecord) String() string {
+	str := fmt.Sprintf("%s %-11s dbht %d vm %d ht %d sysht %d server %s audit %s",
+		r.Time.Format("2006-01-02 15:04:05"), r.Event, r.DBHeight, r.VMIndex, r.Height, r.SystemHeight, shortID(r.ServerID), shortID(r.AuditServerID))
+	if r.FaultReason != 0 {
+		str += fmt.Sprintf(" reason %d", r.FaultReason)
+	}
+	if len(r.Signer) > 0 {
+		str += fmt.Sprintf(" signer %s", shortID(r.Signer))
+	}
+	if len(r.Signatures) > 0 {
+		str += fmt.Sprintf(" sigs %d", len(r.Signatures))
+	}
+	if r.Pledge {
+		str += " pledged"
+	}
+	if len(r.Outcome) > 0 {
+		str += " -- " + r.Outcome
+	}
+	if len(r.AdminBlockChange) > 0 {
+		str += " -- " + r.AdminBlockChange
+	}
+	return str
+}
+
+func shortID(id string) string {
+	if len(id) < 12 {
+		return id
+	}
+	return id[4:12]
+}
+
+type FaultAuditLog struct {
+	mutex    sync.Mutex
+	filename string // "" keeps the trail in memory only
+	records  []FaultAuditRecord
+	last     map[string]string // last negotiation state recorded per core hash, so pings aren't repeated
+}
+
+// NewFaultAuditLog opens the audit trail in filename, loading what is already there
+func NewFaultAuditLog(filename string) *FaultAuditLog {
+	l := new(FaultAuditLog)
+	l.filename = filename
+	l.records = make([]FaultAuditRecord, 0)
+	l.last = make(map[string]string)
+	if len(filename) == 0 {
+		return l
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		return l
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+	for {
+		p, err := r.ReadBytes('\n')
+		if err != nil {
+			break
+		}
+		record := new(FaultAuditRecord)
+		if json.Unmarshal(p, record) == nil {
+			l.remember(*record)
+		}
+	}
+	return l
+}
+
+func (l *FaultAuditLog) remember(record FaultAuditRecord) {
+	l.records = append(l.records, record)
+	if len(l.records) > FaultAuditMemory {
+		l.records = l.records[len(l.records)-FaultAuditMemory:]
+	}
+}
+
+// Add records an event, in memory and in the file
+func (l *FaultAuditLog) Add(record FaultAuditRecord) {
+	if l == nil {
+		return
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if record.Time.IsZero() {
+		record.Time = time.Now()
+	}
+	l.remember(record)
+
+	if len(l.filename) == 0 {
+		return
+	}
+	p, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	os.MkdirAll(filepath.Dir(l.filename), 0775)
+	f, err := os.OpenFile(l.filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+	if err != nil {
+		fmt.Println("Could not write the fault audit file:", l.filename, err)
+		l.filename = ""
+		return
+	}
+	defer f.Close()
+	fmt.Fprintln(f, string(p))
+}
+
+// Records returns the records about serverID ("" for all) at or above dbheight, oldest first
+func (l *FaultAuditLog) Records(serverID string, dbheight uint32) []FaultAuditRecord {
+	ret := make([]FaultAuditRecord, 0)
+	if l == nil {
+		return ret
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	for _, r := range l.records {
+		if r.DBHeight < dbheight {
+			continue
+		}
+		if len(serverID) > 0 && r.ServerID != serverID && r.AuditServerID != serverID {
+			continue
+		}
+		ret = append(ret, r)
+	}
+	return ret
+}
+
+// changed is true if the negotiation with this core hash looks different from when we last recorded it
+func (l *FaultAuditLog) changed(coreHash string, summary string) bool {
+	if l == nil {
+		return false
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.last[coreHash] == summary {
+		return false
+	}
+	if len(l.last) > FaultAuditMemory {
+		l.last = make(map[string]string)
+	}
+	l.last[coreHash] = summary
+	return true
+}
+
+// GetFaultAuditTrail returns the fault audit records about serverID ("" for all) from dbheight on
+func (s *State) GetFaultAuditTrail(serverID string, dbheight uint32) interface{} {
+	return s.FaultAudit.Records(serverID, dbheight)
+}
+
+func (s *State) faultAuditRecord(event string, fc FaultCore) FaultAuditRecord {
+	r := FaultAuditRecord{Time: s.GetClock().Now(), Event: event, Node: s.FactomNodeName, VMIndex: int(fc.VMIndex), DBHeight: fc.DBHeight, Height: fc.Height, SystemHeight: fc.SystemHeight}
+	if fc.ServerID != nil {
+		r.ServerID = fc.ServerID.String()
+	}
+	if fc.AuditServerID != nil {
+		r.AuditServerID = fc.AuditServerID.String()
+	}
+	if fc.ServerID != nil && fc.AuditServerID != nil && fc.Timestamp != nil {
+		if h := fc.GetHash(); h != nil {
+			r.CoreHash = h.String()
+		}
+	}
+	return r
+}
+
+// auditVMFault records a change in whether we consider a VM faulted
+func (s *State) auditVMFault(pl *ProcessList, event string, vmIndex int, faultReason int) {
+	fc := FaultCore{VMIndex: byte(vmIndex), DBHeight: pl.DBHeight, Height: uint32(pl.VMs[vmIndex].Height)}
+	c := s.CurrentMinute
+	if c > 9 {
+		c = 9
+	}
+	if index := pl.ServerMap[c][vmIndex]; index < len(pl.FedServers) {
+		fc.ServerID = pl.FedServers[index].GetChainID()
+	}
+	r := s.faultAuditRecord(event, fc)
+	r.FaultReason = faultReason
+	s.FaultAudit.Add(r)
+}
+
+// auditVote records a ServerFault vote that was counted toward the current negotiation
+func (s *State) auditVote(sf *messages.ServerFault, pledge bool) {
+	r := s.faultAuditRecord(FaultAuditVote, ExtractFaultCore(sf))
+	r.Signer = fmt.Sprintf("%x", sf.GetSignature().GetKey())
+	r.Pledge = pledge
+	s.FaultAudit.Add(r)
+}
+
+// auditFullFault records a FullServerFault, unless we recorded it already (negotiations are pinged and processing is retried)
+func (s *State) auditFullFault(event string, fullFault *messages.FullServerFault, outcome string, adminBlockChange string) {
+	r := s.faultAuditRecord(event, ExtractFaultCore(fullFault))
+	for _, sig := range fullFault.SignatureList.List {
+		r.Signatures = append(r.Signatures, fmt.Sprintf("%x", sig.GetKey()))
+	}
+	r.Pledge = fullFault.GetPledgeDone()
+	r.Outcome = outcome
+	r.AdminBlockChange = adminBlockChange
+	if !s.FaultAudit.changed(r.CoreHash, fmt.Sprintf("%s %s %d %v %v", event, outcome, len(r.Signatures), r.Pledge, fullFault.ClearFault)) {
+		return
+	}
+	s.FaultAudit.Add(r)
+}
+
+// auditAdminBlock records a server list change made to the admin block because of a fault
+func (s *State) auditAdminBlock(dbheight uint32, chainID interfaces.IHash, change string) {
+	s.FaultAudit.Add(FaultAuditRecord{Time: s.GetClock().Now(), Event: FaultAuditAdminBlock, Node: s.FactomNodeName, ServerID: chainID.String(), DBHeight: dbheight, AdminBlockChange: change})
+}
+
+// faultAuditFile is where a node with this log path keeps its audit trail
+func faultAuditFile(logPath string) string {
+	if logPath == "stdout" || len(logPath) == 0 {
+		return ""
+	}
+	return filepath.Join(logPath, "faultaudit.log")
+}
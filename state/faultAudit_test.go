@@ -0,0 +1,60 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package state_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/FactomProject/factomd/state"
+)
+
+func TestFaultAuditLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "faultaudit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "faultaudit.log")
+
+	l := NewFaultAuditLog(filename)
+	l.Add(FaultAuditRecord{Event: FaultAuditMarked, ServerID: "aa", DBHeight: 10})
+	l.Add(FaultAuditRecord{Event: FaultAuditVote, ServerID: "aa", AuditServerID: "bb", DBHeight: 10})
+	l.Add(FaultAuditRecord{Event: FaultAuditReplaced, ServerID: "cc", AuditServerID: "dd", DBHeight: 12, AdminBlockChange: "change"})
+
+	if got := len(l.Records("", 0)); got != 3 {
+		t.Errorf("Records() got %d records, expected 3", got)
+	}
+	if got := len(l.Records("bb", 0)); got != 1 {
+		t.Errorf("Records() for the audit server got %d records, expected 1", got)
+	}
+	if got := len(l.Records("", 11)); got != 1 {
+		t.Errorf("Records() from height 11 got %d records, expected 1", got)
+	}
+
+	// The trail survives a restart
+	reloaded := NewFaultAuditLog(filename).Records("", 0)
+	if len(reloaded) != 3 {
+		t.Fatalf("reloaded %d records, expected 3", len(reloaded))
+	}
+	if reloaded[2].Event != FaultAuditReplaced || reloaded[2].AdminBlockChange != "change" || reloaded[0].Time.IsZero() {
+		t.Errorf("reloaded %+v", reloaded[2])
+	}
+
+	saved := FaultAuditMemory
+	FaultAuditMemory = 2
+	defer func() { FaultAuditMemory = saved }()
+	if got := NewFaultAuditLog(filename).Records("", 0); len(got) != 2 || got[1].Event != FaultAuditReplaced {
+		t.Errorf("only the newest records should be kept, got %+v", got)
+	}
+
+	var nilLog *FaultAuditLog
+	nilLog.Add(FaultAuditRecord{})
+	if got := nilLog.Records("", 0); len(got) != 0 {
+		t.Errorf("a nil log should be empty")
+	}
+}
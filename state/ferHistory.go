@@ -0,0 +1,205 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/FactomProject/factomd/common/entryBlock/specialEntries"
+	"github.com/FactomProject/factomd/common/interfaces"
+)
+
+// The FER history is the Factoshis per EC rate of every block, saved as the epochs where it
+// changed.  The rate is recorded from each factoid block as it is processed, along with the
+// FER entry that scheduled the change when there was one, so past EC purchases can be priced
+// without walking the FER chain.  Saved blocks this node did not process (eg: it booted from
+// a savestate) are read back from the database by GoBackfillFERHistory, a batch at a time, so
+// the walk never holds up ProcessBlocks or the API.
+
+// ferHistoryBatch is the most saved blocks GoBackfillFERHistory records under the mutex at once
+const ferHistoryBatch = 1000
+
+// FERHistory is the rate epochs in effect for some height from From to To
+type FERHistory struct {
+	From   uint32                 `json:"from"`
+	To     uint32                 `json:"to"`
+	Epochs []interfaces.IFEREpoch `json:"epochs"`
+}
+
+// loadFERHistory reads the saved history the first time it is needed
+func (s *State) loadFERHistory() error {
+	if s.ferHistoryLoaded {
+		return nil
+	}
+	epochs, height, err := s.DB.FetchAllFEREpochs()
+	if err != nil {
+		return err
+	}
+	s.ferHistory = epochs
+	s.ferHistoryHeight = height
+	s.ferHistoryLoaded = true
+	return nil
+}
+
+// recordFER records the rate of the factoid block at dbheight
+func (s *State) recordFER(dbheight uint32, factoshisPerEC uint64) {
+	s.ferHistoryMutex.Lock()
+	defer s.ferHistoryMutex.Unlock()
+
+	if err := s.loadFERHistory(); err != nil {
+		s.Logf("error", "Failed to load the FER history: %v", err)
+		return
+	}
+	if dbheight < s.ferHistoryHeight {
+		return // Already recorded, we are loading the database
+	}
+	if dbheight > s.ferHistoryHeight {
+		if s.ferHistoryLive {
+			s.Logf("error", "The FER history is missing blocks %d to %d", s.ferHistoryHeight, dbheight-1)
+		}
+		return // Not backfilled up to here yet, GoBackfillFERHistory reads it once it is saved
+	}
+	if err := s.addFEREpoch(dbheight, factoshisPerEC, s.ferActivated); err != nil {
+		s.Logf("error", "Failed to save the FER history: %v", err)
+		return
+	}
+	s.ferHistoryLive = true
+}
+
+// GoBackfillFERHistory records the saved blocks this node did not process, until recordFER
+// takes over recording each block as it is processed
+func (s *State) GoBackfillFERHistory() {
+	for {
+		live, more, err := s.backfillFERHistory(ferHistoryBatch)
+		if err != nil {
+			s.Logf("error", "Failed to update the FER history: %v", err)
+		}
+		if live {
+			return
+		}
+		if !more || err != nil {
+			time.Sleep(time.Second)
+		}
+	}
+}
+
+// backfillFERHistory records up to max saved blocks from the database.  It returns if
+// recordFER has taken over, and if there are more saved blocks to record.
+func (s *State) backfillFERHistory(max uint32) (live bool, more bool, err error) {
+	s.ferHistoryMutex.Lock()
+	defer s.ferHistoryMutex.Unlock()
+
+	if err := s.loadFERHistory(); err != nil {
+		return false, false, err
+	}
+	if s.ferHistoryLive {
+		return true, false, nil
+	}
+	head, err := s.DB.FetchDBlockHead()
+	if err != nil || head == nil {
+		return false, false, err
+	}
+	saved := head.GetDatabaseHeight() + 1
+	if s.ferHistoryHeight >= saved {
+		return false, false, nil
+	}
+	next := saved
+	if next-s.ferHistoryHeight > max {
+		next = s.ferHistoryHeight + max
+	}
+	for s.ferHistoryHeight < next {
+		fblock, err := s.DB.FetchFBlockByHeight(s.ferHistoryHeight)
+		if err != nil {
+			return false, false, err
+		}
+		if fblock == nil {
+			return false, false, fmt.Errorf("Factoid block %d is missing", s.ferHistoryHeight)
+		}
+		if err := s.addFEREpoch(s.ferHistoryHeight, fblock.GetExchRate(), nil); err != nil {
+			return false, false, err
+		}
+	}
+	return false, s.ferHistoryHeight < saved, nil
+}
+
+// activateFER notes the FER entry whose change takes effect at the next block
+func (s *State) activateFER(epoch *specialEntries.FEREpoch) {
+	s.ferHistoryMutex.Lock()
+	defer s.ferHistoryMutex.Unlock()
+
+	s.ferActivated = epoch
+}
+
+// addFEREpoch adds the rate of the next block to the history, with the FER entry that
+// activated it, if any
+func (s *State) addFEREpoch(dbheight uint32, factoshisPerEC uint64, activated *specialEntries.FEREpoch) error {
+	var epoch interfaces.IFEREpoch
+	if n := len(s.ferHistory); n == 0 || s.ferHistory[n-1].GetFactoshisPerEC() != factoshisPerEC {
+		e := specialEntries.NewFEREpoch(dbheight, factoshisPerEC)
+		if activated != nil {
+			if activated.FactoshisPerEC == factoshisPerEC {
+				e.EntryHash = activated.EntryHash
+				e.Priority = activated.Priority
+			}
+			s.ferActivated = nil
+		}
+		epoch = e
+	}
+
+	if err := s.DB.SaveFEREpoch(epoch, dbheight+1); err != nil {
+		return err
+	}
+	if epoch != nil {
+		s.ferHistory = append(s.ferHistory, epoch)
+	}
+	s.ferHistoryHeight = dbheight + 1
+	return nil
+}
+
+// GetFERHistory returns the rate epochs for the heights from to to, as a *FERHistory
+func (s *State) GetFERHistory(from, to uint32) (interface{}, error) {
+	s.ferHistoryMutex.Lock()
+	defer s.ferHistoryMutex.Unlock()
+
+	if err := s.loadFERHistory(); err != nil {
+		return nil, err
+	}
+	if to < from {
+		return nil, fmt.Errorf("Height %d is before %d", to, from)
+	}
+	if s.ferHistoryHeight == 0 || to >= s.ferHistoryHeight {
+		return nil, fmt.Errorf("The FER history is not recorded up to block %d yet", to)
+	}
+
+	history := new(FERHistory)
+	history.From = from
+	history.To = to
+	history.Epochs = []interfaces.IFEREpoch{}
+	for i, epoch := range s.ferHistory {
+		if epoch.GetActivationHeight() > to {
+			break
+		}
+		if i+1 < len(s.ferHistory) && s.ferHistory[i+1].GetActivationHeight() <= from {
+			continue // Replaced before from
+		}
+		history.Epochs = append(history.Epochs, epoch)
+	}
+	return history, nil
+}
+
+// GetFERHistoryHeight returns the height of the last block recorded in the FER history
+func (s *State) GetFERHistoryHeight() (uint32, error) {
+	s.ferHistoryMutex.Lock()
+	defer s.ferHistoryMutex.Unlock()
+
+	if err := s.loadFERHistory(); err != nil {
+		return 0, err
+	}
+	if s.ferHistoryHeight == 0 {
+		return 0, fmt.Errorf("The FER history is not recorded yet")
+	}
+	return s.ferHistoryHeight - 1, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FactomProject/factomd/common/directoryBlock"
+	"github.com/FactomProject/factomd/common/factoid"
+	. "github.com/FactomProject/factomd/state"
+	"github.com/FactomProject/factomd/testHelper"
+)
+
+func TestFERHistory(t *testing.T) {
+	// Blocks saved that we did not process are read back from the database
+	s := new(State)
+	s.DB = testHelper.CreateAndPopulateTestDatabaseOverlay()
+	s.LoadConfig("", "")
+	s.Init()
+
+	dblock, err := s.DB.FetchDBlockHead()
+	if err != nil || dblock == nil {
+		t.Fatalf("No directory blocks: %v", err)
+	}
+	top := dblock.GetDatabaseHeight()
+	fblock, err := s.DB.FetchFBlockByHeight(top)
+	if err != nil || fblock == nil {
+		t.Fatalf("No factoid block %d: %v", top, err)
+	}
+	rate := fblock.GetExchRate()
+
+	rates := []uint64{rate, 5, 5, 8, 8}
+	for _, rate := range rates {
+		fblock = factoid.NewFBlock(fblock)
+		fblock.(*factoid.FBlock).SetExchRate(rate)
+		dblock = directoryBlock.NewDirectoryBlock(dblock)
+		s.DB.StartMultiBatch()
+		s.DB.ProcessFBlockMultiBatch(fblock)
+		s.DB.ProcessDBlockMultiBatch(dblock)
+		if err := s.DB.ExecuteMultiBatch(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The API doesn't walk the blocks itself, it waits on the backfill
+	if _, err := s.GetFERHistory(0, top); err == nil {
+		t.Errorf("GetFERHistory() returned blocks that are not recorded yet")
+	}
+	go s.GoBackfillFERHistory()
+	var height uint32
+	for i := 0; i < 100; i++ {
+		height, err = s.GetFERHistoryHeight()
+		if err == nil && height == top+5 {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if err != nil || height != top+5 {
+		t.Fatalf("GetFERHistoryHeight() got %d, %v", height, err)
+	}
+
+	check := func(from, to uint32, heights []uint32, rates []uint64) {
+		h, err := s.GetFERHistory(from, to)
+		if err != nil {
+			t.Errorf("GetFERHistory(%d, %d) %v", from, to, err)
+			return
+		}
+		history := h.(*FERHistory)
+		if len(history.Epochs) != len(heights) {
+			t.Errorf("GetFERHistory(%d, %d) got %d epochs, expected %d", from, to, len(history.Epochs), len(heights))
+			return
+		}
+		for i, epoch := range history.Epochs {
+			if epoch.GetActivationHeight() != heights[i] || epoch.GetFactoshisPerEC() != rates[i] {
+				t.Errorf("GetFERHistory(%d, %d) epoch %d got %v", from, to, i, epoch)
+			}
+		}
+	}
+	check(0, top+5, []uint32{0, top + 2, top + 4}, []uint64{rate, 5, 8})
+	check(top+3, top+3, []uint32{top + 2}, []uint64{5})
+	check(top+2, top+4, []uint32{top + 2, top + 4}, []uint64{5, 8})
+	check(top+5, top+5, []uint32{top + 4}, []uint64{8})
+
+	if _, err := s.GetFERHistory(0, top+6); err == nil {
+		t.Errorf("GetFERHistory() returned a block that is not saved")
+	}
+	if _, err := s.GetFERHistory(3, 2); err == nil {
+		t.Errorf("GetFERHistory() accepted a range that goes back")
+	}
+
+	// The history is saved
+	epochs, next, err := s.DB.FetchAllFEREpochs()
+	if err != nil || len(epochs) != 3 || next != top+6 {
+		t.Errorf("FetchAllFEREpochs() got %d epochs up to %d, %v", len(epochs), next, err)
+	}
+}
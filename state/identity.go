@@ -290,6 +290,13 @@ func LoadIdentityByEntry(ent interfaces.IEBEntry, st *State, height uint32, init
 						flog.Warningf("UpdateMatryoshka - %s", err.Error())
 					}
 				}
+			} else if string(ent.ExternalIDs()[1]) == "Coinbase Address" {
+				if len(ent.ExternalIDs()) == 7 {
+					err := RegisterCoinbaseAddress(ent, initial, height, st)
+					if err != nil {
+						flog.Warningf("RegisterCoinbaseAddress - %s", err.Error())
+					}
+				}
 			} else if len(ent.ExternalIDs()) > 1 && string(ent.ExternalIDs()[1]) == "Identity Chain" {
 				addIdentity(ent, height, st)
 			} else if len(ent.ExternalIDs()) > 1 && string(ent.ExternalIDs()[1]) == "Server Management" {
@@ -560,7 +567,7 @@ func RegisterBlockSigningKey(entry interfaces.IEBEntry, initial bool, height uin
 			if !initial && statusIsFedOrAudit(status) && st.GetLeaderVM() == st.ComputeVMIndex(entry.GetChainID().Bytes()) {
 				key := primitives.NewHash(extIDs[3])
 				msg := messages.NewChangeServerKeyMsg(st, chainID, constants.TYPE_ADD_FED_SERVER_KEY, 0, 0, key)
-				err := msg.(*messages.ChangeServerKeyMsg).Sign(st.serverPrivKey)
+				err := msg.(*messages.ChangeServerKeyMsg).Sign(st.serverSigner)
 				if err != nil {
 					return errors.New("New Block Signing key for identity [" + chainID.String()[:10] + "] Error: cannot sign msg")
 				}
@@ -628,7 +635,7 @@ func UpdateMatryoshkaHash(entry interfaces.IEBEntry, initial bool, height uint32
 			if !initial && statusIsFedOrAudit(status) && st.GetLeaderVM() == st.ComputeVMIndex(entry.GetChainID().Bytes()) {
 				//if st.LeaderPL.VMIndexFor(constants.ADMIN_CHAINID) == st.GetLeaderVM() {
 				msg := messages.NewChangeServerKeyMsg(st, chainID, constants.TYPE_ADD_MATRYOSHKA, 0, 0, mhash)
-				err := msg.(*messages.ChangeServerKeyMsg).Sign(st.serverPrivKey)
+				err := msg.(*messages.ChangeServerKeyMsg).Sign(st.serverSigner)
 				if err != nil {
 					return errors.New("New Block Signing key for identity [" + chainID.String()[:10] + "] Error: cannot sign msg")
 				}
@@ -644,6 +651,84 @@ func UpdateMatryoshkaHash(entry interfaces.IEBEntry, initial bool, height uint32
 	return nil
 }
 
+// RegisterCoinbaseAddress checks an entry setting the factoid address an identity's coinbase
+// payouts go to.  The entry is in the identity chain, signed by the level 1 identity key:
+//
+//	[0]     0x00
+//	[1]     "Coinbase Address"
+//	[2]     identity chain ID
+//	[3]     factoid address (the RCD hash)
+//	[4]     timestamp, within 12 hours of the block the entry is in
+//	[5]     0x01 + level 1 public key
+//	[6]     signature of [0] to [4]
+//
+// Identities load as they are needed, so the entry only gets the leader in charge of the
+// identity chain to put the address in the admin block.  The payouts go by the admin block
+// (see coinbasePayouts).
+func RegisterCoinbaseAddress(entry interfaces.IEBEntry, initial bool, height uint32, st *State) error {
+	extIDs := entry.ExternalIDs()
+	if len(extIDs) == 0 {
+		return errors.New("Identity Error Coinbase Address: Invalid external ID length")
+	}
+	if bytes.Compare([]byte{0x00}, extIDs[0]) != 0 || // Version
+		!CheckExternalIDsLength(extIDs, []int{1, 16, 32, 32, 8, 33, 64}) { // Signiture
+		return errors.New("Identity Error Coinbase Address: Invalid external ID length")
+	}
+	chainID := new(primitives.Hash)
+	chainID.SetBytes(extIDs[2][:32])
+
+	IdentityIndex := st.isIdentityChain(chainID)
+	if IdentityIndex == -1 {
+		return errors.New("Identity Error: This cannot happen. Coinbase Address to nonexistent identity")
+	}
+
+	if !st.Identities[IdentityIndex].IdentityChainID.IsSameAs(entry.GetChainID()) {
+		return errors.New("Identity Error: Coinbase Address was not placed in the identity chain")
+	}
+
+	sigmsg, err := AppendExtIDs(extIDs, 0, 4)
+	if err != nil {
+		return err
+	}
+	// Verify Signature
+	idKey := st.Identities[IdentityIndex].Key1
+	if !CheckSig(idKey, extIDs[5][1:33], sigmsg, extIDs[6]) {
+		return errors.New("Coinbase Address for identity [" + chainID.String()[:10] + "] is invalid. Bad signiture")
+	}
+
+	// The entry is in a block already saved, or in the one we are building
+	var blockTime int64
+	dbase := st.GetAndLockDB()
+	dblk, err := dbase.FetchDBlockByHeight(height)
+	st.UnlockDB()
+	if err == nil && dblk != nil {
+		blockTime = dblk.GetHeader().GetTimestamp().GetTimeSeconds()
+	} else if height == st.GetLLeaderHeight() {
+		blockTime = st.GetLeaderTimestamp().GetTimeSeconds()
+	}
+	if blockTime == 0 {
+		return errors.New("Coinbase Address for identity [" + chainID.String()[:10] + "] is in a block we don't have")
+	}
+	if !CheckTimestamp(extIDs[4], blockTime) {
+		return errors.New("Coinbase Address for identity [" + chainID.String()[:10] + "] timestamp is too old")
+	}
+
+	// Add to admin block if the following:
+	//		Not the initial load
+	//		A Federated or Audit server
+	//		This node is charge of admin block
+	status := st.Identities[IdentityIndex].Status
+	if !initial && statusIsFedOrAudit(status) && st.GetLeaderVM() == st.ComputeVMIndex(entry.GetChainID().Bytes()) {
+		msg := messages.NewChangeServerKeyMsg(st, chainID, constants.TYPE_ADD_FACTOID_ADDRESS, 0, 0, primitives.NewHash(extIDs[3]))
+		err := msg.(*messages.ChangeServerKeyMsg).Sign(st.serverSigner)
+		if err != nil {
+			return errors.New("Coinbase Address for identity [" + chainID.String()[:10] + "] Error: cannot sign msg")
+		}
+		st.InMsgQueue().Enqueue(msg)
+	}
+	return nil
+}
+
 func RegisterAnchorSigningKey(entry interfaces.IEBEntry, initial bool, height uint32, st *State, BlockChain string) error {
 	extIDs := entry.ExternalIDs()
 	if bytes.Compare([]byte{0x00}, extIDs[0]) != 0 ||
@@ -725,7 +810,7 @@ func RegisterAnchorSigningKey(entry interfaces.IEBEntry, initial bool, height ui
 				extIDs[5] = append(extIDs[5], []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}...)
 				key := primitives.NewHash(extIDs[5])
 				msg := messages.NewChangeServerKeyMsg(st, chainID, constants.TYPE_ADD_BTC_ANCHOR_KEY, extIDs[3][0], extIDs[4][0], key)
-				err := msg.(*messages.ChangeServerKeyMsg).Sign(st.serverPrivKey)
+				err := msg.(*messages.ChangeServerKeyMsg).Sign(st.serverSigner)
 				if err != nil {
 					return errors.New("New Block Signing key for identity [" + chainID.String()[:10] + "] Error: cannot sign msg")
 				}
@@ -124,6 +124,34 @@ var (
 		Help: "Tally of total messages drained out of MsgQueue (useful for rating)",
 	})
 
+	// Mempool
+	MempoolSize = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "factomd_state_mempool_size",
+		Help: "Number of messages waiting to get into a block",
+	})
+	MempoolEvictions = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "factomd_state_mempool_evictions",
+		Help: "Tally of commits, reveals and transactions evicted from Holding by the mempool limits",
+	})
+
+	// Supply
+	FactoidSupply = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "factomd_state_factoid_supply",
+		Help: "Factoshis held by all factoid addresses",
+	})
+	ECOutstanding = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "factomd_state_ec_outstanding",
+		Help: "Entry credits held by all entry credit addresses",
+	})
+	FundedFactoidAddresses = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "factomd_state_funded_factoid_addresses",
+		Help: "Number of factoid addresses with a balance",
+	})
+	FundedECAddresses = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "factomd_state_funded_ec_addresses",
+		Help: "Number of entry credit addresses with a balance",
+	})
+
 	// Holding Queue
 	TotalHoldingQueueInputs = prometheus.NewCounter(prometheus.CounterOpts{
 		Name: "factomd_state_holding_queue_total_inputs",
@@ -353,4 +381,10 @@ func RegisterPrometheus() {
 	prometheus.MustRegister(TotalEmptyLoopTime)
 	prometheus.MustRegister(TotalAckLoopTime)
 	prometheus.MustRegister(TotalExecuteMsgTime)
+	prometheus.MustRegister(MempoolSize)
+	prometheus.MustRegister(MempoolEvictions)
+	prometheus.MustRegister(FactoidSupply)
+	prometheus.MustRegister(ECOutstanding)
+	prometheus.MustRegister(FundedFactoidAddresses)
+	prometheus.MustRegister(FundedECAddresses)
 }
@@ -0,0 +1,308 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package state
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/common/messages"
+	"github.com/FactomProject/factomd/common/primitives"
+)
+
+// Deterministic replay of a message journal.  A node journaling (-journaling) writes every message
+// as it takes it off its queues, with the time it did so.  Given the node's configuration (identity
+// and keys) and a copy of its database from when the journal started, ReplayJournal feeds the
+// messages through the state one at a time on a single goroutine, on a simulated clock moved to the
+// time each was journaled.  Every block saved is checked against a reference database, usually the
+// node's own, and the replay stops at the first block that differs.
+
+// JournalEntry is a message read back from a journal
+type JournalEntry struct {
+	Timestamp interfaces.Timestamp // when the node took the message off its queues
+	Local     bool
+	Msg       interfaces.IMsg
+}
+
+// ReplayCheckpoint is a block saved during a replay
+type ReplayCheckpoint struct {
+	DBHeight  uint32
+	KeyMR     string
+	Reference string // "" if the reference database does not have the block
+}
+
+type ReplayReport struct {
+	Messages    int // journal entries replayed
+	Checkpoints []ReplayCheckpoint
+	Divergence  *ReplayCheckpoint // the first block that differs from the reference, nil if none
+	Err         error             // why the replay stopped early, nil if it didn't
+}
+
+func (r *ReplayReport) String() string {
+	var out primitives.Buffer
+	out.WriteString(fmt.Sprintf("Replayed %d messages, saved %d blocks\n", r.Messages, len(r.Checkpoints)))
+	for _, c := range r.Checkpoints {
+		out.WriteString(fmt.Sprintf("%10d %s %s\n", c.DBHeight, c.KeyMR, c.Reference))
+	}
+	if r.Err != nil {
+		out.WriteString(fmt.Sprintf("Stopped: %v\n", r.Err))
+	}
+	if r.Divergence != nil {
+		out.WriteString(fmt.Sprintf("Diverged at block %d: KeyMR %s, reference %s\n", r.Divergence.DBHeight, r.Divergence.KeyMR, r.Divergence.Reference))
+	} else {
+		out.WriteString("No divergence\n")
+	}
+	return out.String()
+}
+
+// journalHex returns the message in hex for the journal, or "" if it does not marshal
+func journalHex(msg interfaces.IMsg) (msgHex string) {
+	defer func() {
+		if r := recover(); r != nil {
+			msgHex = ""
+		}
+	}()
+
+	data, err := msg.MarshalBinary()
+	if err != nil {
+		return ""
+	}
+	return hex.EncodeToString(data)
+}
+
+// ParseJournalLine reads a line written by JournalMessage, or an older "MsgHex: <hex>" line.
+// Returns nil for lines without a message we can unmarshal.
+func ParseJournalLine(line []byte) (*JournalEntry, error) {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 {
+		return nil, nil
+	}
+
+	entry := new(JournalEntry)
+	var msgHex string
+	if line[0] == '{' {
+		var j struct {
+			Timestamp int64
+			Local     bool
+			MsgHex    string
+		}
+		if err := json.Unmarshal(line, &j); err != nil {
+			return nil, err
+		}
+		if j.Timestamp > 0 {
+			entry.Timestamp = primitives.NewTimestampFromMilliseconds(uint64(j.Timestamp))
+		}
+		entry.Local = j.Local
+		msgHex = j.MsgHex
+	} else {
+		adv, word, _ := bufio.ScanWords(line, true)
+		if string(word) != "MsgHex:" {
+			return nil, nil
+		}
+		_, data, _ := bufio.ScanWords(line[adv:], true)
+		msgHex = string(data)
+	}
+	if len(msgHex) == 0 {
+		return nil, nil // Journals from before the message was saved in binary
+	}
+
+	data, err := hex.DecodeString(msgHex)
+	if err != nil {
+		return nil, err
+	}
+	entry.Msg, err = messages.UnmarshalMessage(data)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Timestamp == nil {
+		entry.Timestamp = entry.Msg.GetTimestamp()
+	}
+	return entry, nil
+}
+
+// ReadJournal reads all the messages in a journal
+func ReadJournal(r io.Reader) ([]*JournalEntry, error) {
+	var entries []*JournalEntry
+	br := bufio.NewReaderSize(r, 4*1024)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			entry, perr := ParseJournalLine(line)
+			if perr != nil {
+				return entries, fmt.Errorf("Journal entry %d: %v", len(entries), perr)
+			}
+			if entry != nil {
+				entries = append(entries, entry)
+			}
+		}
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return entries, err
+		}
+	}
+}
+
+// ReplayJournal replays the journal into s and checks the blocks saved against the reference database.
+// s must be initialized with the configuration of the node that wrote the journal and a copy of its
+// database, and must not be running its ValidatorLoop or Timer.  The database is loaded first.
+// The state is left on the simulated clock the replay ran on.
+func (s *State) ReplayJournal(entries []*JournalEntry, reference interfaces.DBOverlaySimple) *ReplayReport {
+	report := new(ReplayReport)
+	if len(entries) == 0 {
+		return report
+	}
+
+	// The node booted just before the journal started
+	clock := primitives.NewSimClock(entries[0].Timestamp.GetTime())
+	s.SetClock(clock)
+	s.StartDelay = entries[0].Timestamp.GetTimeMilli()
+	s.BootTime = entries[0].Timestamp.GetTimeSeconds()
+
+	// The database loads on its own goroutine, as at boot, but DBFinished is only set here,
+	// once it is done, as nothing else may touch the state.
+	loaded := make(chan struct{})
+	go func() {
+		defer close(loaded)
+		loadDatabase(s)
+	}()
+	for finished := false; ; {
+		if !finished {
+			select {
+			case <-loaded:
+				finished = true // everything loaded is queued by now
+			default:
+			}
+		}
+		msg := s.InMsgQueue().Dequeue()
+		if msg != nil {
+			if report.Err = s.replayMessage(msg); report.Err != nil {
+				s.drainLoad(loaded)
+				return report
+			}
+		} else if finished {
+			break
+		} else {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	SetDBFinished(s)
+
+	next := s.GetHighestSavedBlk() + 1
+	for _, entry := range entries {
+		clock.Set(entry.Timestamp.GetTime())
+		entry.Msg.SetLocal(entry.Local)
+		if report.Err = s.replayMessage(entry.Msg); report.Err != nil {
+			return report
+		}
+		report.Messages++
+
+		for ; next <= s.GetHighestSavedBlk(); next++ {
+			c := ReplayCheckpoint{DBHeight: next}
+			if keyMR, err := s.DB.FetchDBKeyMRByHeight(next); err == nil && keyMR != nil {
+				c.KeyMR = keyMR.String()
+			}
+			if keyMR, err := reference.FetchDBKeyMRByHeight(next); err == nil && keyMR != nil {
+				c.Reference = keyMR.String()
+			}
+			report.Checkpoints = append(report.Checkpoints, c)
+			if len(c.Reference) > 0 && c.KeyMR != c.Reference {
+				report.Divergence = &c
+				return report
+			}
+		}
+	}
+	return report
+}
+
+// drainLoad throws away what the database loader queues until it is done, so it doesn't block
+func (s *State) drainLoad(loaded chan struct{}) {
+	for {
+		select {
+		case <-loaded:
+			return
+		default:
+			if s.InMsgQueue().Dequeue() == nil {
+				time.Sleep(10 * time.Millisecond)
+			}
+		}
+	}
+}
+
+// The most rounds of processing a replayed message may lead to before we give up on the state settling
+const MaxReplayRounds = 10000
+
+// replayProgress is what we watch to see a round of processing change the state.  Process() and
+// UpdateState() report progress on every call once the block is saved, so we can't go by them.
+type replayProgress struct {
+	saved, complete, leader uint32
+	minute, eoms, dbsigs    int
+	holding, acks, xreview  int
+	vmHeights               int
+	dbstates                int
+}
+
+func (s *State) replayProgress() replayProgress {
+	r := replayProgress{
+		saved:    s.GetHighestSavedBlk(),
+		complete: s.GetHighestCompletedBlk(),
+		leader:   s.LLeaderHeight,
+		minute:   s.CurrentMinute,
+		eoms:     s.EOMProcessed,
+		dbsigs:   s.DBSigProcessed,
+		holding:  len(s.Holding),
+		acks:     len(s.Acks),
+		xreview:  len(s.XReview),
+		dbstates: len(s.DBStates.DBStates),
+	}
+	if pl := s.ProcessLists.GetSafe(s.LLeaderHeight); pl != nil {
+		for _, vm := range pl.VMs {
+			r.vmHeights += vm.Height
+		}
+	}
+	return r
+}
+
+// replayMessage sorts the message as the ValidatorLoop does, and processes it and everything it
+// leads to, until a round changes nothing.  Returns an error if the state is still changing after
+// MaxReplayRounds.
+func (s *State) replayMessage(msg interfaces.IMsg) error {
+	if _, ok := msg.(*messages.Ack); ok {
+		s.ackQueue <- msg
+	} else {
+		s.msgQueue <- msg
+	}
+	last := s.replayProgress()
+	for i := 0; i < MaxReplayRounds; i++ {
+		s.Process()
+		s.UpdateState()
+
+		// There is no network to send to
+		for s.networkOutMsgQueue.Dequeue() != nil {
+		}
+	invalid:
+		for {
+			select {
+			case <-s.networkInvalidMsgQueue:
+			default:
+				break invalid
+			}
+		}
+
+		now := s.replayProgress()
+		if now == last && len(s.msgQueue) == 0 && len(s.ackQueue) == 0 {
+			return nil
+		}
+		last = now
+	}
+	return fmt.Errorf("The state did not settle after %d rounds of processing %s", MaxReplayRounds, msg.String())
+}
@@ -0,0 +1,131 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package state_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"testing"
+
+	"github.com/FactomProject/factomd/common/directoryBlock"
+	"github.com/FactomProject/factomd/common/messages"
+	"github.com/FactomProject/factomd/common/primitives"
+	. "github.com/FactomProject/factomd/state"
+	"github.com/FactomProject/factomd/testHelper"
+)
+
+func TestReadJournal(t *testing.T) {
+	s := testHelper.CreateAndPopulateTestState()
+	filename := "journalreplaytest.log"
+	defer os.Remove(filename)
+	s.JournalFile = filename
+	if _, err := os.Create(s.JournalFile); err != nil {
+		t.Fatal(err)
+	}
+	s.Journaling = true
+
+	eom := new(messages.EOM)
+	eom.Timestamp = primitives.NewTimestampFromMilliseconds(1234)
+	eom.ChainID = primitives.Sha([]byte("leader"))
+	eom.SetLocal(true)
+	s.JournalMessage(eom)
+
+	// Journals used to hold only the message in hex
+	data, _ := eom.MarshalBinary()
+	legacy := "MsgHex: " + hex.EncodeToString(data) + "\n"
+
+	var journal bytes.Buffer
+	for _, line := range s.GetJournalMessages() {
+		journal.Write(line)
+	}
+	journal.WriteString("not a message\n")
+	journal.WriteString(legacy)
+
+	entries, err := ReadJournal(&journal)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("ReadJournal() got %d entries, %v", len(entries), err)
+	}
+	if !entries[0].Msg.GetMsgHash().IsSameAs(eom.GetMsgHash()) || !entries[0].Local || entries[0].Timestamp.GetTimeMilli() == 1234 {
+		t.Errorf("got %+v", entries[0])
+	}
+	if !entries[1].Msg.GetMsgHash().IsSameAs(eom.GetMsgHash()) || entries[1].Local || entries[1].Timestamp.GetTimeMilli() != 1234 {
+		t.Errorf("got %+v", entries[1])
+	}
+
+	if _, err := ParseJournalLine([]byte("MsgHex: zz")); err == nil {
+		t.Errorf("ParseJournalLine() accepted bad hex")
+	}
+}
+
+// newReplayState returns a state with only the first block of the reference database, as the
+// node had when it started its journal
+func newReplayState(first *messages.DBStateMsg) *State {
+	db := testHelper.CreateEmptyTestDatabaseOverlay()
+	db.ProcessABlockBatch(first.AdminBlock)
+	db.ProcessFBlockBatch(first.FactoidBlock)
+	db.ProcessECBlockBatch(first.EntryCreditBlock, false)
+	db.ProcessDBlockBatch(first.DirectoryBlock)
+
+	s := new(State)
+	s.DB = db
+	s.LoadConfig("", "")
+	s.Network = "LOCAL"
+	s.Init()
+	s.Network = "LOCAL"
+	s.SetFactoshisPerEC(1)
+	return s
+}
+
+func TestReplayJournal(t *testing.T) {
+	ref := new(State)
+	ref.DB = testHelper.CreateAndPopulateTestDatabaseOverlay()
+	msgs := testHelper.GetAllDBStateMsgsFromDatabase(ref)
+	var entries []*JournalEntry
+	for _, msg := range msgs[1:] {
+		msg.(*messages.DBStateMsg).IgnoreSigs = true // The test blocks are not signed
+		entries = append(entries, &JournalEntry{Timestamp: msg.GetTimestamp(), Local: true, Msg: msg})
+	}
+
+	s := newReplayState(msgs[0].(*messages.DBStateMsg))
+	report := s.ReplayJournal(entries, ref.DB)
+	if report.Messages != len(entries) || report.Divergence != nil || len(report.Checkpoints) == 0 {
+		t.Fatalf("ReplayJournal() got %s", report.String())
+	}
+	for _, c := range report.Checkpoints {
+		if c.KeyMR != c.Reference {
+			t.Errorf("got checkpoint %+v", c)
+		}
+	}
+	if !s.DBFinished {
+		t.Errorf("DBFinished not set")
+	}
+	// The state was driven by the journal's clock, not the wall clock
+	if _, ok := s.GetClock().(*primitives.SimClock); !ok {
+		t.Errorf("got clock %T", s.GetClock())
+	}
+	var last int64
+	for _, entry := range entries {
+		if last < entry.Timestamp.GetTimeMilli() {
+			last = entry.Timestamp.GetTimeMilli()
+		}
+	}
+	if s.GetTimestamp().GetTimeMilli() != last {
+		t.Errorf("got time %d, want %d", s.GetTimestamp().GetTimeMilli(), last)
+	}
+
+	// Against another chain, the replay stops at the first block
+	other := testHelper.CreateEmptyTestDatabaseOverlay()
+	var prev *directoryBlock.DirectoryBlock
+	for i := 0; i < len(entries); i++ {
+		prev = testHelper.CreateTestDirectoryBlockWithNetworkID(prev, 1234)
+		other.ProcessDBlockBatch(prev)
+	}
+	s = newReplayState(msgs[0].(*messages.DBStateMsg))
+	report = s.ReplayJournal(entries, other)
+	if report.Divergence == nil || report.Divergence.DBHeight != report.Checkpoints[0].DBHeight || report.Messages == len(entries) {
+		t.Errorf("ReplayJournal() got %s", report.String())
+	}
+}
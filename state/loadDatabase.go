@@ -28,7 +28,11 @@ func SetDBFinished(s *State) {
 
 func LoadDatabase(s *State) {
 	defer SetDBFinished(s)
+	loadDatabase(s)
+}
 
+// loadDatabase queues the blocks in the database for the state, without setting DBFinished
+func loadDatabase(s *State) {
 	var blkCnt uint32
 
 	head, err := s.DB.FetchDBlockHead()
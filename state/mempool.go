@@ -0,0 +1,280 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package state
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/FactomProject/factomd/common/constants"
+	"github.com/FactomProject/factomd/common/factoid"
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/common/messages"
+	"github.com/FactomProject/factomd/common/primitives"
+)
+
+// The mempool is every message waiting to get into a block: what is in holding and XReview, and
+// the commits, reveals and transactions in the process lists still being built.  The messages
+// stay where they are.  Once a second the state's own goroutine brings the Mempool up to date,
+// evicts commits, reveals and transactions from holding that are too old (or too many, oldest
+// first) and publishes a snapshot the API can query from other goroutines.
+//
+// Limits come from State.MempoolMaxSize and State.MempoolMaxAge; zero means no limit.  Consensus
+// messages (EOMs, DBSigs, faults...) are counted but never evicted here, ReviewHolding has its
+// own rules for those.  Neither is anything in a process list, it has been acknowledged.
+
+const (
+	MempoolHolding     = "holding"
+	MempoolReview      = "review"
+	MempoolProcessList = "process-list"
+
+	MempoolEvictedAge  = "age"
+	MempoolEvictedSize = "size"
+)
+
+// MempoolForget is how long a message can go unseen before we forget when it arrived.  Messages
+// move between holding and XReview, and may be in neither when we look.
+var MempoolForget = 10 * time.Second
+
+type MempoolMessage struct {
+	Hash      string
+	Type      string
+	Where     string
+	FirstSeen time.Time
+	Age       float64  // seconds
+	ChainIDs  []string `json:",omitempty"` // commit chains give their ChainIDHash, as in pending-entries
+	Addresses []string `json:",omitempty"` // EC address paying for a commit, addresses in a transaction
+
+	key      [32]byte
+	user     bool // a commit, reveal or transaction
+	lastSeen time.Time
+}
+
+type Mempool struct {
+	messages map[[32]byte]*MempoolMessage // only used by the state's goroutine
+	lastSync time.Time
+
+	mutex    sync.RWMutex
+	snapshot []MempoolMessage // oldest first
+	evicted  map[string]uint64
+}
+
+// MempoolStatus is what the API gets back
+type MempoolStatus struct {
+	Size     int
+	MaxSize  int
+	MaxAge   int               // seconds
+	Counts   map[string]int    // by message type
+	Where    map[string]int    // by where the messages are
+	Evicted  map[string]uint64 // since we started, by reason
+	Messages []MempoolMessage  // those matching the query, oldest first
+}
+
+func NewMempool() *Mempool {
+	m := new(Mempool)
+	m.messages = make(map[[32]byte]*MempoolMessage)
+	m.evicted = make(map[string]uint64)
+	return m
+}
+
+// isUserMessage is true for the messages users submit, the only ones the mempool evicts
+func isUserMessage(msg interfaces.IMsg) bool {
+	switch msg.Type() {
+	case constants.COMMIT_CHAIN_MSG, constants.COMMIT_ENTRY_MSG, constants.REVEAL_ENTRY_MSG, constants.FACTOID_TRANSACTION_MSG:
+		return true
+	}
+	return false
+}
+
+func newMempoolMessage(key [32]byte, msg interfaces.IMsg, now time.Time) *MempoolMessage {
+	mm := &MempoolMessage{Hash: primitives.NewHash(key[:]).String(), Type: messages.MessageName(msg.Type()), FirstSeen: now, key: key, user: isUserMessage(msg)}
+	ecAddress := func(pub *primitives.ByteSlice32) string {
+		return primitives.ConvertECAddressToUserStr(factoid.NewAddress(pub[:]))
+	}
+	switch m := msg.(type) {
+	case *messages.CommitChainMsg:
+		mm.ChainIDs = []string{m.CommitChain.ChainIDHash.String()}
+		mm.Addresses = []string{ecAddress(m.CommitChain.ECPubKey)}
+	case *messages.CommitEntryMsg:
+		mm.Addresses = []string{ecAddress(m.CommitEntry.ECPubKey)}
+	case *messages.RevealEntryMsg:
+		mm.ChainIDs = []string{m.Entry.GetChainID().String()}
+	case *messages.FactoidTransaction:
+		tx := m.GetTransaction()
+		for _, in := range tx.GetInputs() {
+			mm.Addresses = append(mm.Addresses, primitives.ConvertFctAddressToUserStr(in.GetAddress()))
+		}
+		for _, out := range tx.GetOutputs() {
+			mm.Addresses = append(mm.Addresses, primitives.ConvertFctAddressToUserStr(out.GetAddress()))
+		}
+		for _, out := range tx.GetECOutputs() {
+			mm.Addresses = append(mm.Addresses, primitives.ConvertECAddressToUserStr(out.GetAddress()))
+		}
+	}
+	return mm
+}
+
+// Sync brings the mempool up to date with holding, XReview and the process lists, and applies the limits.
+// Only call it from the state's goroutine.
+func (m *Mempool) Sync(s *State, now time.Time) {
+	seen := func(key [32]byte, msg interfaces.IMsg, where string) {
+		mm, ok := m.messages[key]
+		if !ok {
+			mm = newMempoolMessage(key, msg, now)
+			m.messages[key] = mm
+		} else if mm.lastSeen.Equal(now) {
+			return // Already seen this time around
+		}
+		mm.Where = where
+		mm.lastSeen = now
+	}
+	for k, msg := range s.Holding {
+		if msg != nil {
+			seen(k, msg, MempoolHolding)
+		}
+	}
+	for _, msg := range s.XReview {
+		if msg != nil {
+			seen(msg.GetMsgHash().Fixed(), msg, MempoolReview)
+		}
+	}
+	saved := s.GetHighestSavedBlk()
+	for _, pl := range s.ProcessLists.Lists {
+		if pl == nil || pl.DBHeight <= saved {
+			continue
+		}
+		for _, vm := range pl.VMs {
+			for _, msg := range vm.List {
+				if msg != nil && isUserMessage(msg) {
+					seen(msg.GetMsgHash().Fixed(), msg, MempoolProcessList)
+				}
+			}
+		}
+	}
+
+	var current, candidates []*MempoolMessage
+	users := 0
+	for k, mm := range m.messages {
+		if now.Sub(mm.lastSeen) > MempoolForget {
+			delete(m.messages, k)
+			continue
+		}
+		if !mm.lastSeen.Equal(now) {
+			continue
+		}
+		current = append(current, mm)
+		if mm.user {
+			users++
+			if mm.Where == MempoolHolding {
+				candidates = append(candidates, mm)
+			}
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].FirstSeen.Before(candidates[j].FirstSeen) })
+
+	evicted := map[[32]byte]string{}
+	maxAge := time.Duration(s.MempoolMaxAge) * time.Second
+	for _, mm := range candidates {
+		if maxAge > 0 && now.Sub(mm.FirstSeen) > maxAge {
+			evicted[mm.key] = MempoolEvictedAge
+		} else if s.MempoolMaxSize > 0 && users > s.MempoolMaxSize {
+			evicted[mm.key] = MempoolEvictedSize
+		} else {
+			continue
+		}
+		users--
+		TotalHoldingQueueOutputs.Inc()
+		MempoolEvictions.Inc()
+		delete(s.Holding, mm.key)
+		delete(m.messages, mm.key)
+	}
+
+	snapshot := make([]MempoolMessage, 0, len(current))
+	for _, mm := range current {
+		if _, gone := evicted[mm.key]; gone {
+			continue
+		}
+		c := *mm
+		c.Age = now.Sub(mm.FirstSeen).Seconds()
+		snapshot = append(snapshot, c)
+	}
+	sort.Slice(snapshot, func(i, j int) bool { return snapshot[i].FirstSeen.Before(snapshot[j].FirstSeen) })
+	MempoolSize.Set(float64(len(snapshot)))
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.snapshot = snapshot
+	for _, reason := range evicted {
+		m.evicted[reason]++
+	}
+}
+
+// Query returns the counts, and the messages for chainID, address and message type ("" matches
+// anything).  limit caps the number of messages returned, 0 for all of them.
+func (m *Mempool) Query(chainID string, address string, msgType string, limit int) *MempoolStatus {
+	st := new(MempoolStatus)
+	st.Counts = map[string]int{}
+	st.Where = map[string]int{}
+	st.Evicted = map[string]uint64{}
+	st.Messages = []MempoolMessage{}
+	if m == nil {
+		return st
+	}
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	st.Size = len(m.snapshot)
+	for reason, n := range m.evicted {
+		st.Evicted[reason] = n
+	}
+	for _, mm := range m.snapshot {
+		st.Counts[mm.Type]++
+		st.Where[mm.Where]++
+		if len(msgType) > 0 && !strings.EqualFold(msgType, mm.Type) {
+			continue
+		}
+		if len(chainID) > 0 && !containsString(mm.ChainIDs, chainID) {
+			continue
+		}
+		if len(address) > 0 && !containsString(mm.Addresses, address) {
+			continue
+		}
+		if limit > 0 && len(st.Messages) >= limit {
+			continue
+		}
+		st.Messages = append(st.Messages, mm)
+	}
+	return st
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// syncMempool updates the mempool once a second
+func (s *State) syncMempool() {
+	now := s.GetClock().Now()
+	if s.Mempool == nil || now.Sub(s.Mempool.lastSync) < time.Second {
+		return
+	}
+	s.Mempool.lastSync = now
+	s.Mempool.Sync(s, now)
+}
+
+// GetMempool returns a *MempoolStatus with the messages matching chainID, address and message type
+// ("" matches anything), up to limit of them (0 for all)
+func (s *State) GetMempool(chainID string, address string, msgType string, limit int) interface{} {
+	st := s.Mempool.Query(chainID, address, msgType, limit)
+	st.MaxSize = s.MempoolMaxSize
+	st.MaxAge = s.MempoolMaxAge
+	return st
+}
@@ -0,0 +1,107 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FactomProject/factomd/common/factoid"
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/common/messages"
+	"github.com/FactomProject/factomd/common/primitives"
+	. "github.com/FactomProject/factomd/state"
+	"github.com/FactomProject/factomd/testHelper"
+)
+
+func TestMempool(t *testing.T) {
+	s := testHelper.CreateAndPopulateTestState()
+	s.Holding = make(map[[32]byte]interfaces.IMsg)
+	s.XReview = nil
+	s.MempoolMaxSize = 0
+	s.MempoolMaxAge = 0
+	m := NewMempool()
+
+	eblock, entries := testHelper.CreateTestEntryBlock(nil)
+	commit := messages.NewCommitEntryMsg()
+	commit.CommitEntry = testHelper.NewCommitEntry(eblock)
+	reveal := messages.NewRevealEntryMsg()
+	reveal.Entry = entries[0]
+	tx := new(factoid.Transaction)
+	tx.AddInput(testHelper.NewFactoidAddress(1), 100)
+	tx.AddOutput(testHelper.NewFactoidAddress(2), 100)
+	fct := new(messages.FactoidTransaction)
+	fct.SetTransaction(tx)
+	eom := new(messages.EOM)
+	eom.Timestamp = primitives.NewTimestampNow()
+	eom.ChainID = primitives.Sha([]byte("leader"))
+
+	now := time.Now()
+	s.Holding[commit.GetMsgHash().Fixed()] = commit
+	s.Holding[eom.GetMsgHash().Fixed()] = eom
+	s.XReview = append(s.XReview, reveal)
+	m.Sync(s, now)
+	now = now.Add(5 * time.Second)
+	s.Holding[fct.GetMsgHash().Fixed()] = fct
+	m.Sync(s, now)
+
+	st := m.Query("", "", "", 0)
+	if st.Size != 4 || len(st.Messages) != 4 || st.Where[MempoolHolding] != 3 || st.Where[MempoolReview] != 1 {
+		t.Fatalf("Query() got %+v", st)
+	}
+	if st.Counts["Commit Entry"] != 1 || st.Counts["EOM"] != 1 || st.Messages[3].Hash != fct.GetMsgHash().String() {
+		t.Errorf("Query() got %+v", st)
+	}
+	if st = m.Query(entries[0].GetChainID().String(), "", "", 0); len(st.Messages) != 1 || st.Messages[0].Hash != reveal.GetMsgHash().String() {
+		t.Errorf("Query() by chain got %+v", st.Messages)
+	}
+	if st = m.Query("", testHelper.NewECAddressString(0), "", 0); len(st.Messages) != 1 || st.Messages[0].Hash != commit.GetMsgHash().String() {
+		t.Errorf("Query() by EC address got %+v", st.Messages)
+	}
+	fctAddress := primitives.ConvertFctAddressToUserStr(testHelper.NewFactoidAddress(2))
+	if st = m.Query("", fctAddress, "", 0); len(st.Messages) != 1 || st.Messages[0].Age != 0 {
+		t.Errorf("Query() by factoid address got %+v", st.Messages)
+	}
+	if st = m.Query("", "", "factoid transaction", 0); len(st.Messages) != 1 {
+		t.Errorf("Query() by type got %+v", st.Messages)
+	}
+	if st = m.Query("", "", "", 2); len(st.Messages) != 2 || st.Size != 4 {
+		t.Errorf("Query() with a limit got %+v", st)
+	}
+
+	// The commit is the oldest user message in holding, so it goes first.  The reveal is in XReview and the EOM
+	// is not ours to evict, the transaction is the only one left.
+	s.MempoolMaxSize = 1
+	now = now.Add(time.Second)
+	m.Sync(s, now)
+	if _, ok := s.Holding[commit.GetMsgHash().Fixed()]; ok {
+		t.Errorf("The commit should have been evicted")
+	}
+	if _, ok := s.Holding[fct.GetMsgHash().Fixed()]; ok {
+		t.Errorf("The transaction should have been evicted")
+	}
+	if st = m.Query("", "", "", 0); st.Size != 2 || st.Evicted[MempoolEvictedSize] != 2 {
+		t.Errorf("Query() got %+v", st)
+	}
+
+	s.MempoolMaxSize = 0
+	s.MempoolMaxAge = 10
+	s.Holding[fct.GetMsgHash().Fixed()] = fct
+	s.XReview = nil
+	s.Holding[reveal.GetMsgHash().Fixed()] = reveal
+	now = now.Add(5 * time.Second)
+	m.Sync(s, now)
+	now = now.Add(6 * time.Second)
+	m.Sync(s, now)
+	if _, ok := s.Holding[reveal.GetMsgHash().Fixed()]; ok {
+		t.Errorf("The reveal should have been evicted")
+	}
+	if _, ok := s.Holding[fct.GetMsgHash().Fixed()]; !ok {
+		t.Errorf("The transaction is not old enough to be evicted")
+	}
+	if st = m.Query("", "", "", 0); st.Size != 2 || st.Evicted[MempoolEvictedAge] != 1 {
+		t.Errorf("Query() got %+v", st)
+	}
+}
@@ -46,6 +46,7 @@ func PrintState(state *State) {
 	str = fmt.Sprintf("%s %35s = %+v\n", str, "ExportData", state.ExportData)
 	str = fmt.Sprintf("%s %35s = %+v\n", str, "ExportDataSubpath", state.ExportDataSubpath)
 	str = fmt.Sprintf("%s %35s = %+v\n", str, "LocalServerPrivKey", state.LocalServerPrivKey)
+	str = fmt.Sprintf("%s %35s = %+v\n", str, "LocalServerSigner", state.LocalServerSigner)
 	str = fmt.Sprintf("%s %35s = %+v\n", str, "DirectoryBlockInSeconds", state.DirectoryBlockInSeconds)
 	str = fmt.Sprintf("%s %35s = %+v\n", str, "PortNumber", state.PortNumber)
 	str = fmt.Sprintf("%s %35s = %+v\n", str, "DropRate", state.DropRate)
@@ -65,6 +66,9 @@ func PrintState(state *State) {
 	str = fmt.Sprintf("%s %35s = %+v\n", str, "LocalNetworkPort", state.LocalNetworkPort)
 	str = fmt.Sprintf("%s %35s = %+v\n", str, "LocalSeedURL", state.LocalSeedURL)
 	str = fmt.Sprintf("%s %35s = %+v\n", str, "LocalSpecialPeers", state.LocalSpecialPeers)
+	str = fmt.Sprintf("%s %35s = %+v\n", str, "ExternalAddress", state.ExternalAddress)
+	str = fmt.Sprintf("%s %35s = %+v\n", str, "NATTraversal", state.NATTraversal)
+	str = fmt.Sprintf("%s %35s = %+v\n", str, "NATGateway", state.NATGateway)
 	str = fmt.Sprintf("%s %35s = %+v\n", str, "CustomNetworkID", state.CustomNetworkID)
 	str = fmt.Sprintf("%s %35s = %+v\n", str, "IdentityChainID", state.IdentityChainID)
 	str = fmt.Sprintf("%s %35s = %+v\n", str, "Identities", state.Identities)
@@ -99,7 +103,7 @@ func PrintState(state *State) {
 	str = fmt.Sprintf("%s %35s = %+v\n", str, "ShutdownChan", state.ShutdownChan)
 	str = fmt.Sprintf("%s %35s = %+v\n", str, "JournalFile", state.JournalFile)
 	str = fmt.Sprintf("%s %35s = %+v\n", str, "Journaling", state.Journaling)
-	str = fmt.Sprintf("%s %35s = %+v\n", str, "serverPrivKey", state.serverPrivKey)
+	str = fmt.Sprintf("%s %35s = %+v\n", str, "serverSigner", state.serverSigner)
 	str = fmt.Sprintf("%s %35s = %+v\n", str, "serverPubKey", state.serverPubKey)
 	str = fmt.Sprintf("%s %35s = %+v\n", str, "serverPendingPrivKeys", state.serverPendingPrivKeys)
 	str = fmt.Sprintf("%s %35s = %+v\n", str, "serverPendingPubKeys", state.serverPendingPubKeys)
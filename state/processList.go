@@ -112,6 +112,7 @@ type ProcessList struct {
 
 	Requests map[[32]byte]*Request
 	//Requests map[[20]byte]*Request
+	requestsLock        *sync.Mutex // The API reads the requests from another goroutine
 	NextHeightToProcess [64]int
 }
 
@@ -608,6 +609,8 @@ func (p *ProcessList) GetRequest(now int64, vmIndex int, height int, waitSeconds
 	r.vmIndex = vmIndex
 	r.vmheight = uint32(height)
 
+	p.requestsLock.Lock()
+	defer p.requestsLock.Unlock()
 	if p.Requests[r.key()] == nil {
 		r.sent = now + 2000
 		p.Requests[r.key()] = r
@@ -639,12 +642,14 @@ func (p *ProcessList) Ask(vmIndex int, height int, waitSeconds int64, tag int) i
 			vm = p.VMs[vmIndex]
 		}
 
+		p.requestsLock.Lock()
 		for k := range p.Requests {
 			r2 := p.Requests[k]
 			if r2.vmIndex == vmIndex && int(r2.vmheight) < vm.Height {
 				delete(p.Requests, k)
 			}
 		}
+		p.requestsLock.Unlock()
 
 		missingMsgRequest.AddHeight(uint32(height))
 		// Okay, we are going to send one, so ask for all nil messages for this vm
@@ -1161,7 +1166,9 @@ func (p *ProcessList) Reset() bool {
 	// Make a copy of the previous FedServers
 	p.System.List = p.System.List[:0]
 	p.System.Height = 0
+	p.requestsLock.Lock()
 	p.Requests = make(map[[32]byte]*Request)
+	p.requestsLock.Unlock()
 	//pl.Requests = make(map[[20]byte]*Request)
 
 	p.FactoidBalancesT = map[[32]byte]int64{}
@@ -1271,6 +1278,7 @@ func NewProcessList(state interfaces.IState, previous *ProcessList, dbheight uin
 	pl.AuditServers = make([]interfaces.IServer, 0)
 	pl.Requests = make(map[[32]byte]*Request)
 	//pl.Requests = make(map[[20]byte]*Request)
+	pl.requestsLock = new(sync.Mutex)
 
 	pl.FactoidBalancesT = map[[32]byte]int64{}
 	pl.ECBalancesT = map[[32]byte]int64{}
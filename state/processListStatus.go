@@ -0,0 +1,200 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/FactomProject/factomd/common/messages"
+)
+
+// A structured view of the process lists, for monitoring.  ProcessList.String() is the
+// human readable version of the same.
+
+type ProcessListStatus struct {
+	DBHeight     uint32
+	Complete     bool
+	PrevDBState  string // what became of the previous block: nil, constructing, saved or signed
+	Minute       int    // minute used to map VMs to their leaders
+	FedServers   []ProcessListServer
+	AuditServers []ProcessListServer
+	VMs          []VMStatus
+	System       VMStatus // system wide messages (full faults)
+	Requests     []RequestStatus
+}
+
+type ProcessListServer struct {
+	ChainID string
+	Online  bool
+}
+
+type VMStatus struct {
+	VMIndex      int
+	Leader       string // chain ID of the VM's leader in Minute
+	Height       int    // messages processed
+	ListLength   int
+	LeaderMinute int
+	Synced       bool
+	Signed       bool
+	Faulted      bool
+	FaultFlag    int   `json:",omitempty"`
+	Missing      []int // heights below ListLength without a message
+	Messages     []VMMessageStatus
+}
+
+type VMMessageStatus struct {
+	Height    int
+	Hash      string
+	Type      string
+	Acked     bool
+	Processed bool
+	Leader    string `json:",omitempty"` // chain ID in the ack
+}
+
+// RequestStatus is an outstanding request for missing messages
+type RequestStatus struct {
+	VMIndex  int // -1 for the system VM
+	VMHeight uint32
+	Wait     int64 // seconds we wait between asks
+	LastSent int64 // milliseconds
+	Count    int   // number of times asked
+}
+
+// ProcessListsStatus holds the process lists being built, at the current and the next height
+type ProcessListsStatus struct {
+	Current *ProcessListStatus
+	Next    *ProcessListStatus
+}
+
+// How long the API waits for the ValidatorLoop to describe the process lists
+const ProcessListStatusTimeout = 5 * time.Second
+
+// GetProcessListStatus returns a *ProcessListsStatus.  The process lists belong to the
+// ValidatorLoop, so the status is taken there and handed back.
+func (s *State) GetProcessListStatus() (interface{}, error) {
+	reply := make(chan *ProcessListsStatus, 1)
+	timeout := time.After(ProcessListStatusTimeout)
+	select {
+	case s.processListStatusQueue <- reply:
+	case <-timeout:
+		return nil, fmt.Errorf("The validator did not respond")
+	}
+	select {
+	case status := <-reply:
+		return status, nil
+	case <-timeout:
+		return nil, fmt.Errorf("The validator did not respond")
+	}
+}
+
+// processListsStatus runs on the ValidatorLoop.  It only looks at the process lists that
+// exist, it doesn't make them.
+func (s *State) processListsStatus() *ProcessListsStatus {
+	ret := new(ProcessListsStatus)
+	ret.Current = s.ProcessLists.GetSafe(s.LLeaderHeight).Status(s.CurrentMinute)
+	ret.Next = s.ProcessLists.GetSafe(s.LLeaderHeight + 1).Status(0)
+	return ret
+}
+
+// Status describes the process list.  Leaders are those of the given minute.
+func (p *ProcessList) Status(minute int) *ProcessListStatus {
+	if p == nil {
+		return nil
+	}
+	if minute < 0 {
+		minute = 0
+	}
+	if minute > 9 {
+		minute = 9
+	}
+
+	st := new(ProcessListStatus)
+	st.DBHeight = p.DBHeight
+	st.Complete = p.Complete()
+	st.Minute = minute
+	pdbs := p.State.DBStates.Get(int(p.DBHeight - 1))
+	switch {
+	case pdbs == nil:
+		st.PrevDBState = "nil"
+	case pdbs.Signed:
+		st.PrevDBState = "signed"
+	case pdbs.Saved:
+		st.PrevDBState = "saved"
+	default:
+		st.PrevDBState = "constructing"
+	}
+
+	st.FedServers = []ProcessListServer{}
+	for _, fed := range p.FedServers {
+		st.FedServers = append(st.FedServers, ProcessListServer{ChainID: fed.GetChainID().String(), Online: fed.IsOnline()})
+	}
+	st.AuditServers = []ProcessListServer{}
+	for _, aud := range p.AuditServers {
+		st.AuditServers = append(st.AuditServers, ProcessListServer{ChainID: aud.GetChainID().String(), Online: aud.IsOnline()})
+	}
+
+	st.VMs = []VMStatus{}
+	for i := 0; i < len(p.FedServers) && i < len(p.VMs); i++ {
+		vs := vmStatus(i, p.VMs[i])
+		if index := p.ServerMap[minute][i]; index < len(p.FedServers) {
+			vs.Leader = p.FedServers[index].GetChainID().String()
+		}
+		st.VMs = append(st.VMs, vs)
+	}
+	st.System = vmStatus(-1, &p.System)
+
+	st.Requests = []RequestStatus{}
+	p.requestsLock.Lock()
+	for _, r := range p.Requests {
+		vm := &p.System
+		if r.vmIndex >= 0 && r.vmIndex < len(p.VMs) {
+			vm = p.VMs[r.vmIndex]
+		}
+		if int(r.vmheight) < vm.Height {
+			continue // Satisfied, it just hasn't been cleaned up
+		}
+		st.Requests = append(st.Requests, RequestStatus{VMIndex: r.vmIndex, VMHeight: r.vmheight, Wait: r.wait, LastSent: r.sent, Count: r.requestCnt})
+	}
+	p.requestsLock.Unlock()
+	sort.Slice(st.Requests, func(i, j int) bool {
+		if st.Requests[i].VMIndex != st.Requests[j].VMIndex {
+			return st.Requests[i].VMIndex < st.Requests[j].VMIndex
+		}
+		return st.Requests[i].VMHeight < st.Requests[j].VMHeight
+	})
+	return st
+}
+
+func vmStatus(index int, vm *VM) VMStatus {
+	vs := VMStatus{
+		VMIndex:      index,
+		Height:       vm.Height,
+		ListLength:   len(vm.List),
+		LeaderMinute: vm.LeaderMinute,
+		Synced:       vm.Synced,
+		Signed:       vm.Signed,
+		Faulted:      vm.WhenFaulted > 0,
+		FaultFlag:    vm.FaultFlag,
+		Missing:      []int{},
+		Messages:     []VMMessageStatus{},
+	}
+	for j, msg := range vm.List {
+		if msg == nil {
+			vs.Missing = append(vs.Missing, j)
+			continue
+		}
+		ms := VMMessageStatus{Height: j, Hash: msg.GetMsgHash().String(), Type: messages.MessageName(msg.Type()), Processed: j < vm.Height}
+		if j < len(vm.ListAck) && vm.ListAck[j] != nil {
+			ms.Acked = true
+			if vm.ListAck[j].LeaderChainID != nil {
+				ms.Leader = vm.ListAck[j].LeaderChainID.String()
+			}
+		}
+		vs.Messages = append(vs.Messages, ms)
+	}
+	return vs
+}
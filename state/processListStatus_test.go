@@ -0,0 +1,74 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package state_test
+
+import (
+	"testing"
+
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/common/messages"
+	"github.com/FactomProject/factomd/common/primitives"
+	. "github.com/FactomProject/factomd/state"
+	"github.com/FactomProject/factomd/testHelper"
+)
+
+func TestProcessListStatus(t *testing.T) {
+	s := testHelper.CreateAndPopulateTestState()
+	pl := NewProcessList(s, nil, 1000)
+	leader := pl.FedServers[0].GetChainID()
+
+	eom := new(messages.EOM)
+	eom.Timestamp = primitives.NewTimestampNow()
+	eom.ChainID = leader
+	ack := new(messages.Ack)
+	ack.LeaderChainID = leader
+	vm := pl.VMs[0]
+	vm.List = []interfaces.IMsg{eom, nil, eom}
+	vm.ListAck = []*messages.Ack{ack, nil, ack}
+	vm.Height = 1
+	pl.GetRequest(0, 0, 1, 5)
+
+	st := pl.Status(12)
+	if st.DBHeight != 1000 || st.Minute != 9 || st.PrevDBState != "nil" || len(st.FedServers) != 1 || len(st.VMs) != 1 {
+		t.Fatalf("Status() got %+v", st)
+	}
+	vs := st.VMs[0]
+	if vs.Leader != leader.String() || vs.Height != 1 || vs.ListLength != 3 {
+		t.Errorf("got VM %+v", vs)
+	}
+	if len(vs.Missing) != 1 || vs.Missing[0] != 1 {
+		t.Errorf("got missing %v, expected [1]", vs.Missing)
+	}
+	if len(vs.Messages) != 2 {
+		t.Fatalf("got %d messages, expected 2", len(vs.Messages))
+	}
+	m := vs.Messages[0]
+	if !m.Processed || !m.Acked || m.Type != "EOM" || m.Hash != eom.GetMsgHash().String() || m.Leader != leader.String() {
+		t.Errorf("got message %+v", m)
+	}
+	if m = vs.Messages[1]; m.Height != 2 || m.Processed {
+		t.Errorf("got message %+v", m)
+	}
+	if len(st.Requests) != 1 || st.Requests[0].VMIndex != 0 || st.Requests[0].VMHeight != 1 || st.Requests[0].Wait != 5 {
+		t.Errorf("got requests %+v", st.Requests)
+	}
+
+	// Once the VM gets past the request, it is no longer outstanding
+	vm.Height = 3
+	if st = pl.Status(0); len(st.Requests) != 0 {
+		t.Errorf("got requests %+v", st.Requests)
+	}
+
+	status, err := s.GetProcessListStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current := status.(*ProcessListsStatus).Current; current == nil || current.DBHeight != s.LLeaderHeight {
+		t.Errorf("GetProcessListStatus() got %+v", current)
+	}
+	if s.ProcessLists.GetSafe(s.LLeaderHeight+100) != nil {
+		t.Errorf("GetSafe() should not make process lists")
+	}
+}
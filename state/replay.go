@@ -27,6 +27,8 @@ type Replay struct {
 	Buckets  [numBuckets]map[[32]byte]int
 	Basetime int // hours since 1970
 	Center   int // Hour of the current time.
+
+	Clock interfaces.IClock // IsTSValid() goes by this clock, the wall clock if nil
 }
 
 var _ interfaces.BinaryMarshallable = (*Replay)(nil)
@@ -157,6 +159,7 @@ func (r *Replay) Save() *Replay {
 	}
 	newr.Basetime = r.Basetime
 	newr.Center = r.Center
+	newr.Clock = r.Clock
 	return newr
 }
 
@@ -231,7 +234,11 @@ func (r *Replay) Valid(mask int, hash [32]byte, timestamp interfaces.Timestamp,
 // this code remembers hashes tested in the past, and rejects the
 // second submission of the same hash.
 func (r *Replay) IsTSValid(mask int, hash interfaces.IHash, timestamp interfaces.Timestamp) bool {
-	return r.IsTSValid_(mask, hash.Fixed(), timestamp, primitives.NewTimestampNow())
+	now := primitives.NewTimestampNow()
+	if r.Clock != nil {
+		now = primitives.NewTimestampFromTime(r.Clock.Now())
+	}
+	return r.IsTSValid_(mask, hash.Fixed(), timestamp, now)
 }
 
 // To make the function testable, the logic accepts the current time
@@ -603,6 +603,7 @@ func (ss *SaveState) RestoreFactomdState(state *State) { //, d *DBState) {
 		state.ECBalancesP[k] = ss.ECBalancesP[k]
 	}
 	state.ECBalancesPMutex.Unlock()
+	state.recountSupply(ss.DBHeight)
 
 	state.Identities = append(state.Identities[:0], ss.Identities...)
 	state.Authorities = append(state.Authorities[:0], ss.Authorities...)
@@ -0,0 +1,154 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package state
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/FactomProject/factomd/common/interfaces"
+	"github.com/FactomProject/factomd/common/messages"
+)
+
+// An orderly shutdown.  Once the API and the network have stopped feeding us, the ValidatorLoop
+// processes the messages already queued, finishes writing the blocks it can save, writes the
+// fast boot state and closes the database.  Anything left when ShutdownTimeout runs out is
+// abandoned; it is picked up from the network again on the next boot.
+
+// DefaultShutdownTimeout is used when ShutdownTimeout is not set
+const DefaultShutdownTimeout = 30
+
+func (s *State) shutdownTimeout() time.Duration {
+	if s.ShutdownTimeout <= 0 {
+		return DefaultShutdownTimeout * time.Second
+	}
+	return time.Duration(s.ShutdownTimeout) * time.Second
+}
+
+// Shutdown stops the ValidatorLoop and waits for it to close the database, for at most
+// ShutdownTimeout seconds.  Returns nil if everything was written.
+func (s *State) Shutdown() error {
+	if !atomic.CompareAndSwapInt32(&s.shuttingDown, 0, 1) {
+		return fmt.Errorf("%s is already shutting down", s.FactomNodeName)
+	}
+	timeout := s.shutdownTimeout()
+	s.shutdownDeadline = time.Now().Add(timeout)
+
+	select {
+	case s.ShutdownChan <- 0:
+	default: // Already asked to stop
+	}
+	select {
+	case err := <-s.shutdownDone:
+		return err
+	case <-time.After(timeout + time.Second):
+		return fmt.Errorf("%s did not shut down within %v", s.FactomNodeName, timeout)
+	}
+}
+
+// IsShuttingDown returns true once a shutdown has started
+func (s *State) IsShuttingDown() bool {
+	return atomic.LoadInt32(&s.shuttingDown) != 0
+}
+
+// shutdown runs the shutdown on the ValidatorLoop, and reports how it went to Shutdown()
+func (s *State) shutdown() {
+	atomic.StoreInt32(&s.shuttingDown, 1)
+	deadline := s.shutdownDeadline
+	if deadline.IsZero() {
+		deadline = time.Now().Add(s.shutdownTimeout())
+	}
+
+	err := s.drainQueues(deadline)
+	if werr := s.finishBlockWrites(deadline); err == nil {
+		err = werr
+	}
+
+	if s.StateSaverStruct.FastBoot {
+		if ferr := s.StateSaverStruct.FlushDBStateList(s.DBStates, s.Network); ferr != nil && err == nil {
+			err = ferr
+		}
+	}
+	s.StateSaverStruct.StopSaving()
+
+	fmt.Println("Closing the Database on", s.GetFactomNodeName())
+	s.dbCloseMutex.Lock()
+	cerr := s.DB.Close()
+	s.dbCloseMutex.Unlock()
+	if cerr != nil && err == nil {
+		err = cerr
+	}
+	s.closeSigner()
+	fmt.Println(s.GetFactomNodeName(), "closed")
+	s.IsRunning = false
+
+	select {
+	case s.shutdownDone <- err:
+	default:
+	}
+}
+
+// writeUnlessShuttingDown runs write, which writes to the database from outside the ValidatorLoop,
+// unless a shutdown has started.  The database is not closed while it runs.  Returns false if
+// write wasn't run.
+func (s *State) writeUnlessShuttingDown(write func()) bool {
+	s.dbCloseMutex.Lock()
+	defer s.dbCloseMutex.Unlock()
+
+	if s.IsShuttingDown() {
+		return false
+	}
+	write()
+	return true
+}
+
+// drainQueues processes the messages that were queued when the shutdown started
+func (s *State) drainQueues(deadline time.Time) error {
+	queued := s.APIQueue().Length() + s.InMsgQueue().Length()
+	for {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%s ran out of time with %d messages queued", s.FactomNodeName, queued)
+		}
+
+		var msg interfaces.IMsg
+		if queued > 0 {
+			msg = s.APIQueue().Dequeue()
+			if msg == nil {
+				msg = s.InMsgQueue().Dequeue()
+			}
+			queued--
+		}
+		if msg != nil {
+			s.JournalMessage(msg)
+			if _, ok := msg.(*messages.Ack); ok {
+				s.ackQueue <- msg
+			} else {
+				s.msgQueue <- msg
+			}
+		}
+
+		// As the ValidatorLoop does; holding is always reviewed, so there is always "progress"
+		for i := 0; i < 10; i++ {
+			p, b := s.Process(), s.UpdateState()
+			if !p && !b {
+				break
+			}
+		}
+		if queued <= 0 && len(s.msgQueue) == 0 && len(s.ackQueue) == 0 {
+			return nil
+		}
+	}
+}
+
+// finishBlockWrites saves the blocks that are ready to be saved
+func (s *State) finishBlockWrites(deadline time.Time) error {
+	for s.DBStates.UpdateState() {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%s ran out of time saving blocks, saved up to %d", s.FactomNodeName, s.GetHighestSavedBlk())
+		}
+	}
+	return nil
+}
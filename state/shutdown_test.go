@@ -0,0 +1,53 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package state_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/FactomProject/factomd/common/messages"
+	"github.com/FactomProject/factomd/common/primitives"
+	. "github.com/FactomProject/factomd/state"
+	"github.com/FactomProject/factomd/testHelper"
+)
+
+func TestShutdown(t *testing.T) {
+	s := testHelper.CreateAndPopulateTestState()
+	s.ShutdownTimeout = 5
+
+	dir, err := ioutil.TempDir("", "shutdown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	s.StateSaverStruct.FastBoot = true
+	s.StateSaverStruct.FastBootLocation = dir
+	s.StateSaverStruct.TmpState = []byte("cached state")
+
+	// Messages queued when we stop are still processed
+	eom := new(messages.EOM)
+	eom.Timestamp = s.GetTimestamp()
+	eom.ChainID = primitives.Sha([]byte("leader"))
+	s.InMsgQueue().Enqueue(eom)
+
+	if err := s.Shutdown(); err != nil {
+		t.Errorf("Shutdown() %v", err)
+	}
+	if !s.IsShuttingDown() || s.InMsgQueue().Length() != 0 {
+		t.Errorf("Shutdown() left %d messages queued", s.InMsgQueue().Length())
+	}
+	if err := s.Shutdown(); err == nil {
+		t.Errorf("Shutdown() twice")
+	}
+
+	// The fast boot state we had is written, rather than waiting for the next interval
+	b, err := ioutil.ReadFile(NetworkIDToFilename(s.Network, dir))
+	if err != nil || !bytes.Equal(b, []byte("cached state")) {
+		t.Errorf("Fast boot state got %q, %v", b, err)
+	}
+}
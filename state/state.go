@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -20,6 +21,7 @@ import (
 
 	"github.com/FactomProject/factomd/common/adminBlock"
 	"github.com/FactomProject/factomd/common/constants"
+	"github.com/FactomProject/factomd/common/entryBlock/specialEntries"
 	"github.com/FactomProject/factomd/common/interfaces"
 	"github.com/FactomProject/factomd/common/messages"
 	"github.com/FactomProject/factomd/common/primitives"
@@ -28,6 +30,7 @@ import (
 	"github.com/FactomProject/factomd/database/leveldb"
 	"github.com/FactomProject/factomd/database/mapdb"
 	"github.com/FactomProject/factomd/p2p"
+	"github.com/FactomProject/factomd/signer"
 	"github.com/FactomProject/factomd/util"
 	"github.com/FactomProject/factomd/wsapi"
 
@@ -70,6 +73,8 @@ type State struct {
 	DBStatesReceivedBase    int
 	DBStatesReceived        []*messages.DBStateMsg
 	LocalServerPrivKey      string
+	LocalServerSigner       string // Where the server key is held instead, see signer.Open()
+	KeystoreOverride        string // The signer given by -keystore, in place of the config's LocalServerSigner
 	DirectoryBlockInSeconds int
 	PortNumber              int
 	Replay                  *Replay
@@ -93,6 +98,9 @@ type State struct {
 	LocalNetworkPort        string
 	LocalSeedURL            string
 	LocalSpecialPeers       string
+	ExternalAddress         string
+	NATTraversal            string
+	NATGateway              string
 	CustomNetworkID         []byte
 	CustomBootstrapIdentity string
 	CustomBootstrapKey      string
@@ -141,6 +149,7 @@ type State struct {
 
 	tickerQueue            chan int
 	timerMsgQueue          chan interfaces.IMsg
+	processListStatusQueue chan chan *ProcessListsStatus // the API's requests for the process lists, see processListStatus.go
 	TimeOffset             interfaces.Timestamp
 	MaxTimeOffset          interfaces.Timestamp
 	networkOutMsgQueue     NetOutMsgQueue
@@ -150,12 +159,20 @@ type State struct {
 	ackQueue               chan interfaces.IMsg
 	msgQueue               chan interfaces.IMsg
 
-	ShutdownChan chan int // For gracefully halting Factom
+	ShutdownChan     chan int // For gracefully halting Factom
+	ShutdownTimeout  int      // Seconds to finish writing before we give up, see shutdown.go
+	shuttingDown     int32
+	shutdownDeadline time.Time
+	shutdownDone     chan error
+	dbCloseMutex     sync.Mutex // Held to write from outside the ValidatorLoop, and to close the database
+
 	JournalFile  string
 	Journaling   bool
 
-	serverPrivKey         *primitives.PrivateKey
+	serverSigner          interfaces.KeySigner // Signs as the server identity, see initServerKeys()
 	serverPubKey          *primitives.PublicKey
+	openSigner            interfaces.KeySigner // Opened from openSignerSpec, kept open across identity changes
+	openSignerSpec        string
 	serverPendingPrivKeys []*primitives.PrivateKey
 	serverPendingPubKeys  []*primitives.PublicKey
 
@@ -273,6 +290,13 @@ type State struct {
 	HighestKnown    uint32
 	HighestAck      uint32
 	AuthorityDeltas string
+	FaultAudit      *FaultAuditLog // Fault negotiations and their outcomes (see faultAudit.go)
+	Mempool         *Mempool       // Messages waiting to get into a block (see mempool.go)
+	MempoolMaxSize  int            // Most commits, reveals and transactions we hold, 0 for no limit
+	MempoolMaxAge   int            // Seconds they may wait in holding, 0 for no limit
+
+	authorityHistory      *adminBlock.AuthorityHistory // Authority sets by height, see authorityHistory.go
+	authorityHistoryMutex sync.Mutex
 
 	// Factom State
 	FactoidState    interfaces.IFactoidState
@@ -293,6 +317,8 @@ type State struct {
 	IsReplaying     bool
 	ReplayTimestamp interfaces.Timestamp
 
+	Clock interfaces.IClock // The time we go by; the wall clock if nil (see SetClock)
+
 	MissingEntryBlockRepeat interfaces.Timestamp
 	// DBlock Height at which node has a complete set of eblocks+entries
 	EntryBlockDBHeightComplete uint32
@@ -333,6 +359,31 @@ type State struct {
 	FERPriority          uint32
 	FERPrioritySetHeight uint32
 
+	// The FER history, see ferHistory.go
+	ferChangeEntry   interfaces.IHash         // The FER entry that set FERChangePrice
+	ferActivated     *specialEntries.FEREpoch // A scheduled change that took effect, waiting for its block
+	ferHistory       []interfaces.IFEREpoch   // The rate epochs recorded
+	ferHistoryHeight uint32                   // The next block to record
+	ferHistoryLoaded bool
+	ferHistoryLive   bool // recordFER records each block, so GoBackfillFERHistory is done
+	ferHistoryMutex  sync.Mutex
+
+	// The balance history, see balanceHistory.go
+	factoidBalanceChanges  map[[32]byte]int64 // Permanent balances changed by the block being processed
+	ecBalanceChanges       map[[32]byte]int64
+	balanceCheckpoints     []uint32 // Heights of the saved checkpoints, in order
+	balanceHistoryHeight   uint32   // The next block to record
+	balanceHistoryLoaded   bool
+	balanceCheckpointCache *balanceCheckpoint
+	balanceHistoryMutex    sync.Mutex
+
+	// The supply statistics, see supply.go
+	supply          SupplyStats // Running totals of the permanent balances
+	factoidsChanged bool        // A factoid balance changed since the totals were published
+	publishedSupply SupplyStats // The totals after the last block applied
+	richList        *RichList   // The largest balances, sorted for publishedSupply.Height
+	supplyMutex     sync.Mutex
+
 	AckChange uint32
 
 	StateSaverStruct StateSaverStruct
@@ -345,6 +396,13 @@ type State struct {
 	SuperVerboseMessages    bool
 	FastBoot                bool
 	FastBootLocation        string
+
+	// Checkpoints, see checkpoints.go
+	FastSync          bool
+	CheckPointList    string
+	checkPoints       map[uint32]string
+	highestCheckPoint uint32
+	vouched           map[uint32]string // KeyMRs the checkpoints vouch for, through the blocks after
 }
 
 var _ interfaces.IState = (*State)(nil)
@@ -411,6 +469,9 @@ func (s *State) Clone(cloneNumber int) interfaces.IState {
 	newState.LocalNetworkPort = s.LocalNetworkPort
 	newState.LocalSeedURL = s.LocalSeedURL
 	newState.LocalSpecialPeers = s.LocalSpecialPeers
+	newState.ExternalAddress = s.ExternalAddress
+	newState.NATTraversal = s.NATTraversal
+	newState.NATGateway = s.NATGateway
 	newState.StartDelayLimit = s.StartDelayLimit
 	newState.CustomNetworkID = s.CustomNetworkID
 
@@ -425,6 +486,12 @@ func (s *State) Clone(cloneNumber int) interfaces.IState {
 	newState.AuthorityServerCount = s.AuthorityServerCount
 
 	newState.FaultTimeout = s.FaultTimeout
+	newState.Clock = s.Clock
+	newState.MempoolMaxSize = s.MempoolMaxSize
+	newState.MempoolMaxAge = s.MempoolMaxAge
+	newState.ShutdownTimeout = s.ShutdownTimeout
+	newState.FastSync = s.FastSync
+	newState.CheckPointList = s.CheckPointList
 	newState.FaultWait = s.FaultWait
 	newState.EOMfaultIndex = s.EOMfaultIndex
 
@@ -568,7 +635,7 @@ func (s *State) GetCurrentMinuteStartTime() int64 {
 }
 
 func (s *State) GetCurrentTime() int64 {
-	return time.Now().UnixNano()
+	return s.GetClock().Now().UnixNano()
 }
 
 func (s *State) IncDBStateAnswerCnt() {
@@ -643,7 +710,14 @@ func (s *State) LoadConfig(filename string, networkFlag string) {
 		s.LocalNetworkPort = cfg.App.LocalNetworkPort
 		s.LocalSeedURL = cfg.App.LocalSeedURL
 		s.LocalSpecialPeers = cfg.App.LocalSpecialPeers
+		s.ExternalAddress = cfg.App.ExternalAddress
+		s.NATTraversal = cfg.App.NATTraversal
+		s.NATGateway = cfg.App.NATGateway
 		s.LocalServerPrivKey = cfg.App.LocalServerPrivKey
+		s.LocalServerSigner = cfg.App.LocalServerSigner
+		if s.KeystoreOverride != "" {
+			s.LocalServerSigner = s.KeystoreOverride
+		}
 		s.FactoshisPerEC = cfg.App.ExchangeRate
 		s.DirectoryBlockInSeconds = cfg.App.DirectoryBlockInSeconds
 		s.PortNumber = cfg.App.PortNumber
@@ -654,6 +728,8 @@ func (s *State) LoadConfig(filename string, networkFlag string) {
 		s.StateSaverStruct.FastBootLocation = cfg.App.FastBootLocation
 		s.FastBoot = cfg.App.FastBoot
 		s.FastBootLocation = cfg.App.FastBootLocation
+		s.FastSync = cfg.App.FastSync
+		s.CheckPointList = cfg.App.CheckPoints
 
 		s.FactomdTLSEnable = cfg.App.FactomdTlsEnabled
 		if cfg.App.FactomdTlsPrivateKey == "/full/path/to/factomdAPIpriv.key" {
@@ -706,6 +782,9 @@ func (s *State) LoadConfig(filename string, networkFlag string) {
 		s.LocalNetworkPort = "8110"
 		s.LocalSeedURL = "https://raw.githubusercontent.com/FactomProject/factomproject.github.io/master/seed/localseed.txt"
 		s.LocalSpecialPeers = ""
+		s.ExternalAddress = ""
+		s.NATTraversal = "NONE"
+		s.NATGateway = ""
 
 		s.LocalServerPrivKey = "4c38c72fc5cdad68f13b74674d3ffb1f3d63a112710868c9b08946553448d26d"
 		s.FactoshisPerEC = 006666
@@ -787,6 +866,8 @@ func (s *State) Init() {
 	s.MissingEntries = make(chan *MissingEntry, 1000)   //Entries I discover are missing from the database
 	s.UpdateEntryHash = make(chan *EntryUpdate, 10000)  //Handles entry hashes and updating Commit maps.
 	s.WriteEntry = make(chan interfaces.IEBEntry, 3000) //Entries to be written to the database
+	s.shutdownDone = make(chan error, 1)
+	s.processListStatusQueue = make(chan chan *ProcessListsStatus, 10)
 
 	if s.Journaling {
 		f, err := os.Create(s.JournalFile)
@@ -796,8 +877,12 @@ func (s *State) Init() {
 		}
 		f.Close()
 	}
+	s.FaultAudit = NewFaultAuditLog(faultAuditFile(s.LogPath))
+	s.Mempool = NewMempool()
+
 	// Set up struct to stop replay attacks
 	s.Replay = new(Replay)
+	s.Replay.Clock = s.Clock
 
 	// Set up maps for the followers
 	s.Holding = make(map[[32]byte]interfaces.IMsg)
@@ -875,6 +960,8 @@ func (s *State) Init() {
 		panic("Bad value for Network in factomd.conf")
 	}
 
+	s.initCheckPoints()
+
 	s.Println("\nRunning on the ", s.Network, "Network")
 	s.Println("\nExchange rate chain id set to ", s.FERChainId)
 	s.Println("\nExchange rate Authority Public Key set to ", s.ExchangeRateAuthorityPublicKey)
@@ -1557,7 +1644,7 @@ func (s *State) IsStalled() bool {
 	stalltime = stalltime * 1.5 * 1e9
 	//fmt.Println("STALL 2", s.CurrentMinuteStartTime/1e9, time.Now().UnixNano()/1e9, stalltime/1e9, (float64(time.Now().UnixNano())-stalltime)/1e9)
 
-	if float64(s.CurrentMinuteStartTime) < float64(time.Now().UnixNano())-stalltime { //-90 seconds was arbitrary
+	if float64(s.CurrentMinuteStartTime) < float64(s.GetClock().Now().UnixNano())-stalltime { //-90 seconds was arbitrary
 		return true
 	}
 
@@ -1575,8 +1662,11 @@ func (s *State) DatabaseContains(hash interfaces.IHash) bool {
 // JournalMessage writes the message to the message journal for debugging
 func (s *State) JournalMessage(msg interfaces.IMsg) {
 	type journalentry struct {
-		Type    byte
-		Message interfaces.IMsg
+		Type      byte
+		Timestamp int64 // milliseconds, so a replay can use the same clock (see journalReplay.go)
+		Local     bool
+		MsgHex    string
+		Message   interfaces.IMsg
 	}
 
 	if s.Journaling && len(s.JournalFile) != 0 {
@@ -1589,6 +1679,9 @@ func (s *State) JournalMessage(msg interfaces.IMsg) {
 
 		e := new(journalentry)
 		e.Type = msg.Type()
+		e.Timestamp = s.GetTimestamp().GetTimeMilli()
+		e.Local = msg.IsLocal()
+		e.MsgHex = journalHex(msg)
 		e.Message = msg
 
 		p, err := json.Marshal(e)
@@ -1685,6 +1778,7 @@ func (s *State) UpdateState() (progress bool) {
 	// check to see ig a holding queue list request has been made
 	s.fillHoldingMap()
 	s.fillAcksMap()
+	s.syncMempool()
 
 entryHashProcessing:
 	for {
@@ -1800,8 +1894,15 @@ func (s *State) SetDirectoryBlockInSeconds(t int) {
 	s.DirectoryBlockInSeconds = t
 }
 
+// GetServerPrivateKey returns the key of the server identity, or nil if the
+// key is held by a signer outside of factomd.
 func (s *State) GetServerPrivateKey() *primitives.PrivateKey {
-	return s.serverPrivKey
+	key, _ := s.serverSigner.(*primitives.PrivateKey)
+	return key
+}
+
+func (s *State) GetServerSigner() interfaces.KeySigner {
+	return s.serverSigner
 }
 
 func (s *State) GetServerPublicKey() *primitives.PublicKey {
@@ -1832,13 +1933,43 @@ func (s *State) GetFactomdVersion() int {
 	return s.FactomdVersion
 }
 
+// initServerKeys sets the key the server signs with.  It is LocalServerPrivKey,
+// unless LocalServerSigner names a keystore or remote signer that holds it.  The
+// signer is only opened again if LocalServerSigner changed, as opening a keystore
+// may ask for its password, and the previous one is closed.
 func (s *State) initServerKeys() {
-	var err error
-	s.serverPrivKey, err = primitives.NewPrivateKeyFromHex(s.LocalServerPrivKey)
+	if s.LocalServerSigner != "" {
+		if s.openSigner == nil || s.openSignerSpec != s.LocalServerSigner {
+			key, err := signer.Open(s.LocalServerSigner)
+			if err != nil {
+				panic("Cannot open the server signer " + s.LocalServerSigner + ": " + err.Error())
+			}
+			s.closeSigner()
+			s.openSigner = key
+			s.openSignerSpec = s.LocalServerSigner
+		}
+		s.serverSigner = s.openSigner
+		s.serverPubKey = new(primitives.PublicKey)
+		copy(s.serverPubKey[:], s.openSigner.Public())
+		return
+	}
+
+	s.closeSigner()
+	key, err := primitives.NewPrivateKeyFromHex(s.LocalServerPrivKey)
 	if err != nil {
 		//panic("Cannot parse Server Private Key from configuration file: " + err.Error())
 	}
-	s.serverPubKey = s.serverPrivKey.Pub
+	s.serverSigner = key
+	s.serverPubKey = key.Pub
+}
+
+// closeSigner closes the signer opened by initServerKeys, if it holds a connection
+func (s *State) closeSigner() {
+	if closer, ok := s.openSigner.(io.Closer); ok {
+		closer.Close()
+	}
+	s.openSigner = nil
+	s.openSignerSpec = ""
 }
 
 func (s *State) Log(level string, message string) {
@@ -1882,11 +2013,26 @@ func (s *State) SetIsDoneReplaying() {
 
 // Returns a millisecond timestamp
 func (s *State) GetTimestamp() interfaces.Timestamp {
-	if s.IsReplaying == true {
-		fmt.Println("^^^^^^^^ IsReplying is true")
+	if s.IsReplaying == true && s.ReplayTimestamp != nil {
 		return s.ReplayTimestamp
 	}
-	return primitives.NewTimestampNow()
+	return primitives.NewTimestampFromTime(s.GetClock().Now())
+}
+
+// GetClock returns the clock the state goes by
+func (s *State) GetClock() interfaces.IClock {
+	if s.Clock == nil {
+		return primitives.SystemClock{}
+	}
+	return s.Clock
+}
+
+// SetClock sets the clock the state goes by, e.g. a *primitives.SimClock in tests
+func (s *State) SetClock(clock interfaces.IClock) {
+	s.Clock = clock
+	if s.Replay != nil {
+		s.Replay.Clock = clock
+	}
 }
 
 func (s *State) GetTimeOffset() interfaces.Timestamp {
@@ -1894,7 +2040,7 @@ func (s *State) GetTimeOffset() interfaces.Timestamp {
 }
 
 func (s *State) Sign(b []byte) interfaces.IFullSignature {
-	return s.serverPrivKey.Sign(b)
+	return s.serverSigner.Sign(b)
 }
 
 func (s *State) GetFactoidState() interfaces.IFactoidState {
@@ -2001,6 +2147,15 @@ func (s *State) GetNetworkNumber() int {
 	return s.NetworkNumber
 }
 
+// GetNetworkController returns the p2p Controller, or nil if we don't have one.  (A nil
+// *p2p.Controller would not compare equal to a nil interface.)
+func (s *State) GetNetworkController() interfaces.INetworkController {
+	if nil == s.NetworkControler {
+		return nil
+	}
+	return s.NetworkControler
+}
+
 func (s *State) GetNetworkName() string {
 	switch s.NetworkNumber {
 	case constants.NETWORK_MAIN:
@@ -249,10 +249,7 @@ skipreview:
 // Checkpoint DBKeyMR
 //***************************************************************
 func CheckDBKeyMR(s *State, ht uint32, hash string) error {
-	if s.Network != "MAIN" && s.Network != "main" {
-		return nil
-	}
-	if val, ok := constants.CheckPoints[ht]; ok {
+	if val := s.GetCheckPoint(ht); val != "" {
 		if val != hash {
 			return fmt.Errorf("%20s CheckPoints at %d DB height failed\n", s.FactomNodeName, ht)
 		}
@@ -403,7 +400,7 @@ func (s *State) AddDBState(isNew bool,
 
 	err := CheckDBKeyMR(s, ht, DBKeyMR)
 	if err != nil {
-		panic(fmt.Errorf("Found block at height %d that didn't match a checkpoint. Got %s, expected %s", ht, DBKeyMR, constants.CheckPoints[ht])) //TODO make failing when given bad blocks fail more elegantly
+		panic(fmt.Errorf("Found block at height %d that didn't match a checkpoint. Got %s, expected %s", ht, DBKeyMR, s.GetCheckPoint(ht))) //TODO make failing when given bad blocks fail more elegantly
 	}
 
 	if ht > s.LLeaderHeight {
@@ -1136,6 +1133,8 @@ func (s *State) ProcessChangeServerKey(dbheight uint32, changeServerKeyMsg inter
 		s.LeaderPL.AdminBlock.AddFederatedServerSigningKey(ask.IdentityChainID, pub)
 	case constants.TYPE_ADD_MATRYOSHKA:
 		s.LeaderPL.AdminBlock.AddMatryoshkaHash(ask.IdentityChainID, ask.Key)
+	case constants.TYPE_ADD_FACTOID_ADDRESS:
+		s.LeaderPL.AdminBlock.AddFactoidAddress(ask.IdentityChainID, ask.Key)
 	}
 	return true
 }
@@ -1427,7 +1426,7 @@ func (s *State) ProcessEOM(dbheight uint32, msg interfaces.IMsg) bool {
 		}
 
 		s.CurrentMinute++
-		s.CurrentMinuteStartTime = time.Now().UnixNano()
+		s.CurrentMinuteStartTime = s.GetClock().Now().UnixNano()
 
 		switch {
 		case s.CurrentMinute < 10:
@@ -1544,6 +1543,10 @@ func (s *State) CheckForIDChange() {
 			panic(err)
 		}
 		s.LocalServerPrivKey = config.App.LocalServerPrivKey
+		s.LocalServerSigner = config.App.LocalServerSigner
+		if s.KeystoreOverride != "" {
+			s.LocalServerSigner = s.KeystoreOverride
+		}
 		s.initServerKeys()
 	}
 }
@@ -1768,6 +1771,7 @@ func (s *State) ProcessFullServerFault(dbheight uint32, msg interfaces.IMsg) boo
 			// If we agree that the server doesn't need to be faulted, we will clear our currentFault
 			// but otherwise do nothing (we do not execute the actual demotion/promotion)
 			//s.AddStatus(fmt.Sprintf("PROCESS Full Fault CLEARING: %s", fullFault.StringWithSigCnt(s)))
+			s.auditFullFault(FaultAuditCleared, fullFault, "the faulted server came back online", "")
 			fullFault.SetAlreadyProcessed()
 			return true
 		}
@@ -1785,6 +1789,7 @@ func (s *State) ProcessFullServerFault(dbheight uint32, msg interfaces.IMsg) boo
 		for _, fedServer := range s.GetFedServers(fullFault.DBHeight) {
 			if fedServer.GetChainID().IsSameAs(fullFault.AuditServerID) {
 				//s.AddStatus(fmt.Sprintf("PROCESS Full Fault Nothing to do, Already a Fed Server! %s", fullFault.StringWithSigCnt(s)))
+				s.auditFullFault(FaultAuditRejected, fullFault, "the nominated audit server is already a federated server", "")
 				return false
 			}
 		}
@@ -1792,6 +1797,7 @@ func (s *State) ProcessFullServerFault(dbheight uint32, msg interfaces.IMsg) boo
 		// that match the nominated Audit Server in the FullFault,
 		// we can't really do anything useful with it
 		//s.AddStatus(fmt.Sprintf("PROCESS Full Fault Audit Server not an audit server. %s", fullFault.StringWithSigCnt(s)))
+		s.auditFullFault(FaultAuditRejected, fullFault, "the nominated server is not an audit server", "")
 		return false
 	}
 
@@ -1841,12 +1847,14 @@ func (s *State) ProcessFullServerFault(dbheight uint32, msg interfaces.IMsg) boo
 					fullFault.ServerID.String()[4:12],
 					fullFault.AuditServerID.String()[4:12])
 				pl.State.AddAuthorityDelta(authorityDeltaString)
+				s.auditFullFault(FaultAuditReplaced, fullFault, fmt.Sprintf("federated server %s replaced by audit server %s",
+					fullFault.ServerID.String()[4:12], fullFault.AuditServerID.String()[4:12]), authorityDeltaString)
 
 				consenLogger.WithFields(log.Fields{"dbht": fullFault.DBHeight, "sysht": fullFault.SystemHeight,
 					"server": fullFault.ServerID.String()[4:12], "audit": fullFault.AuditServerID.String()[4:12]}).Info("Full fault success")
 				//s.AddStatus(authorityDeltaString)
 
-				pl.State.LastFaultAction = time.Now().Unix()
+				pl.State.LastFaultAction = pl.State.GetClock().Now().Unix()
 				markNoFault(pl, fullFault.GetVMIndex())
 				nextIndex := (int(fullFault.VMIndex) + 1) % len(pl.FedServers)
 				if pl.VMs[nextIndex].FaultFlag > 0 {
@@ -1916,7 +1924,7 @@ func (s *State) ProcessFullServerFault(dbheight uint32, msg interfaces.IMsg) boo
 
 		if s.Leader || s.IdentityChainID.IsSameAs(fullFault.AuditServerID) {
 			if !fullFault.GetMyVoteTallied() {
-				now := time.Now().Unix()
+				now := s.GetClock().Now().Unix()
 				if now-fullFault.LastMatch > 5 && int(now-s.LastTiebreak) > s.FaultTimeout/2 {
 					if fullFault.SigTally(s) >= len(pl.FedServers)-1 {
 						s.LastTiebreak = now
@@ -1961,11 +1969,11 @@ func (s *State) SendHeartBeat() {
 		if auditServer.GetChainID().IsSameAs(s.IdentityChainID) {
 			hb := new(messages.Heartbeat)
 			hb.DBHeight = s.LLeaderHeight
-			hb.Timestamp = primitives.NewTimestampNow()
+			hb.Timestamp = s.GetTimestamp()
 			hb.SecretNumber = s.GetSalt(hb.Timestamp)
 			hb.DBlockHash = dbstate.DBHash
 			hb.IdentityChainID = s.IdentityChainID
-			hb.Sign(s.GetServerPrivateKey())
+			hb.Sign(s.GetServerSigner())
 			hb.SendOut(s, hb)
 		}
 	}
@@ -2138,7 +2146,9 @@ func (s *State) PutF(rt bool, adr [32]byte, v int64) {
 	} else {
 		s.FactoidBalancesPMutex.Lock()
 		defer s.FactoidBalancesPMutex.Unlock()
+		s.countBalanceChange(false, s.FactoidBalancesP[adr], v)
 		s.FactoidBalancesP[adr] = v
+		s.noteBalanceChange(false, adr, v)
 	}
 }
 
@@ -2173,7 +2183,9 @@ func (s *State) PutE(rt bool, adr [32]byte, v int64) {
 	} else {
 		s.ECBalancesPMutex.Lock()
 		defer s.ECBalancesPMutex.Unlock()
+		s.countBalanceChange(true, s.ECBalancesP[adr], v)
 		s.ECBalancesP[adr] = v
+		s.noteBalanceChange(true, adr, v)
 	}
 }
 
@@ -39,6 +39,7 @@ type DisplayState struct {
 	Identities      []*Identity
 	Authorities     []*Authority
 	PublicKey       *primitives.PublicKey
+	FaultAudit      []FaultAuditRecord
 
 	// Process List
 	PLFactoid []FactoidTransaction
@@ -137,6 +138,7 @@ func DeepStateDisplayCopy(s *State) (*DisplayState, error) {
 	} else {
 		ds.PublicKey = pubkey
 	}
+	ds.FaultAudit = s.FaultAudit.Records("", 0)
 
 	vms := s.LeaderPL.VMs
 	for _, v := range vms {
@@ -268,6 +270,7 @@ func (d *DisplayState) Clone() *DisplayState {
 	} else {
 		ds.PublicKey = pubkey
 	}
+	ds.FaultAudit = append(ds.FaultAudit, d.FaultAudit...)
 
 	ds.RawSummary = d.RawSummary
 	ds.PrintMap = d.PrintMap
@@ -51,6 +51,11 @@ func (this *State) ProcessRecentFERChainEntries() {
 	// Check to see if a price change targets the next block
 	if this.FERChangeHeight == (this.GetDBHeightComplete())+1 {
 		this.FactoshisPerEC = this.FERChangePrice
+		activated := specialEntries.NewFEREpoch(this.FERChangeHeight, this.FERChangePrice)
+		activated.EntryHash = this.ferChangeEntry
+		activated.Priority = this.FERPriority
+		this.activateFER(activated)
+		this.ferChangeEntry = nil
 		this.FERChangePrice = 0
 		this.FERChangeHeight = 1
 	}
@@ -118,6 +123,7 @@ func (this *State) ProcessRecentFERChainEntries() {
 				this.FERPrioritySetHeight = this.GetDBHeightComplete()
 				this.FERChangePrice = ferEntry.GetTargetPrice()
 				this.FERChangeHeight = ferEntry.GetTargetActivationHeight()
+				this.ferChangeEntry = entryHash
 
 				// Adjust the target if needed
 				if this.FERChangeHeight < (this.GetDBHeightComplete() + 2) {
@@ -23,7 +23,7 @@ type StateSaverStruct struct {
 }
 
 //To be increased whenever the data being saved changes from the last verion
-const version = 6
+const version = 7
 
 func (sss *StateSaverStruct) StopSaving() {
 	sss.Mutex.Lock()
@@ -70,6 +70,31 @@ func (sss *StateSaverStruct) SaveDBStateList(ss *DBStateList, networkName string
 	return nil
 }
 
+// FlushDBStateList writes the fast boot state at shutdown, rather than waiting for the next
+// interval.  If every block in the list has been saved we write the list as it is, otherwise
+// the last state cached by SaveDBStateList.
+func (sss *StateSaverStruct) FlushDBStateList(ss *DBStateList, networkName string) error {
+	sss.Mutex.Lock()
+	defer sss.Mutex.Unlock()
+	if sss.Stop == true {
+		return nil
+	}
+
+	last := ss.Last()
+	if last != nil && last.Saved && last.SaveStruct != nil && ss.GetHighestSavedBlk() >= 1000 {
+		b, err := ss.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		h := primitives.Sha(b)
+		sss.TmpState = append(h.Bytes(), b...)
+	}
+	if len(sss.TmpState) == 0 {
+		return nil
+	}
+	return SaveToFile(sss.TmpState, NetworkIDToFilename(networkName, sss.FastBootLocation))
+}
+
 func (sss *StateSaverStruct) DeleteSaveState(networkName string) error {
 	return DeleteFile(NetworkIDToFilename(networkName, sss.FastBootLocation))
 }
@@ -7,12 +7,18 @@ package state_test
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	//"github.com/FactomProject/factomd/common/constants"
 	"github.com/FactomProject/factomd/common/primitives"
+	"github.com/FactomProject/factomd/signer"
 	"github.com/FactomProject/factomd/state"
 	. "github.com/FactomProject/factomd/state"
 	"github.com/FactomProject/factomd/testHelper"
@@ -289,3 +295,101 @@ func TestIsStalled(t *testing.T) {
 	}
 
 }
+
+// countingListener counts the connections a remote signer accepts
+type countingListener struct {
+	net.Listener
+	accepted int32
+}
+
+func (l *countingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err == nil {
+		atomic.AddInt32(&l.accepted, 1)
+	}
+	return conn, err
+}
+
+func TestServerSignerOpenedOnce(t *testing.T) {
+	dir, err := ioutil.TempDir("", "factomd-signer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	key := primitives.RandomPrivateKey()
+	l, err := net.Listen("unix", filepath.Join(dir, "signer.sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	counter := &countingListener{Listener: l}
+	go signer.ServeRemote(counter, key)
+
+	cfg := filepath.Join(dir, "factomd.conf")
+	conf := "[app]\nIdentityChainID = 38bab1455b7bd7e5efd15c53c777c79d0c988e9210f1da49a99d95b3a6417be9\n" +
+		"LocalServerSigner = remote:" + filepath.Join(dir, "signer.sock") + "\n"
+	if err := ioutil.WriteFile(cfg, []byte(conf), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	s := testHelper.CreateEmptyTestState()
+	s.LoadConfig(cfg, "LOCAL")
+	s.AckChange = 1
+	s.LLeaderHeight = 1
+
+	// The identity is reloaded every block past AckChange, but the signer is opened once
+	s.CheckForIDChange()
+	first := s.GetServerSigner()
+	for i := 0; i < 3; i++ {
+		s.CheckForIDChange()
+	}
+	if s.GetServerSigner() != first {
+		t.Error("The server signer was opened again")
+	}
+	if n := atomic.LoadInt32(&counter.accepted); n != 1 {
+		t.Errorf("The remote signer was dialled %d times, wanted once", n)
+	}
+	if !bytes.Equal(s.GetServerPublicKey()[:], key.Public()) {
+		t.Error("Wrong server public key")
+	}
+}
+
+func TestKeystoreOverride(t *testing.T) {
+	dir, err := ioutil.TempDir("", "factomd-signer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	key := primitives.RandomPrivateKey()
+	l, err := net.Listen("unix", filepath.Join(dir, "signer.sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go signer.ServeRemote(l, key)
+
+	// The config names a signer that isn't there, so only the override can be opened
+	cfg := filepath.Join(dir, "factomd.conf")
+	conf := "[app]\nIdentityChainID = 38bab1455b7bd7e5efd15c53c777c79d0c988e9210f1da49a99d95b3a6417be9\n" +
+		"LocalServerSigner = remote:" + filepath.Join(dir, "missing.sock") + "\n"
+	if err := ioutil.WriteFile(cfg, []byte(conf), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	s := testHelper.CreateEmptyTestState()
+	s.KeystoreOverride = "remote:" + filepath.Join(dir, "signer.sock")
+	s.LoadConfig(cfg, "LOCAL")
+	s.AckChange = 1
+	s.LLeaderHeight = 1
+
+	// Reloading the identity keeps the signer given on the command line
+	s.CheckForIDChange()
+	if s.LocalServerSigner != s.KeystoreOverride {
+		t.Errorf("LocalServerSigner is %s, wanted %s", s.LocalServerSigner, s.KeystoreOverride)
+	}
+	if !bytes.Equal(s.GetServerPublicKey()[:], key.Public()) {
+		t.Error("Wrong server public key")
+	}
+}
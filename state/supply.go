@@ -0,0 +1,172 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package state
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/FactomProject/factomd/common/factoid"
+	"github.com/FactomProject/factomd/common/primitives"
+)
+
+// The supply statistics are running totals of the permanent balances, kept up as each
+// balance changes and published when a block has been applied.  Queries only see the
+// totals published, never those of a block half applied.  The rich list is sorted from
+// the balances when asked for, at most once a block, and only while they are still those
+// of the block published.
+
+// The most holders a rich list returns
+var MaxRichList = 1000
+
+// SupplyStats are the totals of the balances after the block at Height
+type SupplyStats struct {
+	Height                 uint32 `json:"height"`
+	FactoidSupply          int64  `json:"factoidsupply"` // In factoshis
+	ECOutstanding          int64  `json:"ecoutstanding"`
+	FundedFactoidAddresses int    `json:"fundedfactoidaddresses"`
+	FundedECAddresses      int    `json:"fundedecaddresses"`
+}
+
+// RichList is the factoid addresses with the largest balances after the block at Height
+type RichList struct {
+	Height  uint32          `json:"height"`
+	Holders []RichListEntry `json:"holders"`
+}
+
+type RichListEntry struct {
+	Address string `json:"address"`
+	Balance int64  `json:"balance"`
+}
+
+// countBalanceChange adds the change of a permanent balance from old to v to the totals.
+// The caller holds the mutex of the balances changed.
+func (s *State) countBalanceChange(ec bool, old, v int64) {
+	total, funded := &s.supply.FactoidSupply, &s.supply.FundedFactoidAddresses
+	if ec {
+		total, funded = &s.supply.ECOutstanding, &s.supply.FundedECAddresses
+	} else {
+		s.factoidsChanged = true
+	}
+	*total += v - old
+	if old <= 0 && v > 0 {
+		*funded++
+	}
+	if old > 0 && v <= 0 {
+		*funded--
+	}
+}
+
+// recountSupply totals the permanent balances again, after they have been replaced by
+// those after the block at dbheight
+func (s *State) recountSupply(dbheight uint32) {
+	s.FactoidBalancesPMutex.Lock()
+	s.supply.FactoidSupply, s.supply.FundedFactoidAddresses = 0, 0
+	for _, v := range s.FactoidBalancesP {
+		s.countBalanceChange(false, 0, v)
+	}
+	s.FactoidBalancesPMutex.Unlock()
+
+	s.ECBalancesPMutex.Lock()
+	s.supply.ECOutstanding, s.supply.FundedECAddresses = 0, 0
+	for _, v := range s.ECBalancesP {
+		s.countBalanceChange(true, 0, v)
+	}
+	s.ECBalancesPMutex.Unlock()
+
+	s.updateSupply(dbheight)
+}
+
+// updateSupply publishes the totals once the block at dbheight has been applied
+func (s *State) updateSupply(dbheight uint32) {
+	s.FactoidBalancesPMutex.Lock()
+	s.ECBalancesPMutex.Lock()
+	s.supply.Height = dbheight
+	stats := s.supply
+	s.factoidsChanged = false
+	s.supplyMutex.Lock()
+	s.publishedSupply = stats
+	s.supplyMutex.Unlock()
+	s.ECBalancesPMutex.Unlock()
+	s.FactoidBalancesPMutex.Unlock()
+
+	FactoidSupply.Set(float64(stats.FactoidSupply))
+	ECOutstanding.Set(float64(stats.ECOutstanding))
+	FundedFactoidAddresses.Set(float64(stats.FundedFactoidAddresses))
+	FundedECAddresses.Set(float64(stats.FundedECAddresses))
+}
+
+// GetSupply returns the totals of the permanent balances after the last block applied,
+// as a *SupplyStats
+func (s *State) GetSupply() interface{} {
+	s.supplyMutex.Lock()
+	defer s.supplyMutex.Unlock()
+
+	stats := s.publishedSupply
+	return &stats
+}
+
+// GetRichList returns the count factoid addresses with the largest balances, as a *RichList.
+// While a block is being applied it is the list sorted for an earlier block, which is
+// empty if none was.
+func (s *State) GetRichList(count int) interface{} {
+	if count > MaxRichList {
+		count = MaxRichList
+	}
+
+	s.FactoidBalancesPMutex.Lock()
+	s.supplyMutex.Lock()
+	if !s.factoidsChanged && (s.richList == nil || s.richList.Height != s.publishedSupply.Height) {
+		s.richList = s.sortRichList()
+	}
+	sorted := s.richList
+	s.supplyMutex.Unlock()
+	s.FactoidBalancesPMutex.Unlock()
+
+	list := new(RichList)
+	if sorted == nil {
+		return list
+	}
+	list.Height = sorted.Height
+	list.Holders = sorted.Holders
+	if count >= 0 && count < len(list.Holders) {
+		list.Holders = list.Holders[:count]
+	}
+	return list
+}
+
+// sortRichList sorts the MaxRichList largest factoid balances.  The caller holds
+// FactoidBalancesPMutex and supplyMutex.
+func (s *State) sortRichList() *RichList {
+	type holder struct {
+		adr     [32]byte
+		balance int64
+	}
+	holders := make([]holder, 0, s.publishedSupply.FundedFactoidAddresses)
+	for adr, v := range s.FactoidBalancesP {
+		if v > 0 {
+			holders = append(holders, holder{adr, v})
+		}
+	}
+	// Ties go by address, so the list doesn't change order from one call to the next
+	sort.Slice(holders, func(i, j int) bool {
+		if holders[i].balance != holders[j].balance {
+			return holders[i].balance > holders[j].balance
+		}
+		return bytes.Compare(holders[i].adr[:], holders[j].adr[:]) < 0
+	})
+	if len(holders) > MaxRichList {
+		holders = holders[:MaxRichList]
+	}
+
+	list := new(RichList)
+	list.Height = s.publishedSupply.Height
+	list.Holders = make([]RichListEntry, len(holders))
+	for i, h := range holders {
+		list.Holders[i].Address = primitives.ConvertFctAddressToUserStr(factoid.NewAddress(h.adr[:]))
+		list.Holders[i].Balance = h.balance
+	}
+	return list
+}
@@ -0,0 +1,75 @@
+// Copyright 2017 Factom Foundation
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package state_test
+
+import (
+	"testing"
+
+	. "github.com/FactomProject/factomd/state"
+	"github.com/FactomProject/factomd/testHelper"
+)
+
+func TestSupply(t *testing.T) {
+	s := testHelper.CreatePopulateAndExecuteTestState()
+
+	total := func(balances map[[32]byte]int64) (sum int64, funded int) {
+		for _, v := range balances {
+			sum += v
+			if v > 0 {
+				funded++
+			}
+		}
+		return
+	}
+	check := func() *SupplyStats {
+		stats := s.GetSupply().(*SupplyStats)
+		s.FactoidBalancesPMutex.Lock()
+		sum, funded := total(s.FactoidBalancesP)
+		s.FactoidBalancesPMutex.Unlock()
+		if stats.FactoidSupply != sum || stats.FundedFactoidAddresses != funded {
+			t.Errorf("Factoid supply %d in %d addresses, expected %d in %d", stats.FactoidSupply, stats.FundedFactoidAddresses, sum, funded)
+		}
+		s.ECBalancesPMutex.Lock()
+		sum, funded = total(s.ECBalancesP)
+		s.ECBalancesPMutex.Unlock()
+		if stats.ECOutstanding != sum || stats.FundedECAddresses != funded {
+			t.Errorf("EC outstanding %d in %d addresses, expected %d in %d", stats.ECOutstanding, stats.FundedECAddresses, sum, funded)
+		}
+		return stats
+	}
+
+	stats := check()
+	if stats.Height != s.GetHighestSavedBlk() || stats.FactoidSupply == 0 || stats.ECOutstanding == 0 {
+		t.Errorf("GetSupply() got %+v", stats)
+	}
+
+	list := s.GetRichList(MaxRichList).(*RichList)
+	if list.Height != stats.Height || len(list.Holders) == 0 || len(list.Holders) != stats.FundedFactoidAddresses {
+		t.Fatalf("GetRichList() got %d holders at %d, expected %d at %d", len(list.Holders), list.Height, stats.FundedFactoidAddresses, stats.Height)
+	}
+	for i := 1; i < len(list.Holders); i++ {
+		if list.Holders[i].Balance > list.Holders[i-1].Balance {
+			t.Errorf("GetRichList() holder %d has more than %d", i, i-1)
+		}
+	}
+
+	// Changes made while a block is applied are not seen until it is published
+	var adr [32]byte
+	adr[0] = 0xAA
+	s.PutF(false, adr, 1e12)
+	s.PutE(false, adr, 5)
+	if during := s.GetSupply().(*SupplyStats); *during != *stats {
+		t.Errorf("GetSupply() got %+v while applying a block, expected %+v", during, stats)
+	}
+	if during := s.GetRichList(MaxRichList).(*RichList); len(during.Holders) != len(list.Holders) || during.Holders[0] != list.Holders[0] {
+		t.Errorf("GetRichList() got %d holders at %d while applying a block", len(during.Holders), during.Height)
+	}
+	s.PutF(false, adr, 0)
+	s.PutE(false, adr, 0)
+
+	if top := s.GetRichList(1).(*RichList); len(top.Holders) != 1 || top.Holders[0] != list.Holders[0] {
+		t.Errorf("GetRichList(1) got %+v", top.Holders)
+	}
+}
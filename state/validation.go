@@ -5,7 +5,6 @@
 package state
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/FactomProject/factomd/common/interfaces"
@@ -18,11 +17,7 @@ func (state *State) ValidatorLoop() {
 		// Check if we should shut down.
 		select {
 		case <-state.ShutdownChan:
-			fmt.Println("Closing the Database on", state.GetFactomNodeName())
-			state.DB.Close()
-			state.StateSaverStruct.StopSaving()
-			fmt.Println(state.GetFactomNodeName(), "closed")
-			state.IsRunning = false
+			state.shutdown()
 			return
 		default:
 		}
@@ -47,6 +42,12 @@ func (state *State) ValidatorLoop() {
 				default:
 				}
 
+				select {
+				case reply := <-state.processListStatusQueue:
+					reply <- state.processListsStatus()
+				default:
+				}
+
 				select {
 				case msg = <-state.TimerMsgQueue():
 					state.JournalMessage(msg)
@@ -69,18 +70,53 @@ func (state *State) ValidatorLoop() {
 
 		// Sort the messages.
 		if msg != nil {
-			if state.IsReplaying == true {
-				state.ReplayTimestamp = msg.GetTimestamp()
-			}
-			if _, ok := msg.(*messages.Ack); ok {
-				state.ackQueue <- msg
-			} else {
-				state.msgQueue <- msg
+			for _, msg := range state.commitBurst(msg) {
+				if state.IsReplaying == true {
+					state.ReplayTimestamp = msg.GetTimestamp()
+				}
+				if _, ok := msg.(*messages.Ack); ok {
+					state.ackQueue <- msg
+				} else {
+					state.msgQueue <- msg
+				}
 			}
 		}
 	}
 }
 
+// The most messages taken from the InMsgQueue at once to verify the commits among them
+const MaxCommitBurst = 64
+
+// commitBurst returns msg and, if it is a commit, the messages queued behind it, as
+// many as our queues have room for.  The signatures of the commits among them are
+// verified together, so a burst of commits is checked on all our cores.
+func (state *State) commitBurst(msg interfaces.IMsg) []interfaces.IMsg {
+	msgs := []interfaces.IMsg{msg}
+	switch msg.(type) {
+	case *messages.CommitChainMsg, *messages.CommitEntryMsg:
+	default:
+		return msgs
+	}
+
+	room := cap(state.msgQueue) - len(state.msgQueue)
+	if ackRoom := cap(state.ackQueue) - len(state.ackQueue); ackRoom < room {
+		room = ackRoom
+	}
+	for len(msgs) < MaxCommitBurst && len(msgs) < room && state.InMsgQueue().Length() > 0 {
+		next := state.InMsgQueue().Dequeue()
+		if next == nil {
+			break
+		}
+		state.JournalMessage(next)
+		msgs = append(msgs, next)
+	}
+
+	if len(msgs) > 1 {
+		messages.ValidateCommits(msgs)
+	}
+	return msgs
+}
+
 type Timer struct {
 	lastMin      int
 	lastDBHeight uint32
@@ -5,6 +5,7 @@ package testHelper
 import (
 	"encoding/hex"
 
+	"github.com/FactomProject/factomd/common/constants"
 	"github.com/FactomProject/factomd/common/factoid"
 	"github.com/FactomProject/factomd/common/interfaces"
 	"github.com/FactomProject/factomd/common/primitives"
@@ -30,7 +31,7 @@ func CreateTestFactoidBlock(prev interfaces.IFBlock) interfaces.IFBlock {
 
 	SignFactoidTransaction(0, ecTx)
 
-	err = fBlock.AddTransaction(ecTx)
+	err = fBlock.AddTransaction(constants.LOCAL_NETWORK_ID, ecTx)
 	if err != nil {
 		panic(err)
 	}
@@ -42,7 +43,7 @@ func CreateTestFactoidBlockWithTransaction(prev interfaces.IFBlock, sentSecret s
 	fBlock := CreateTestFactoidBlockWithCoinbase(prev, NewFactoidAddress(0), DefaultCoinbaseAmount)
 
 	for i := 0; i < 5; i++ {
-		err := fBlock.AddTransaction(newTrans(fBlock.GetDatabaseHeight(), sentSecret, recievePublic, amt))
+		err := fBlock.AddTransaction(constants.LOCAL_NETWORK_ID, newTrans(fBlock.GetDatabaseHeight(), sentSecret, recievePublic, amt))
 		if err != nil {
 			panic(err)
 		}
@@ -30,10 +30,13 @@ type FactomdConfig struct {
 		ExportDataSubpath                      string
 		FastBoot                               bool
 		FastBootLocation                       string
+		FastSync                               bool
+		CheckPoints                            string
 		NodeMode                               string
 		IdentityChainID                        string
 		LocalServerPrivKey                     string
 		LocalServerPublicKey                   string
+		LocalServerSigner                      string
 		ExchangeRate                           uint64
 		ExchangeRateChainId                    string
 		ExchangeRateAuthorityPublicKey         string
@@ -53,6 +56,9 @@ type FactomdConfig struct {
 		LocalNetworkPort        string
 		LocalSeedURL            string
 		LocalSpecialPeers       string
+		ExternalAddress         string
+		NATTraversal            string
+		NATGateway              string
 		CustomBootstrapIdentity string
 		CustomBootstrapKey      string
 		FactomdTlsEnabled       bool
@@ -118,6 +124,10 @@ ExportData                            = false
 ExportDataSubpath                     = "database/export/"
 FastBoot                              = true
 FastBootLocation                      = ""
+; --------------- FastSync: trust blocks up to the highest checkpoint; only chaining and KeyMRs are checked
+FastSync                              = false
+; --------------- CheckPoints: extra trusted directory block KeyMRs, as height:keymr,height:keymr
+CheckPoints                           = ""
 ; --------------- Network: MAIN | TEST | LOCAL
 Network                               = MAIN
 PeersFile            = "peers.json"
@@ -130,12 +140,19 @@ TestSpecialPeers     = ""
 LocalNetworkPort     = 8110
 LocalSeedURL         = "https://raw.githubusercontent.com/FactomProject/factomproject.github.io/master/seed/localseed.txt"
 LocalSpecialPeers    = ""
+; --------------- ExternalAddress: address[:port] peers can reach us at, if we can't work it out ourselves
+ExternalAddress      = ""
+; --------------- NATTraversal: NONE | ANY | UPNP | NATPMP  (ask the router to forward our network port)
+NATTraversal         = NONE
+NATGateway           = ""
 CustomBootstrapIdentity     = 38bab1455b7bd7e5efd15c53c777c79d0c988e9210f1da49a99d95b3a6417be9
 CustomBootstrapKey          = cc1985cdfae4e32b5a454dfda8ce5e1361558482684f3367649c3ad852c8e31a
 ; --------------- NodeMode: FULL | SERVER ----------------
 NodeMode                                = FULL
 LocalServerPrivKey                      = 4c38c72fc5cdad68f13b74674d3ffb1f3d63a112710868c9b08946553448d26d
 LocalServerPublicKey                    = cc1985cdfae4e32b5a454dfda8ce5e1361558482684f3367649c3ad852c8e31a
+; --------------- LocalServerSigner: keystore:<path>[#name] | remote:<unix socket>; replaces LocalServerPrivKey if set
+LocalServerSigner                       = ""
 ExchangeRateChainId                     = 111111118d918a8be684e0dac725493a75862ef96d2d3f43f84b26969329bf03
 ExchangeRateAuthorityPublicKeyMainNet   = daf5815c2de603dbfa3e1e64f88a5cf06083307cf40da4a9b539c41832135b4a
 ExchangeRateAuthorityPublicKeyTestNet   = 1d75de249c2fc0384fb6701b30dc86b39dc72e5a47ba4f79ef250d39e21e7a4f
@@ -208,6 +225,8 @@ func (s *FactomdConfig) String() string {
 	out.WriteString(fmt.Sprintf("\n    DirectoryBlockInSeconds %v", s.App.DirectoryBlockInSeconds))
 	out.WriteString(fmt.Sprintf("\n    ExportData              %v", s.App.ExportData))
 	out.WriteString(fmt.Sprintf("\n    ExportDataSubpath       %v", s.App.ExportDataSubpath))
+	out.WriteString(fmt.Sprintf("\n    FastSync                %v", s.App.FastSync))
+	out.WriteString(fmt.Sprintf("\n    CheckPoints             %v", s.App.CheckPoints))
 	out.WriteString(fmt.Sprintf("\n    Network                 %v", s.App.Network))
 	out.WriteString(fmt.Sprintf("\n    MainNetworkPort         %v", s.App.MainNetworkPort))
 	out.WriteString(fmt.Sprintf("\n    PeersFile               %v", s.App.PeersFile))
@@ -219,12 +238,16 @@ func (s *FactomdConfig) String() string {
 	out.WriteString(fmt.Sprintf("\n    LocalNetworkPort        %v", s.App.LocalNetworkPort))
 	out.WriteString(fmt.Sprintf("\n    LocalSeedURL            %v", s.App.LocalSeedURL))
 	out.WriteString(fmt.Sprintf("\n    LocalSpecialPeers       %v", s.App.LocalSpecialPeers))
+	out.WriteString(fmt.Sprintf("\n    ExternalAddress         %v", s.App.ExternalAddress))
+	out.WriteString(fmt.Sprintf("\n    NATTraversal            %v", s.App.NATTraversal))
+	out.WriteString(fmt.Sprintf("\n    NATGateway              %v", s.App.NATGateway))
 	out.WriteString(fmt.Sprintf("\n    CustomBootstrapIdentity %v", s.App.CustomBootstrapIdentity))
 	out.WriteString(fmt.Sprintf("\n    CustomBootstrapKey      %v", s.App.CustomBootstrapKey))
 	out.WriteString(fmt.Sprintf("\n    NodeMode                %v", s.App.NodeMode))
 	out.WriteString(fmt.Sprintf("\n    IdentityChainID         %v", s.App.IdentityChainID))
 	out.WriteString(fmt.Sprintf("\n    LocalServerPrivKey      %v", s.App.LocalServerPrivKey))
 	out.WriteString(fmt.Sprintf("\n    LocalServerPublicKey    %v", s.App.LocalServerPublicKey))
+	out.WriteString(fmt.Sprintf("\n    LocalServerSigner       %v", s.App.LocalServerSigner))
 	out.WriteString(fmt.Sprintf("\n    ExchangeRate            %v", s.App.ExchangeRate))
 	out.WriteString(fmt.Sprintf("\n    ExchangeRateChainId     %v", s.App.ExchangeRateChainId))
 	out.WriteString(fmt.Sprintf("\n    ExchangeRateAuthorityPublicKey   %v", s.App.ExchangeRateAuthorityPublicKey))
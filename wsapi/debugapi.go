@@ -99,6 +99,9 @@ func HandleDebugRequest(
 	case "set-drop-rate":
 		resp, jsonError = HandleSetDropRate(state, params)
 		break
+	case "fault-audit":
+		resp, jsonError = HandleFaultAudit(state, params)
+		break
 	case "federated-servers":
 		resp, jsonError = HandleFedServers(state, params)
 		break
@@ -120,9 +123,30 @@ func HandleDebugRequest(
 	case "process-list":
 		resp, jsonError = HandleProcessList(state, params)
 		break
+	case "mempool":
+		resp, jsonError = HandleMempool(state, params)
+		break
+	case "process-list-status":
+		resp, jsonError = HandleProcessListStatus(state, params)
+		break
 	case "reload-configuration":
 		resp, jsonError = HandleReloadConfig(state, params)
 		break
+	case "peers":
+		resp, jsonError = HandlePeers(state, params)
+		break
+	case "dial-peer":
+		resp, jsonError = HandleDialPeer(state, params)
+		break
+	case "disconnect-peer":
+		resp, jsonError = HandleDisconnectPeer(state, params)
+		break
+	case "ban-peer":
+		resp, jsonError = HandleBanPeer(state, params)
+		break
+	case "unban-peer":
+		resp, jsonError = HandleUnbanPeer(state, params)
+		break
 	default:
 		jsonError = NewMethodNotFoundError()
 		break
@@ -171,6 +195,30 @@ func HandleAuthorities(
 	return r, nil
 }
 
+func HandleFaultAudit(
+	state interfaces.IState,
+	params interface{},
+) (
+	interface{},
+	*primitives.JSONError,
+) {
+	type ret struct {
+		FaultAudit interface{} `json:"faultaudit"`
+	}
+	r := new(ret)
+
+	req := new(FaultAuditRequest)
+	if params != nil {
+		err := MapToObject(params, req)
+		if err != nil {
+			return nil, NewInvalidParamsError()
+		}
+	}
+
+	r.FaultAudit = state.GetFaultAuditTrail(req.ServerID, req.DBHeight)
+	return r, nil
+}
+
 func HandleConfig(
 	state interfaces.IState,
 	params interface{},
@@ -392,6 +440,37 @@ func HandleProcessList(
 	return r, nil
 }
 
+func HandleProcessListStatus(
+	state interfaces.IState,
+	params interface{},
+) (
+	interface{},
+	*primitives.JSONError,
+) {
+	status, err := state.GetProcessListStatus()
+	if err != nil {
+		return nil, NewCustomInternalError(err.Error())
+	}
+	return status, nil
+}
+
+func HandleMempool(
+	state interfaces.IState,
+	params interface{},
+) (
+	interface{},
+	*primitives.JSONError,
+) {
+	req := new(MempoolRequest)
+	if params != nil {
+		err := MapToObject(params, req)
+		if err != nil || req.Limit < 0 {
+			return nil, NewInvalidParamsError()
+		}
+	}
+	return state.GetMempool(req.ChainID, req.Address, req.Type, req.Limit), nil
+}
+
 func HandleReloadConfig(
 	state interfaces.IState,
 	params interface{},
@@ -405,10 +484,136 @@ func HandleReloadConfig(
 	return state.GetCfg(), nil
 }
 
+func HandlePeers(
+	state interfaces.IState,
+	params interface{},
+) (
+	interface{},
+	*primitives.JSONError,
+) {
+	controller := state.GetNetworkController()
+	if controller == nil {
+		return nil, NewCustomInternalError("No network controller")
+	}
+	status, err := controller.GetNetworkStatus()
+	if err != nil {
+		return nil, NewCustomInternalError(err.Error())
+	}
+	return status, nil
+}
+
+func HandleDialPeer(
+	state interfaces.IState,
+	params interface{},
+) (
+	interface{},
+	*primitives.JSONError,
+) {
+	dial := new(DialPeerRequest)
+	err := MapToObject(params, dial)
+	if err != nil || dial.Address == "" {
+		return nil, NewInvalidParamsError()
+	}
+	return peerControl(state, func(controller interfaces.INetworkController) error {
+		return controller.DialPeerAddress(dial.Address, dial.Persistent)
+	})
+}
+
+func HandleDisconnectPeer(
+	state interfaces.IState,
+	params interface{},
+) (
+	interface{},
+	*primitives.JSONError,
+) {
+	peer := new(PeerRequest)
+	err := MapToObject(params, peer)
+	if err != nil || peer.Peer == "" {
+		return nil, NewInvalidParamsError()
+	}
+	return peerControl(state, func(controller interfaces.INetworkController) error {
+		return controller.DisconnectPeer(peer.Peer)
+	})
+}
+
+func HandleBanPeer(
+	state interfaces.IState,
+	params interface{},
+) (
+	interface{},
+	*primitives.JSONError,
+) {
+	peer := new(PeerRequest)
+	err := MapToObject(params, peer)
+	if err != nil || peer.Peer == "" {
+		return nil, NewInvalidParamsError()
+	}
+	return peerControl(state, func(controller interfaces.INetworkController) error {
+		return controller.BanPeer(peer.Peer)
+	})
+}
+
+func HandleUnbanPeer(
+	state interfaces.IState,
+	params interface{},
+) (
+	interface{},
+	*primitives.JSONError,
+) {
+	unban := new(UnbanPeerRequest)
+	err := MapToObject(params, unban)
+	if err != nil || unban.Address == "" {
+		return nil, NewInvalidParamsError()
+	}
+	return peerControl(state, func(controller interfaces.INetworkController) error {
+		return controller.UnbanPeer(unban.Address)
+	})
+}
+
+// peerControl runs one of the peer control calls and reports the outcome
+func peerControl(state interfaces.IState, control func(interfaces.INetworkController) error) (interface{}, *primitives.JSONError) {
+	type ret struct {
+		Success bool
+	}
+	controller := state.GetNetworkController()
+	if controller == nil {
+		return nil, NewCustomInternalError("No network controller")
+	}
+	if err := control(controller); err != nil {
+		return nil, NewCustomInternalError(err.Error())
+	}
+	return &ret{Success: true}, nil
+}
+
 type SetDelayRequest struct {
 	Delay int64 `json:"delay"`
 }
 
+type FaultAuditRequest struct {
+	ServerID string `json:"serverid"` // only negotiations about this identity chain, as faulted or nominated server
+	DBHeight uint32 `json:"dbheight"` // only from this height on
+}
+
 type SetDropRateRequest struct {
 	DropRate int `json:"droprate"`
 }
+
+type DialPeerRequest struct {
+	Address    string `json:"address"` // address:port
+	Persistent bool   `json:"persistent"`
+}
+
+type PeerRequest struct {
+	Peer string `json:"peer"` // peer hash, address or address:port
+}
+
+type UnbanPeerRequest struct {
+	Address string `json:"address"`
+}
+
+type MempoolRequest struct {
+	ChainID string `json:"chainid"` // only messages about this chain
+	Address string `json:"address"` // only messages with this FCT or EC address
+	Type    string `json:"type"`    // only this message type, e.g. "Commit Entry"
+	Limit   int    `json:"limit"`   // at most this many messages, 0 for all
+}
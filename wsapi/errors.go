@@ -47,6 +47,9 @@ func NewCustomInternalError(data interface{}) *primitives.JSONError {
 func NewCustomInvalidParamsError(data interface{}) *primitives.JSONError {
 	return primitives.NewJSONError(-32602, "Invalid params", data)
 }
+func NewCustomBlockNotFoundError(data interface{}) *primitives.JSONError {
+	return primitives.NewJSONError(-32008, "Block not found", data)
+}
 
 /*******************************************************************/
 
@@ -50,16 +50,31 @@ var (
 		Help: "Time it takes to compelete a ecbal",
 	})
 
+	HandleV2APICallECBalAt = prometheus.NewSummary(prometheus.SummaryOpts{
+		Name: "factomd_wsapi_v2_api_call_ecbalat_ns",
+		Help: "Time it takes to compelete a ecbalat",
+	})
+
 	HandleV2APICallECRate = prometheus.NewSummary(prometheus.SummaryOpts{
 		Name: "factomd_wsapi_v2_api_call_ecrate_ns",
 		Help: "Time it takes to compelete a ecrate",
 	})
 
+	HandleV2APICallECRateHistory = prometheus.NewSummary(prometheus.SummaryOpts{
+		Name: "factomd_wsapi_v2_api_call_ecratehistory_ns",
+		Help: "Time it takes to compelete an ecratehistory",
+	})
+
 	HandleV2APICallFABal = prometheus.NewSummary(prometheus.SummaryOpts{
 		Name: "factomd_wsapi_v2_api_call_fabal_ns",
 		Help: "Time it takes to compelete a fabal",
 	})
 
+	HandleV2APICallFABalAt = prometheus.NewSummary(prometheus.SummaryOpts{
+		Name: "factomd_wsapi_v2_api_call_fabalat_ns",
+		Help: "Time it takes to compelete a fabalat",
+	})
+
 	HandleV2APICallFctTx = prometheus.NewSummary(prometheus.SummaryOpts{
 		Name: "factomd_wsapi_v2_api_call_fcttx_ns",
 		Help: "Time it takes to compelete a fcttx",
@@ -80,6 +95,16 @@ var (
 		Help: "Time it takes to compelete a prop",
 	})
 
+	HandleV2APICallRichList = prometheus.NewSummary(prometheus.SummaryOpts{
+		Name: "factomd_wsapi_v2_api_call_richlist_ns",
+		Help: "Time it takes to compelete a richlist",
+	})
+
+	HandleV2APICallSupply = prometheus.NewSummary(prometheus.SummaryOpts{
+		Name: "factomd_wsapi_v2_api_call_supply_ns",
+		Help: "Time it takes to compelete a supply",
+	})
+
 	HandleV2APICallRawData = prometheus.NewSummary(prometheus.SummaryOpts{
 		Name: "factomd_wsapi_v2_api_call_rawdata_ns",
 		Help: "Time it takes to compelete a rawdata",
@@ -155,6 +180,11 @@ var (
 		Help: "Time it takes to compelete an auths ",
 	})
 
+	HandleV2APICallAuthoritiesAtHeight = prometheus.NewSummary(prometheus.SummaryOpts{
+		Name: "factomd_wsapi_v2_api_call_authsatheight_ns",
+		Help: "Time it takes to compelete an authsatheight",
+	})
+
 	HandleV2APICallTpsRate = prometheus.NewSummary(prometheus.SummaryOpts{
 		Name: "factomd_wsapi_v2_api_call_tpsrate_ns",
 		Help: "Time it takes to compelete a tpsrate",
@@ -180,11 +210,16 @@ func RegisterPrometheus() {
 	prometheus.MustRegister(HandleV2APICallEblock)
 	prometheus.MustRegister(HandleV2APICallEntry)
 	prometheus.MustRegister(HandleV2APICallECBal)
+	prometheus.MustRegister(HandleV2APICallECBalAt)
 	prometheus.MustRegister(HandleV2APICallECRate)
+	prometheus.MustRegister(HandleV2APICallECRateHistory)
 	prometheus.MustRegister(HandleV2APICallFABal)
+	prometheus.MustRegister(HandleV2APICallFABalAt)
 	prometheus.MustRegister(HandleV2APICallFctTx)
 	prometheus.MustRegister(HandleV2APICallHeights)
 	prometheus.MustRegister(HandleV2APICallProp)
+	prometheus.MustRegister(HandleV2APICallRichList)
+	prometheus.MustRegister(HandleV2APICallSupply)
 	prometheus.MustRegister(HandleV2APICallRawData)
 	prometheus.MustRegister(HandleV2APICallReceipt)
 	prometheus.MustRegister(HandleV2APICallRevealEntry)
@@ -200,5 +235,6 @@ func RegisterPrometheus() {
 	prometheus.MustRegister(HandleV2APICallFblockByHeight)
 	prometheus.MustRegister(HandleV2APICallABlockByHeight)
 	prometheus.MustRegister(HandleV2APICallAuthorities)
+	prometheus.MustRegister(HandleV2APICallAuthoritiesAtHeight)
 	prometheus.MustRegister(HandleV2APICallTpsRate)
 }
@@ -131,11 +131,14 @@ func SetState(state interfaces.IState) {
 	go wait()
 }
 
+// Stop closes the API server, so it takes no more requests
 func Stop(state interfaces.IState) {
 	ServersMutex.Lock()
 	defer ServersMutex.Unlock()
 
-	Servers[state.GetPort()].Close()
+	if server := Servers[state.GetPort()]; server != nil {
+		server.Close()
+	}
 }
 
 func handleV1Error(ctx *web.Context, err *primitives.JSONError) {
@@ -115,6 +115,11 @@ type FactoidBalanceResponse struct {
 	Balance int64 `json:"balance"`
 }
 
+type BalanceAtResponse struct {
+	Balance int64 `json:"balance"`
+	Height  int64 `json:"height"`
+}
+
 type EntryCreditRateResponse struct {
 	Rate int64 `json:"rate"`
 }
@@ -227,10 +232,24 @@ type AddressRequest struct {
 	Address string `json:"address"`
 }
 
+type CountRequest struct {
+	Count int64 `json:"count"`
+}
+
+type AddressHeightRequest struct {
+	Address string `json:"address"`
+	Height  int64  `json:"height"`
+}
+
 type HeightRequest struct {
 	Height int64 `json:"height"`
 }
 
+type HeightRangeRequest struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
 type ChainIDRequest struct {
 	ChainID string `json:"chainid"`
 }
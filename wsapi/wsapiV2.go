@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"net/http"
 	"reflect"
 	"strings"
@@ -107,12 +108,21 @@ func HandleV2Request(state interfaces.IState, j *primitives.JSON2Request) (*prim
 	case "entry-credit-balance":
 		resp, jsonError = HandleV2EntryCreditBalance(state, params)
 		break
+	case "entry-credit-balance-at":
+		resp, jsonError = HandleV2EntryCreditBalanceAt(state, params)
+		break
 	case "entry-credit-rate":
 		resp, jsonError = HandleV2EntryCreditRate(state, params)
 		break
+	case "entry-credit-rate-history":
+		resp, jsonError = HandleV2EntryCreditRateHistory(state, params)
+		break
 	case "factoid-balance":
 		resp, jsonError = HandleV2FactoidBalance(state, params)
 		break
+	case "factoid-balance-at":
+		resp, jsonError = HandleV2FactoidBalanceAt(state, params)
+		break
 	case "factoid-submit":
 		resp, jsonError = HandleV2FactoidSubmit(state, params)
 		break
@@ -128,12 +138,18 @@ func HandleV2Request(state interfaces.IState, j *primitives.JSON2Request) (*prim
 	case "receipt":
 		resp, jsonError = HandleV2Receipt(state, params)
 		break
+	case "rich-list":
+		resp, jsonError = HandleV2RichList(state, params)
+		break
 	case "reveal-chain":
 		resp, jsonError = HandleV2RevealChain(state, params)
 		break
 	case "reveal-entry":
 		resp, jsonError = HandleV2RevealEntry(state, params)
 		break
+	case "supply":
+		resp, jsonError = HandleV2Supply(state, params)
+		break
 	case "factoid-ack":
 		resp, jsonError = HandleV2FactoidACK(state, params)
 		break
@@ -166,6 +182,8 @@ func HandleV2Request(state interfaces.IState, j *primitives.JSON2Request) (*prim
 		break
 	case "authorities":
 		resp, jsonError = HandleAuthorities(state, params)
+	case "authorities-at-height":
+		resp, jsonError = HandleV2AuthoritiesAtHeight(state, params)
 	case "tps-rate":
 		resp, jsonError = HandleV2TransactionRate(state, params)
 	case "ack":
@@ -433,6 +451,26 @@ func HandleV2ABlockByHeight(state interfaces.IState, params interface{}) (interf
 	return aBlockToResp(block)
 }
 
+func HandleV2AuthoritiesAtHeight(state interfaces.IState, params interface{}) (interface{}, *primitives.JSONError) {
+	n := time.Now()
+	defer HandleV2APICallAuthoritiesAtHeight.Observe(float64(time.Since(n).Nanoseconds()))
+
+	heightRequest := new(HeightRequest)
+	err := MapToObject(params, heightRequest)
+	if err != nil || heightRequest.Height < 0 {
+		return nil, NewInvalidParamsError()
+	}
+	if uint32(heightRequest.Height) > state.GetHighestSavedBlk() {
+		return nil, NewBlockNotFoundError()
+	}
+
+	set, err := state.GetAuthoritiesAtHeight(uint32(heightRequest.Height))
+	if err != nil {
+		return nil, NewInternalDatabaseError()
+	}
+	return set, nil
+}
+
 func aBlockToResp(block interfaces.IAdminBlock) (interface{}, *primitives.JSONError) {
 	raw, err := block.MarshalBinary()
 	if err != nil {
@@ -975,6 +1013,51 @@ func HandleV2EntryCreditBalance(state interfaces.IState, params interface{}) (in
 	return resp, nil
 }
 
+func HandleV2EntryCreditBalanceAt(state interfaces.IState, params interface{}) (interface{}, *primitives.JSONError) {
+	n := time.Now()
+	defer HandleV2APICallECBalAt.Observe(float64(time.Since(n).Nanoseconds()))
+
+	adr, height, jsonError := addressAtHeight(params, primitives.ValidateECUserStr)
+	if jsonError != nil {
+		return nil, jsonError
+	}
+	balance, err := state.GetECBalanceAt(adr, height)
+	if err != nil {
+		return nil, NewBlockNotFoundError()
+	}
+	resp := new(BalanceAtResponse)
+	resp.Balance = balance
+	resp.Height = int64(height)
+	return resp, nil
+}
+
+// addressAtHeight reads an AddressHeightRequest, with the address as a user string
+// that passes validUserStr or in hex
+func addressAtHeight(params interface{}, validUserStr func(string) bool) ([32]byte, uint32, *primitives.JSONError) {
+	var fixed [32]byte
+	req := new(AddressHeightRequest)
+	req.Height = -1
+	err := MapToObject(params, req)
+	if err != nil || req.Height < 0 || req.Height > math.MaxUint32 {
+		return fixed, 0, NewInvalidParamsError()
+	}
+
+	var adr []byte
+	if validUserStr(req.Address) {
+		adr = primitives.ConvertUserStrToAddress(req.Address)
+	} else {
+		adr, err = hex.DecodeString(req.Address)
+		if err != nil {
+			return fixed, 0, NewInvalidAddressError()
+		}
+	}
+	if len(adr) != constants.HASH_LENGTH {
+		return fixed, 0, NewInvalidAddressError()
+	}
+	copy(fixed[:], adr)
+	return fixed, uint32(req.Height), nil
+}
+
 func HandleV2EntryCreditRate(state interfaces.IState, params interface{}) (interface{}, *primitives.JSONError) {
 	n := time.Now()
 	defer HandleV2APICallECRate.Observe(float64(time.Since(n).Nanoseconds()))
@@ -985,6 +1068,32 @@ func HandleV2EntryCreditRate(state interfaces.IState, params interface{}) (inter
 	return resp, nil
 }
 
+func HandleV2EntryCreditRateHistory(state interfaces.IState, params interface{}) (interface{}, *primitives.JSONError) {
+	n := time.Now()
+	defer HandleV2APICallECRateHistory.Observe(float64(time.Since(n).Nanoseconds()))
+
+	height, err := state.GetFERHistoryHeight()
+	if err != nil {
+		return nil, NewCustomBlockNotFoundError(err.Error())
+	}
+
+	// Without a range, the whole history
+	rangeRequest := &HeightRangeRequest{From: 0, To: int64(height)}
+	err = MapToObject(params, rangeRequest)
+	if err != nil || rangeRequest.From < 0 || rangeRequest.To < rangeRequest.From {
+		return nil, NewInvalidParamsError()
+	}
+	if rangeRequest.To > int64(height) {
+		return nil, NewCustomBlockNotFoundError(fmt.Sprintf("The FER history is not recorded up to block %d yet", rangeRequest.To))
+	}
+
+	history, err := state.GetFERHistory(uint32(rangeRequest.From), uint32(rangeRequest.To))
+	if err != nil {
+		return nil, NewInternalDatabaseError()
+	}
+	return history, nil
+}
+
 func HandleV2FactoidSubmit(state interfaces.IState, params interface{}) (interface{}, *primitives.JSONError) {
 	n := time.Now()
 	defer HandleV2APICallFctTx.Observe(float64(time.Since(n).Nanoseconds()))
@@ -1006,6 +1115,15 @@ func HandleV2FactoidSubmit(state interfaces.IState, params interface{}) (interfa
 	if err != nil {
 		return nil, NewUnableToDecodeTransactionError()
 	}
+	if err = factoid.CheckRCDActivation(msg.Transaction, state.GetNetworkID(), state.GetLLeaderHeight()); err != nil {
+		return nil, NewInvalidTransactionError()
+	}
+	if err = factoid.CheckVersionActivation(msg.Transaction, state.GetNetworkID(), state.GetLLeaderHeight()); err != nil {
+		return nil, NewInvalidTransactionError()
+	}
+	if err = factoid.CheckLockReachable(msg.Transaction, state.GetLLeaderHeight(), state.GetDirectoryBlockInSeconds()); err != nil {
+		return nil, NewInvalidTransactionError()
+	}
 
 	state.IncFCTSubmits()
 
@@ -1051,6 +1169,24 @@ func HandleV2FactoidBalance(state interfaces.IState, params interface{}) (interf
 	return resp, nil
 }
 
+func HandleV2FactoidBalanceAt(state interfaces.IState, params interface{}) (interface{}, *primitives.JSONError) {
+	n := time.Now()
+	defer HandleV2APICallFABalAt.Observe(float64(time.Since(n).Nanoseconds()))
+
+	adr, height, jsonError := addressAtHeight(params, primitives.ValidateFUserStr)
+	if jsonError != nil {
+		return nil, jsonError
+	}
+	balance, err := state.GetFactoidBalanceAt(adr, height)
+	if err != nil {
+		return nil, NewBlockNotFoundError()
+	}
+	resp := new(BalanceAtResponse)
+	resp.Balance = balance
+	resp.Height = int64(height)
+	return resp, nil
+}
+
 func HandleV2Heights(state interfaces.IState, params interface{}) (interface{}, *primitives.JSONError) {
 	n := time.Now()
 	defer HandleV2APICallHeights.Observe(float64(time.Since(n).Nanoseconds()))
@@ -1116,6 +1252,28 @@ func HandleV2Properties(state interfaces.IState, params interface{}) (interface{
 	return p, nil
 }
 
+func HandleV2Supply(state interfaces.IState, params interface{}) (interface{}, *primitives.JSONError) {
+	n := time.Now()
+	defer HandleV2APICallSupply.Observe(float64(time.Since(n).Nanoseconds()))
+
+	return state.GetSupply(), nil
+}
+
+func HandleV2RichList(state interfaces.IState, params interface{}) (interface{}, *primitives.JSONError) {
+	n := time.Now()
+	defer HandleV2APICallRichList.Observe(float64(time.Since(n).Nanoseconds()))
+
+	// Without a count, the top 100
+	req := &CountRequest{Count: 100}
+	if params != nil {
+		err := MapToObject(params, req)
+		if err != nil || req.Count < 1 || req.Count > 1000 {
+			return nil, NewInvalidParamsError()
+		}
+	}
+	return state.GetRichList(int(req.Count)), nil
+}
+
 func HandleV2SendRawMessage(state interfaces.IState, params interface{}) (interface{}, *primitives.JSONError) {
 	n := time.Now()
 	defer HandleV2APICallSendRaw.Observe(float64(time.Since(n).Nanoseconds()))